@@ -6,6 +6,9 @@ import (
 	"path/filepath"
 	"strings"
 	"text/template"
+	"time"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
 )
 
 func main() {
@@ -42,7 +45,18 @@ func main() {
 		"service.go":    serviceTemplate,
 		"graphql.go":    graphqlTemplate,
 		"init.go":       initTemplate,
+		"module.go":     moduleTemplate,
+		"migrations.go": migrationsTemplate,
+	}
+
+	// Migração inicial da tabela do módulo
+	if err := os.MkdirAll(filepath.Join(moduleDir, "migrations"), 0755); err != nil {
+		fmt.Printf("Erro ao criar diretório de migrações: %v\n", err)
+		os.Exit(1)
 	}
+	migrationName := fmt.Sprintf("migrations/%s_create_%s_table", time.Now().UTC().Format(database.MigrationVersionFormat), moduleName)
+	files[migrationName+".up.sql"] = migrationUpTemplate
+	files[migrationName+".down.sql"] = migrationDownTemplate
 
 	for filename, tmplContent := range files {
 		filepath := filepath.Join(moduleDir, filename)
@@ -66,4 +80,5 @@ func main() {
 	}
 
 	fmt.Printf("Módulo %s criado em %s\n", moduleName, moduleDir)
+	fmt.Printf("Importe o pacote em internal/app/modules.go para registrá-lo:\n\t_ \"github.com/rafaelcoelhox/labbend/internal/%s\"\n", moduleName)
 }
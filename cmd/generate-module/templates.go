@@ -7,13 +7,95 @@ package {{.ModuleName}}
 
 const initTemplate = `package {{.ModuleName}}
 
+import "github.com/rafaelcoelhox/labbend/pkg/module"
+
+// init - registra automaticamente o módulo {{.ModuleName}}
+func init() {
+	module.Register(module.Definition{
+		Name: ModuleName,
+		// DependsOn: []string{"users"}, // módulos cujo service este módulo usa
+		New:        NewModule,
+		Migrations: migrations,
+		Models:     models,
+	})
+}
+`
+
+const migrationsTemplate = `package {{.ModuleName}}
+
+import "embed"
+
+// migrations - migrações SQL versionadas do módulo (aplicadas por cmd/migrate)
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+`
+
+// Migrações iniciais: novas mudanças de schema vão em arquivos novos
+// (go run ./cmd/migrate -module <nome> create <descrição>)
+const migrationUpTemplate = `CREATE TABLE IF NOT EXISTS "{{.ModuleName}}" (
+    "id" bigserial,
+    "nome" text NOT NULL,
+    "descricao" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_{{.ModuleName}}_deleted_at" ON "{{.ModuleName}}" ("deleted_at");
+`
+
+const migrationDownTemplate = `DROP TABLE IF EXISTS "{{.ModuleName}}";
+`
+
+const moduleTemplate = `package {{.ModuleName}}
+
 import (
-	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/graphql-go/graphql"
+
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/module"
 )
 
-func init() {
-	// Registre aqui os modelos do GORM para migration automática
-	database.RegisterModel(&{{.ModuleNameCap}}{})
+// ModuleName - nome do módulo no registry (usado em DependsOn)
+const ModuleName = "{{.ModuleName}}"
+
+// Module - registro do módulo {{.ModuleName}} na aplicação
+// module.Base fornece defaults vazios; sobrescreva Subscriptions, EventSubscriptions,
+// Jobs, Routes ou WithRequestContext quando precisar
+type Module struct {
+	module.Base
+	service Service
+	logger  logger.Logger
+}
+
+// NewModule - factory registrada no init.go
+func NewModule(deps *module.Deps) (module.Module, error) {
+	repo := NewRepository(deps.DB)
+	return &Module{
+		service: NewService(repo, deps.Logger),
+		logger:  deps.Logger,
+	}, nil
+}
+
+func (m *Module) Name() string         { return ModuleName }
+func (m *Module) Service() interface{} { return m.service }
+
+func (m *Module) Queries() graphql.Fields {
+	return *Queries(m.service, m.logger)
+}
+
+func (m *Module) Mutations() graphql.Fields {
+	return *Mutations(m.service, m.logger)
+}
+
+// models - modelos do GORM; o schema do PostgreSQL vem de migrations/ e o
+// do SQLite do AutoMigrate destes modelos
+var models = []interface{}{&{{.ModuleNameCap}}{}}
+
+// Models - modelos do GORM do módulo
+func (m *Module) Models() []interface{} {
+	return models
 }
 `
 
@@ -35,6 +117,11 @@ type {{.ModuleNameCap}} struct {
 	DeletedAt gorm.DeletedAt ` + "`" + `gorm:"index" json:"deleted_at"` + "`" + `
 }
 
+// TableName - tabela criada em migrations/
+func ({{.ModuleNameCap}}) TableName() string {
+	return "{{.ModuleName}}"
+}
+
 // Create{{.ModuleNameCap}}Input - input para criar nova entidade
 // Adicione aqui os campos necessários para criação
 type Create{{.ModuleNameCap}}Input struct {
@@ -45,7 +132,7 @@ type Create{{.ModuleNameCap}}Input struct {
 // Update{{.ModuleNameCap}}Input - input para atualizar entidade
 // Adicione aqui os campos que podem ser atualizados
 type Update{{.ModuleNameCap}}Input struct {
-	Nome      *string ` + "`" + `json:"nome,omitempty"` + "`" + `
+	Nome      *string ` + "`" + `json:"nome,omitempty" validate:"omitempty,notblank"` + "`" + `
 	Descricao *string ` + "`" + `json:"descricao,omitempty"` + "`" + `
 }
 `
@@ -54,7 +141,10 @@ const repositoryTemplate = `package {{.ModuleName}}
 
 import (
 	"context"
+
 	"gorm.io/gorm"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
 )
 
 // Repository - interface para operações de banco de dados
@@ -75,30 +165,31 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-// Implementação básica dos métodos CRUD
+// Implementação básica dos métodos CRUD (database.FromContext usa a transação
+// ativa no contexto, quando houver)
 // Customize conforme suas necessidades
 func (r *repository) Create(ctx context.Context, {{.ModuleName}} *{{.ModuleNameCap}}) error {
-	return r.db.WithContext(ctx).Create({{.ModuleName}}).Error
+	return database.FromContext(ctx, r.db).Create({{.ModuleName}}).Error
 }
 
 func (r *repository) GetByID(ctx context.Context, id uint) (*{{.ModuleNameCap}}, error) {
 	var {{.ModuleName}} {{.ModuleNameCap}}
-	err := r.db.WithContext(ctx).First(&{{.ModuleName}}, id).Error
+	err := database.FromContext(ctx, r.db).First(&{{.ModuleName}}, id).Error
 	return &{{.ModuleName}}, err
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]{{.ModuleNameCap}}, error) {
 	var {{.ModuleName}}s []{{.ModuleNameCap}}
-	err := r.db.WithContext(ctx).Find(&{{.ModuleName}}s).Error
+	err := database.FromContext(ctx, r.db).Find(&{{.ModuleName}}s).Error
 	return {{.ModuleName}}s, err
 }
 
 func (r *repository) Update(ctx context.Context, id uint, input Update{{.ModuleNameCap}}Input) error {
-	return r.db.WithContext(ctx).Model(&{{.ModuleNameCap}}{}).Where("id = ?", id).Updates(input).Error
+	return database.FromContext(ctx, r.db).Model(&{{.ModuleNameCap}}{}).Where("id = ?", id).Updates(input).Error
 }
 
 func (r *repository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&{{.ModuleNameCap}}{}, id).Error
+	return database.FromContext(ctx, r.db).Delete(&{{.ModuleNameCap}}{}, id).Error
 }
 `
 
@@ -107,6 +198,7 @@ const serviceTemplate = `package {{.ModuleName}}
 import (
 	"context"
 	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/validation"
 	"go.uber.org/zap"
 )
 
@@ -135,7 +227,11 @@ func NewService(repo Repository, logger logger.Logger) Service {
 // Implementação dos métodos de negócio
 // Adicione aqui validações, regras de negócio, etc.
 func (s *service) Create(ctx context.Context, input Create{{.ModuleNameCap}}Input) (*{{.ModuleNameCap}}, error) {
-	// Adicione validações aqui
+	// Tags validate do input; regras de negócio vêm depois
+	if err := validation.Struct(input); err != nil {
+		return nil, err
+	}
+
 	{{.ModuleName}} := &{{.ModuleNameCap}}{
 		Nome:      input.Nome,
 		Descricao: input.Descricao,
@@ -159,7 +255,9 @@ func (s *service) GetAll(ctx context.Context) ([]{{.ModuleNameCap}}, error) {
 }
 
 func (s *service) Update(ctx context.Context, id uint, input Update{{.ModuleNameCap}}Input) error {
-	// Adicione validações aqui
+	if err := validation.Struct(input); err != nil {
+		return err
+	}
 	return s.repo.Update(ctx, id, input)
 }
 
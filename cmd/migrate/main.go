@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"gorm.io/gorm/logger"
+
+	"github.com/rafaelcoelhox/labbend/internal/app"
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+)
+
+const usage = `Uso: migrate [flags] <comando>
+
+Comandos:
+  up                  aplica as migrações pendentes
+  down N              desfaz as últimas N migrações aplicadas
+  status              lista as migrações e o estado de cada uma
+  create <nome>       cria o par .up.sql/.down.sql em -dir (ou internal/<-module>/migrations)
+
+Flags:
+`
+
+var namePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// migrate - migrações SQL versionadas dos módulos (tabela schema_migrations)
+func main() {
+	dsn := flag.String("dsn", os.Getenv("DATABASE_URL"), "DSN do PostgreSQL (padrão: $DATABASE_URL)")
+	moduleName := flag.String("module", "", "módulo da nova migração (create)")
+	dir := flag.String("dir", "", "diretório da nova migração (create; padrão: internal/<module>/migrations)")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "create":
+		if len(args) != 2 {
+			fail("Uso: migrate -module <módulo> create <nome>")
+		}
+		create(*moduleName, *dir, args[1])
+	case "up":
+		up(newMigrator(*dsn))
+	case "down":
+		if len(args) != 2 {
+			fail("Uso: migrate down N")
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			fail("N deve ser um inteiro positivo: %s", args[1])
+		}
+		down(newMigrator(*dsn), n)
+	case "status":
+		status(newMigrator(*dsn))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func newMigrator(dsn string) *database.Migrator {
+	if dsn == "" {
+		fail("Informe -dsn ou DATABASE_URL")
+	}
+	db, err := database.Connect(database.Config{
+		DSN:          dsn,
+		MaxIdleConns: 1,
+		MaxOpenConns: 2,
+		MaxLifetime:  time.Minute,
+		LogLevel:     logger.Silent,
+	})
+	if err != nil {
+		fail("Erro ao conectar: %v", err)
+	}
+	if database.IsSQLite(db) {
+		fail("As migrações SQL são para PostgreSQL; no SQLite a aplicação cria o schema pelos modelos na inicialização")
+	}
+	migrator, err := app.NewMigrator(db)
+	if err != nil {
+		fail("Erro ao carregar migrações: %v", err)
+	}
+	return migrator
+}
+
+func up(migrator *database.Migrator) {
+	applied, err := migrator.Up(context.Background())
+	for _, migration := range applied {
+		fmt.Printf("aplicada   %d  %s/%s\n", migration.Version, migration.Source, migration.Name)
+	}
+	if err != nil {
+		fail("Erro: %v", err)
+	}
+	fmt.Printf("%d migrações aplicadas\n", len(applied))
+}
+
+func down(migrator *database.Migrator, n int) {
+	reverted, err := migrator.Down(context.Background(), n)
+	for _, migration := range reverted {
+		fmt.Printf("desfeita   %d  %s/%s\n", migration.Version, migration.Source, migration.Name)
+	}
+	if err != nil {
+		fail("Erro: %v", err)
+	}
+	fmt.Printf("%d migrações desfeitas\n", len(reverted))
+}
+
+func status(migrator *database.Migrator) {
+	statuses, err := migrator.Status(context.Background())
+	if err != nil {
+		fail("Erro: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSÃO\tMÓDULO\tNOME\tESTADO\tAPLICADA EM")
+	for _, s := range statuses {
+		state, appliedAt := "pendente", "-"
+		if s.Applied {
+			state = "aplicada"
+			appliedAt = s.AppliedAt.Local().Format(time.RFC3339)
+		}
+		if s.Missing {
+			state = "aplicada (sem arquivo)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", s.Version, s.Source, s.Name, state, appliedAt)
+	}
+	w.Flush()
+}
+
+func create(moduleName, dir, name string) {
+	if !namePattern.MatchString(name) {
+		fail("Nome inválido %q: use letras minúsculas, dígitos e _", name)
+	}
+	if dir == "" {
+		if moduleName == "" {
+			fail("Informe -module ou -dir")
+		}
+		dir = filepath.Join("internal", moduleName, "migrations")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fail("Erro ao criar %s: %v", dir, err)
+	}
+
+	version := time.Now().UTC().Format(database.MigrationVersionFormat)
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(dir, fmt.Sprintf("%s_%s.%s.sql", version, name, direction))
+		content := fmt.Sprintf("-- %s (%s)\n", name, direction)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			fail("Erro ao criar %s: %v", path, err)
+		}
+		fmt.Println(path)
+	}
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
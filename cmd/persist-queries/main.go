@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"gorm.io/gorm/logger"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/persisted"
+)
+
+// persist-queries - registra as queries de arquivos .graphql na tabela persisted_queries
+// (allowlist de produção) e opcionalmente gera o manifesto arquivo -> hash para os clientes.
+func main() {
+	dir := flag.String("dir", "queries", "diretório com os arquivos .graphql")
+	dsn := flag.String("dsn", os.Getenv("DATABASE_URL"), "DSN do PostgreSQL (padrão: $DATABASE_URL)")
+	manifest := flag.String("manifest", "", "arquivo JSON de saída com arquivo e hash de cada query")
+	dryRun := flag.Bool("dry-run", false, "apenas lista os hashes, sem gravar no banco")
+	flag.Parse()
+
+	entries, err := persisted.ReadDir(*dir)
+	if err != nil {
+		fail("Erro ao ler queries: %v", err)
+	}
+	if len(entries) == 0 {
+		fail("Nenhum arquivo .graphql encontrado em %s", *dir)
+	}
+
+	for _, entry := range entries {
+		fmt.Printf("%s  %s\n", entry.Hash, entry.File)
+	}
+
+	if *manifest != "" {
+		content, _ := json.MarshalIndent(entries, "", "  ")
+		if err := os.WriteFile(*manifest, append(content, '\n'), 0644); err != nil {
+			fail("Erro ao gravar manifesto: %v", err)
+		}
+	}
+
+	if *dryRun {
+		return
+	}
+	if *dsn == "" {
+		fail("Informe -dsn ou DATABASE_URL")
+	}
+
+	db, err := database.Connect(database.Config{
+		DSN:          *dsn,
+		MaxIdleConns: 1,
+		MaxOpenConns: 1,
+		MaxLifetime:  time.Minute,
+		LogLevel:     logger.Silent,
+	})
+	if err != nil {
+		fail("Erro ao conectar: %v", err)
+	}
+	if err := database.AutoMigrate(db, &persisted.Query{}); err != nil {
+		fail("Erro ao migrar persisted_queries: %v", err)
+	}
+
+	if err := persisted.Register(context.Background(), persisted.NewDBStore(db), entries); err != nil {
+		fail("Erro ao registrar queries: %v", err)
+	}
+	fmt.Printf("%d queries registradas\n", len(entries))
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
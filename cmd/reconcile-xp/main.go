@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"gorm.io/gorm/logger"
+
+	"github.com/rafaelcoelhox/labbend/internal/users"
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	corelogger "github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+// reconcile-xp - compara user_xp_balance com o ledger user_xp; com -fix
+// regrava os saldos divergentes (o job da aplicação faz o mesmo periodicamente)
+func main() {
+	dsn := flag.String("dsn", os.Getenv("DATABASE_URL"), "DSN do banco (padrão: $DATABASE_URL)")
+	fix := flag.Bool("fix", false, "corrige as divergências a partir do ledger")
+	flag.Parse()
+
+	if *dsn == "" {
+		fail("Informe -dsn ou DATABASE_URL")
+	}
+	db, err := database.Connect(database.Config{
+		DSN:          *dsn,
+		MaxIdleConns: 1,
+		MaxOpenConns: 2,
+		MaxLifetime:  time.Minute,
+		LogLevel:     logger.Silent,
+	})
+	if err != nil {
+		fail("Erro ao conectar: %v", err)
+	}
+	defer database.Close(db)
+
+	log, err := corelogger.New()
+	if err != nil {
+		fail("Erro ao criar logger: %v", err)
+	}
+
+	// Sem event bus: os totais em cache expiram pelo TTL
+	reconciler := users.NewReconciler(users.NewRepository(db), log, nil)
+	report, err := reconciler.Reconcile(context.Background(), *fix)
+	if report != nil && len(report.Drifts) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "USUÁRIO\tSALDO\tLEDGER\tDIFERENÇA")
+		for _, drift := range report.Drifts {
+			fmt.Fprintf(w, "%d\t%d\t%d\t%+d\n", drift.UserID, drift.Balance, drift.LedgerTotal, drift.Balance-drift.LedgerTotal)
+		}
+		w.Flush()
+	}
+	if err != nil {
+		fail("Erro: %v", err)
+	}
+
+	fmt.Printf("%d divergências, %d corrigidas\n", len(report.Drifts), report.Fixed)
+	if len(report.Drifts) > report.Fixed {
+		os.Exit(3)
+	}
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
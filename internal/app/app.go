@@ -2,24 +2,41 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/graphql-go/handler"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"gorm.io/gorm"
 
-	"github.com/rafaelcoelhox/labbend/internal/challenges"
 	schemas_configuration "github.com/rafaelcoelhox/labbend/internal/config/graphql"
 	"github.com/rafaelcoelhox/labbend/internal/users"
+	"github.com/rafaelcoelhox/labbend/internal/users/usersgrpc"
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	"github.com/rafaelcoelhox/labbend/pkg/cache"
+	"github.com/rafaelcoelhox/labbend/pkg/complexity"
 	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/dataloader"
 	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	"github.com/rafaelcoelhox/labbend/pkg/graphqlerrors"
+	"github.com/rafaelcoelhox/labbend/pkg/graphqlws"
+	"github.com/rafaelcoelhox/labbend/pkg/grpcerrors"
 	"github.com/rafaelcoelhox/labbend/pkg/health"
 	corelogger "github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/module"
 	"github.com/rafaelcoelhox/labbend/pkg/monitoring"
+	"github.com/rafaelcoelhox/labbend/pkg/persisted"
+	"github.com/rafaelcoelhox/labbend/pkg/rest"
 	"github.com/rafaelcoelhox/labbend/pkg/saga"
 	"gorm.io/gorm/logger"
 )
@@ -34,6 +51,10 @@ type App struct {
 	sagaManager *saga.SagaManager
 	healthMgr   *health.Manager
 	monitor     *monitoring.Monitor
+	modules     []module.Module
+	usersConn   *grpc.ClientConn // conexão com o users service remoto (USERS_GRPC_ADDR)
+	redisCache  *cache.Redis     // CACHE_DRIVER=redis
+	tokens      *auth.Tokens     // tokens de acesso (AUTH_TOKEN_SECRET)
 }
 
 // NewApp - cria nova instância da aplicação
@@ -67,28 +88,37 @@ func NewApp(config Config) (*App, error) {
 		gormLogLevel = logger.Silent
 	}
 
+	replicaPolicy, err := database.ParseReplicaPolicy(config.DatabaseReplicaPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	// Setup database
 	db, err := database.Connect(database.Config{
-		DSN:          config.DatabaseURL,
-		MaxIdleConns: config.MaxIdleConns,
-		MaxOpenConns: config.MaxOpenConns,
-		MaxLifetime:  config.ConnMaxLifetime,
-		LogLevel:     gormLogLevel,
+		DSN:           config.DatabaseURL,
+		MaxIdleConns:  config.MaxIdleConns,
+		MaxOpenConns:  config.MaxOpenConns,
+		MaxLifetime:   config.ConnMaxLifetime,
+		LogLevel:      gormLogLevel,
+		ReplicaDSNs:   config.DatabaseReplicaURLs,
+		ReplicaPolicy: replicaPolicy,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	log.Info("Database connection established", zap.String("database", config.DatabaseURL))
-
-	// Auto migrate database tables using registered models
-	registeredModels := database.GetRegisteredModels()
-	log.Info("Auto migrating database", zap.Int("registered_models", len(registeredModels)))
+	log.Info("Database connection established",
+		zap.String("database", config.DatabaseURL),
+		zap.Int("replicas", len(config.DatabaseReplicaURLs)),
+		zap.String("replica_policy", config.DatabaseReplicaPolicy))
 
-	if err := database.AutoMigrateRegistered(db); err != nil {
-		return nil, fmt.Errorf("failed to auto migrate database: %w", err)
+	// Migrações versionadas (cmd/migrate); desative com DB_MIGRATE_ON_START=false
+	// quando o deploy roda "migrate up" antes de subir as réplicas
+	if config.MigrateOnStart {
+		if err := migrate(context.Background(), db, log); err != nil {
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
 	}
-	log.Info("Database auto migration completed")
 
 	// Setup database transaction manager
 	txManager := database.NewTxManager(db)
@@ -99,12 +129,69 @@ func NewApp(config Config) (*App, error) {
 	// Setup saga manager
 	sagaManager := saga.NewSagaManager(log)
 
+	// Setup monitoring (antes dos módulos: as métricas do cache usam o registry)
+	monitor := monitoring.NewMonitor(log)
+
+	// Setup cache
+	cacheStore, redisCache, err := newCacheStore(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache: %w", err)
+	}
+	var cacheMetrics *cache.Metrics
+	if cacheStore != nil {
+		cacheMetrics, err = cache.NewMetrics(monitor.GetRegistry())
+		if err != nil {
+			return nil, fmt.Errorf("failed to register cache metrics: %w", err)
+		}
+		log.Info("Cache enabled", zap.String("driver", config.CacheDriver), zap.Duration("ttl", config.CacheTTL))
+	}
+
+	// Users service remoto: challenges passa a usar o client gRPC no lugar do módulo local
+	remote := map[string]interface{}{}
+	var usersConn *grpc.ClientConn
+	if config.UsersGRPCAddr != "" {
+		usersConn, err = grpc.NewClient(config.UsersGRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create users gRPC client: %w", err)
+		}
+		remote[users.ModuleName] = usersgrpc.NewClient(usersConn)
+		log.Info("Using remote users service", zap.String("address", config.UsersGRPCAddr))
+	}
+
+	// Construir os módulos registrados (init.go de cada módulo, importados em modules.go)
+	modules, err := module.Build(&module.Deps{
+		DB:          db,
+		Logger:      log,
+		EventBus:    eventBus,
+		TxManager:   txManager,
+		SagaManager: sagaManager,
+		LoaderConfig: dataloader.Config{
+			MaxBatch: config.DataLoaderMaxBatch,
+			Wait:     config.DataLoaderWait,
+		},
+		Config:       config,
+		Remote:       remote,
+		CacheStore:   cacheStore,
+		CacheMetrics: cacheMetrics,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build modules: %w", err)
+	}
+
+	tokens, err := newTokens(config, log)
+	if err != nil {
+		return nil, err
+	}
+
 	// Setup health manager
 	healthMgr := health.NewManager()
 	healthMgr.Register("database", health.NewDatabaseChecker(db))
-
-	// Setup monitoring
-	monitor := monitoring.NewMonitor(log)
+	for i, replica := range database.Replicas(db) {
+		healthMgr.Register(fmt.Sprintf("database_replica_%d", i), health.NewDatabaseChecker(replica))
+	}
+	if redisCache != nil {
+		healthMgr.Register("cache", health.NewCacheChecker(redisCache))
+	}
 
 	return &App{
 		config:      config,
@@ -115,31 +202,86 @@ func NewApp(config Config) (*App, error) {
 		sagaManager: sagaManager,
 		healthMgr:   healthMgr,
 		monitor:     monitor,
+		modules:     modules,
+		usersConn:   usersConn,
+		redisCache:  redisCache,
+		tokens:      tokens,
 	}, nil
 }
 
-func (a *App) Start(ctx context.Context) error {
-	a.logger.Info("Starting application", zap.String("environment", a.config.Environment))
+// newTokens - AUTH_TOKEN_SECRET é obrigatório em produção; fora dela, sem
+// secret, uma chave aleatória é gerada e nenhum token externo é aceito
+func newTokens(config Config, log corelogger.Logger) (*auth.Tokens, error) {
+	if config.AuthTokenSecret != "" {
+		return auth.NewTokens([]byte(config.AuthTokenSecret)), nil
+	}
+	if config.IsProduction() {
+		return nil, fmt.Errorf("AUTH_TOKEN_SECRET is required in production")
+	}
 
-	// Setup repositories
-	userRepo := users.NewRepository(a.db)
-	challengeRepo := challenges.NewRepository(a.db)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate auth token secret: %w", err)
+	}
+	log.Warn("AUTH_TOKEN_SECRET not set; using a random secret, all requests are anonymous")
+	return auth.NewTokens(secret), nil
+}
 
-	// Setup services
-	userService := users.NewService(userRepo, a.logger, a.eventBus, a.txManager)
-	challengeService := challenges.NewService(challengeRepo, userService, a.logger, a.eventBus, a.txManager, a.sagaManager)
+// newCacheStore - cache conforme CACHE_DRIVER; nil com "off". O Redis também é
+// retornado à parte para health check e shutdown
+func newCacheStore(config Config) (cache.Cache, *cache.Redis, error) {
+	switch config.CacheDriver {
+	case "off":
+		return nil, nil, nil
+	case "redis":
+		redisCache, err := cache.NewRedisFromURL(config.RedisURL, "labend:", config.CacheTTL)
+		if err != nil {
+			return nil, nil, err
+		}
+		return redisCache, redisCache, nil
+	case "memory", "":
+		return cache.NewMemory(config.CacheSize, config.CacheTTL), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown cache driver %q", config.CacheDriver)
+	}
+}
 
-	// Setup GraphQL schema usando o novo ModuleRegistry
-	registry := schemas_configuration.NewModuleRegistry(a.logger)
-	registry.Register("users", userService)
-	registry.Register("challenges", challengeService)
-	// Adicione novos módulos aqui: registry.Register("products", productService)
+func (a *App) Start(ctx context.Context) error {
+	a.logger.Info("Starting application", zap.String("environment", a.config.Environment))
 
-	schema, err := schemas_configuration.ConfigureSchema(registry)
+	// Setup GraphQL schema a partir dos módulos registrados
+	schema, err := schemas_configuration.ConfigureSchema(a.modules, a.eventBus)
 	if err != nil {
 		return fmt.Errorf("failed to build GraphQL schema: %w", err)
 	}
 
+	// Limites de profundidade/custo por papel; o custo aceito vai em extensions.complexity.
+	// O papel vem do token (withViewer → complexity.WithRole); sem ele valem os limites padrão.
+	costs := complexity.Costs{}
+	for _, m := range a.modules {
+		costs.Merge(m.Costs())
+	}
+	// Erros dos resolvers viram extensions.code; internos ficam ocultos em produção (correlationId)
+	errorPresenter := graphqlerrors.NewPresenter(graphqlerrors.Config{
+		Production: a.config.IsProduction(),
+		Logger:     a.logger,
+	})
+	schema.AddExtensions(complexity.Extension{}, errorPresenter)
+	limiter := complexity.NewLimiter(
+		complexity.NewAnalyzer(&schema, costs, a.config.GraphQLDefaultListSize),
+		a.config.ComplexityLimits(),
+	)
+
+	// Handlers de eventos declarados pelos módulos
+	for _, m := range a.modules {
+		for _, sub := range m.EventSubscriptions() {
+			a.eventBus.Subscribe(sub.EventType, sub.Handler)
+		}
+	}
+
+	// Jobs periódicos dos módulos (ex.: reconciliação do saldo de XP)
+	a.startJobs(ctx)
+
 	// Setup server
 	if a.config.IsProduction() {
 		gin.SetMode(gin.ReleaseMode)
@@ -150,6 +292,13 @@ func (a *App) Start(ctx context.Context) error {
 	// Middleware básico
 	router.Use(gin.Recovery())
 
+	// Read-your-writes: depois de uma escrita, as leituras do mesmo request
+	// deixam as réplicas e vão para o primário
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(database.WithReadYourWrites(c.Request.Context()))
+		c.Next()
+	})
+
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
 		status := a.healthMgr.CheckAll(context.Background())
@@ -160,16 +309,14 @@ func (a *App) Start(ctx context.Context) error {
 		c.JSON(statusCode, status)
 	})
 
-	// Metrics endpoint
-	router.GET("/metrics", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"message": "metrics endpoint"})
-	})
+	// Metrics endpoint (registry do monitoring, inclui as métricas do cache)
+	router.GET("/metrics", gin.WrapH(promhttp.HandlerFor(a.monitor.GetRegistry(), promhttp.HandlerOpts{})))
 
 	// Middleware de CORS simples
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
@@ -179,6 +326,24 @@ func (a *App) Start(ctx context.Context) error {
 		c.Next()
 	})
 
+	// Autenticação: "Authorization: Bearer <token>" define o Viewer; sem header
+	// a request é anônima, com token inválido é recusada (depois do CORS, para que
+	// o 401 chegue ao navegador)
+	router.Use(func(c *gin.Context) {
+		token := auth.BearerToken(c.Request)
+		if token == "" {
+			c.Next()
+			return
+		}
+		viewer, err := a.tokens.Verify(token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.Request = c.Request.WithContext(withViewer(c.Request.Context(), viewer))
+		c.Next()
+	})
+
 	// Setup GraphQL handler usando graphql-go/handler
 	graphqlHandler := handler.New(&handler.Config{
 		Schema:     &schema,
@@ -187,18 +352,75 @@ func (a *App) Start(ctx context.Context) error {
 		Playground: false,
 	})
 
+	// Contexto por request dos módulos (ex.: dataloaders que evitam N+1)
+	withLoaders := func(ctx context.Context) context.Context {
+		for _, m := range a.modules {
+			ctx = m.WithRequestContext(ctx)
+		}
+		return ctx
+	}
+
+	// Persisted queries (APQ/allowlist) resolvem o texto antes da análise de complexidade
+	persistedQueries, err := a.persistedQueries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to setup persisted queries: %w", err)
+	}
+	graphqlEndpoint := limiter.Handler(graphqlHandler)
+	if persistedQueries != nil {
+		graphqlEndpoint = persistedQueries.Handler(graphqlEndpoint)
+	}
+
 	// GraphQL endpoint
 	router.POST("/graphql", func(c *gin.Context) {
-		graphqlHandler.ServeHTTP(c.Writer, c.Request)
+		graphqlEndpoint.ServeHTTP(c.Writer, c.Request.WithContext(withLoaders(c.Request.Context())))
+	})
+
+	// GraphQL subscriptions via WebSocket (graphql-transport-ws)
+	subscriptionHandler := graphqlws.NewHandler(graphqlws.Config{
+		Schema:                &schema,
+		Logger:                a.logger,
+		Authenticate:          a.authenticateWebSocket,
+		ConnectionInitTimeout: a.config.WSConnectionInitTimeout,
+		OperationContext:      withLoaders,
+		PresentErrors:         errorPresenter.PresentAll,
+		CheckOperation: func(ctx context.Context, payload graphqlws.SubscribePayload) (context.Context, error) {
+			if persistedQueries != nil {
+				if err := persistedQueries.Allowed(ctx, payload.Query); err != nil {
+					return nil, err
+				}
+			}
+			return limiter.Check(ctx, payload.Query, payload.OperationName, payload.Variables)
+		},
+		CheckOrigin: a.checkWebSocketOrigin,
+	})
+	router.GET("/graphql/ws", func(c *gin.Context) {
+		subscriptionHandler.ServeHTTP(c.Writer, c.Request)
 	})
 
 	// GraphQL playground (apenas em desenvolvimento)
 	if !a.config.IsProduction() {
 		router.GET("/graphql", func(c *gin.Context) {
-			graphqlHandler.ServeHTTP(c.Writer, c.Request)
+			graphqlEndpoint.ServeHTTP(c.Writer, c.Request.WithContext(withLoaders(c.Request.Context())))
 		})
 	}
 
+	// Rotas HTTP dos módulos
+	for _, m := range a.modules {
+		m.Routes(router)
+	}
+
+	// API REST (/api/v1) com os mesmos services e especificação OpenAPI gerada das rotas
+	restAPI := rest.NewAPI(router.Group("/api/v1"), rest.Config{
+		Title:      "LabEnd API",
+		Version:    "1.0.0",
+		Production: a.config.IsProduction(),
+		Logger:     a.logger,
+	})
+	for _, m := range a.modules {
+		restAPI.Handle(m.RESTRoutes()...)
+	}
+	restAPI.ServeSpec("/openapi.json")
+
 	// Health check simples
 	router.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -219,6 +441,11 @@ func (a *App) Start(ctx context.Context) error {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	grpcServer, err := a.startGRPC()
+	if err != nil {
+		return err
+	}
+
 	a.logger.Info("Server starting", zap.String("port", a.config.Port))
 
 	// Iniciar servidor em goroutine separada
@@ -233,6 +460,10 @@ func (a *App) Start(ctx context.Context) error {
 
 	a.logger.Info("Shutting down server...")
 
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+
 	// Context com timeout para o shutdown
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -253,23 +484,146 @@ func (a *App) Stop() error {
 	if a.eventBus != nil {
 		a.eventBus.Shutdown()
 	}
-	// Fechar conexão com o banco de dados
-	if sqlDB, err := a.db.DB(); err == nil {
-		if err := sqlDB.Close(); err != nil {
-			a.logger.Error("Failed to close database connection", zap.Error(err))
-			return err
+	// Fechar conexão com o users service remoto
+	if a.usersConn != nil {
+		if err := a.usersConn.Close(); err != nil {
+			a.logger.Error("Failed to close users gRPC connection", zap.Error(err))
+		}
+	}
+	// Fechar conexão com o Redis do cache
+	if a.redisCache != nil {
+		if err := a.redisCache.Close(); err != nil {
+			a.logger.Error("Failed to close cache connection", zap.Error(err))
 		}
 	}
+	// Fechar conexões com o banco de dados (primário e réplicas)
+	if err := database.Close(a.db); err != nil {
+		a.logger.Error("Failed to close database connection", zap.Error(err))
+		return err
+	}
 
 	a.logger.Info("Application stopped successfully")
 	return nil
 }
 
-// userServiceAdapter adapta o users.Service para ser compatível com outros módulos
-type userServiceAdapter struct {
-	userService users.Service
+// startGRPC - servidor gRPC (GRPC_PORT) com o acesso service-to-service aos
+// usuários e ao XP; nil quando desativado
+func (a *App) startGRPC() (*grpc.Server, error) {
+	if a.config.GRPCPort == "" {
+		return nil, nil
+	}
+
+	var usersService users.Service
+	for _, m := range a.modules {
+		if service, ok := m.Service().(users.Service); ok && m.Name() == users.ModuleName {
+			usersService = service
+		}
+	}
+	if usersService == nil {
+		return nil, fmt.Errorf("gRPC server requires the local users module")
+	}
+
+	listener, err := net.Listen("tcp", ":"+a.config.GRPCPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on gRPC port: %w", err)
+	}
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(grpcerrors.UnaryServerInterceptor(grpcerrors.Config{
+		Production: a.config.IsProduction(),
+		Logger:     a.logger,
+	})))
+	usersgrpc.Register(server, usersService)
+
+	a.logger.Info("gRPC server starting", zap.String("port", a.config.GRPCPort))
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			a.logger.Error("gRPC server stopped", zap.Error(err))
+		}
+	}()
+	return server, nil
+}
+
+// persistedQueries - manager conforme APQ_MODE/APQ_STORE; nil quando desativado
+func (a *App) persistedQueries(ctx context.Context) (*persisted.Manager, error) {
+	mode := persisted.Mode(a.config.APQMode)
+	switch mode {
+	case "off":
+		return nil, nil
+	case persisted.ModeAPQ, persisted.ModeAllowlist:
+	default:
+		return nil, fmt.Errorf("invalid APQ_MODE %q", a.config.APQMode)
+	}
+
+	var store persisted.Store
+	switch a.config.APQStore {
+	case "memory":
+		store = persisted.NewMemoryStore(a.config.APQCacheSize)
+	case "postgres":
+		store = persisted.NewCachedStore(persisted.NewDBStore(a.db), a.config.APQCacheSize)
+	default:
+		return nil, fmt.Errorf("invalid APQ_STORE %q", a.config.APQStore)
+	}
+
+	if dir := a.config.PersistedQueriesDir; dir != "" {
+		entries, err := persisted.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		if err := persisted.Register(ctx, store, entries); err != nil {
+			return nil, err
+		}
+		a.logger.Info("Persisted queries loaded", zap.String("dir", dir), zap.Int("queries", len(entries)))
+	}
+
+	a.logger.Info("Persisted queries enabled", zap.String("mode", string(mode)), zap.String("store", a.config.APQStore))
+	return persisted.NewManager(store, mode), nil
 }
 
-func (u *userServiceAdapter) GetUser(ctx context.Context, id uint) (interface{}, error) {
-	return u.userService.GetUser(ctx, id)
+// authenticateWebSocket - autenticação por conexão WebSocket (authToken do
+// connection_init). Sem token a conexão é anônima (recusada em produção);
+// token inválido ou expirado encerra a conexão
+func (a *App) authenticateWebSocket(ctx context.Context, payload map[string]interface{}) (context.Context, error) {
+	token, _ := payload["authToken"].(string)
+	if token == "" {
+		if a.config.IsProduction() {
+			return nil, fmt.Errorf("authToken is required")
+		}
+		return ctx, nil
+	}
+
+	viewer, err := a.tokens.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	return withViewer(ctx, viewer), nil
+}
+
+// withViewer - solicitante autenticado e o papel dele para os limites de
+// complexidade (complexity.Limits.Roles)
+func withViewer(ctx context.Context, viewer auth.Viewer) context.Context {
+	ctx = auth.WithViewer(ctx, viewer)
+	if viewer.Role != "" {
+		ctx = complexity.WithRole(ctx, viewer.Role)
+	}
+	return ctx
+}
+
+// checkWebSocketOrigin - WS_ALLOWED_ORIGINS ("*" libera qualquer uma); sem
+// configuração só a mesma origem. Clientes sem Origin (não navegadores) passam
+func (a *App) checkWebSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range a.config.WSAllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	if len(a.config.WSAllowedOrigins) > 0 {
+		return false
+	}
+
+	parsed, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(parsed.Host, r.Host)
 }
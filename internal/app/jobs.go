@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/rafaelcoelhox/labbend/pkg/module"
+)
+
+// startJobs - inicia os jobs periódicos dos módulos; param quando ctx termina
+func (a *App) startJobs(ctx context.Context) {
+	for _, m := range a.modules {
+		for _, job := range m.Jobs() {
+			if job.Interval <= 0 {
+				continue
+			}
+			go a.runJob(ctx, m.Name(), job)
+		}
+	}
+}
+
+// runJob - executa job a cada Interval; falhas ficam no log e não param o job
+func (a *App) runJob(ctx context.Context, moduleName string, job module.Job) {
+	a.logger.Info("Starting module job",
+		zap.String("module", moduleName),
+		zap.String("job", job.Name),
+		zap.Duration("interval", job.Interval))
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			start := time.Now()
+			if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+				a.logger.Error("Module job failed",
+					zap.String("module", moduleName),
+					zap.String("job", job.Name),
+					zap.Error(err))
+				continue
+			}
+			a.logger.Debug("Module job finished",
+				zap.String("module", moduleName),
+				zap.String("job", job.Name),
+				zap.Duration("duration", time.Since(start)))
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	corelogger "github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/module"
+	"github.com/rafaelcoelhox/labbend/pkg/persisted"
+)
+
+// Migrations - migrações dos módulos registrados (modules.go) e dos pacotes
+// com tabelas próprias, em ordem de versão
+func Migrations() ([]database.Migration, error) {
+	migrations, err := module.Migrations()
+	if err != nil {
+		return nil, err
+	}
+	persistedMigrations, err := database.LoadMigrations("persisted", persisted.Migrations)
+	if err != nil {
+		return nil, err
+	}
+	return append(migrations, persistedMigrations...), nil
+}
+
+// NewMigrator - migrator com todas as migrações da aplicação
+func NewMigrator(db *gorm.DB) (*database.Migrator, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return database.NewMigrator(db, migrations)
+}
+
+// migrate - aplica as migrações pendentes na inicialização (DB_MIGRATE_ON_START);
+// o advisory lock garante que só uma réplica migre por vez. As migrações SQL
+// são escritas para o PostgreSQL: no SQLite (desenvolvimento local) o schema
+// vem do AutoMigrate dos modelos registrados
+func migrate(ctx context.Context, db *gorm.DB, log corelogger.Logger) error {
+	if database.IsSQLite(db) {
+		models := append(database.GetRegisteredModels(), &persisted.Query{})
+		if err := database.AutoMigrate(db.WithContext(ctx), models...); err != nil {
+			return err
+		}
+		log.Info("SQLite schema migrated from models", zap.Int("models", len(models)))
+		return nil
+	}
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+	for _, migration := range applied {
+		log.Info("Migration applied",
+			zap.Int64("version", migration.Version),
+			zap.String("source", migration.Source),
+			zap.String("name", migration.Name))
+	}
+	log.Info("Database migrations up to date", zap.Int("applied", len(applied)))
+	return nil
+}
@@ -0,0 +1,10 @@
+package app
+
+// Módulos da aplicação: cada pacote se registra no próprio init.go
+// (module.Register). Para adicionar um módulo basta importá-lo aqui.
+import (
+	_ "github.com/rafaelcoelhox/labbend/internal/challenges"
+	_ "github.com/rafaelcoelhox/labbend/internal/dailytasks"
+	_ "github.com/rafaelcoelhox/labbend/internal/moderation"
+	_ "github.com/rafaelcoelhox/labbend/internal/users"
+)
@@ -0,0 +1,31 @@
+package challenges
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/rafaelcoelhox/labbend/pkg/cache"
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+)
+
+func challengeCacheKey(id uint) string {
+	return cache.Key("challenge", strconv.FormatUint(uint64(id), 10))
+}
+
+// readCache - o cache só vale fora de transação: dentro dela a leitura precisa
+// ver o que a própria transação já escreveu
+func (s *service) readCache(ctx context.Context) cache.Cache {
+	if _, inTx := database.TxFromContext(ctx); inTx {
+		return nil
+	}
+	return s.cache
+}
+
+// getCachedChallenge - challenge (com tags) pelo cache ou pelo repositório.
+// Challenges não são editados depois de criados, então o TTL basta como
+// invalidação
+func (s *service) getCachedChallenge(ctx context.Context, id uint) (*Challenge, error) {
+	return cache.GetOrLoad(ctx, s.readCache(ctx), challengeCacheKey(id), 0, func(ctx context.Context) (*Challenge, error) {
+		return s.repo.GetChallengeByID(ctx, id)
+	})
+}
@@ -33,6 +33,19 @@
 //   - Processamento assíncrono em background
 //   - Aprovação por maioria simples
 //
+// # Tentativas
+//
+// Cada submission tem um AttemptNumber. Após uma rejeição o usuário pode
+// submeter novamente, respeitando o limite de tentativas e o cooldown do
+// challenge (ou da SubmissionPolicy global quando o challenge usa 0).
+//
+// # Validação de Provas
+//
+// ProofValidator valida a URL de prova e as evidências anexadas: sintaxe,
+// allowlist de schemes, domínios permitidos/negados do challenge e, se
+// exigido, acessibilidade via ProofFetcher. Evidências (url, text, file)
+// são gravadas em SubmissionEvidence.
+//
 // # Eventos
 //
 // O pacote publica os seguintes eventos:
@@ -41,6 +54,22 @@
 //   - ChallengeVoteAdded: Quando um voto é registrado
 //   - ChallengeApproved: Quando uma submissão é aprovada
 //   - ChallengeRejected: Quando uma submissão é rejeitada
+//   - ChallengeRevoked: Quando uma aprovação fraudulenta é revogada
+//   - ChallengeRevocationReverted: Compensação de uma revogação que falhou
+//   - NotificationRequested: Pedido de notificação ao usuário (revogação)
+//
+// Aprovação e rejeição também podem vir da moderação (pacote moderation),
+// via ApproveSubmission e RejectSubmission, que participam da transação em
+// ctx (TxManager.InTransaction); o motivo fica em
+// ChallengeSubmission.DecisionReason.
+//
+// # Revogação via Saga
+//
+// RevokeSubmission reverte aprovações fraudulentas com saga.SagaManager:
+// marca a submission como revogada, remove o XP (UserService.RemoveUserXP),
+// publica ChallengeRevoked e notifica o usuário. Se um passo falhar, os
+// anteriores são compensados (status restaurado, XP removido devolvido com
+// UserService.RestoreUserXP, sem reaplicar as regras de XP).
 //
 // # Comunicação Inter-Módulos
 //
@@ -81,6 +110,7 @@
 // - Prevenção de auto-votação
 // - Processamento assíncrono para não bloquear API
 // - Timeouts em todas operações de banco
+// - Loaders por request para submissions e votos (elimina N+1 no GraphQL)
 //
 // # Thread Safety
 //
@@ -1,11 +1,18 @@
 package challenges
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
 	"github.com/graphql-go/graphql"
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	"github.com/rafaelcoelhox/labbend/pkg/complexity"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	"github.com/rafaelcoelhox/labbend/pkg/graphqlws"
 	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
 )
 
 // ===== GRAPHQL TYPES =====
@@ -28,6 +35,46 @@ var ChallengeType = graphql.NewObject(graphql.ObjectConfig{
 		"status": &graphql.Field{
 			Type: graphql.String,
 		},
+		"category": &graphql.Field{
+			Type: graphql.String,
+		},
+		"difficulty": &graphql.Field{
+			Type: graphql.String,
+		},
+		"estimatedMinutes": &graphql.Field{
+			Type: graphql.Int,
+		},
+		"maxAttempts": &graphql.Field{
+			Type:        graphql.Int,
+			Description: "Limite de tentativas por usuário (0 usa o padrão global)",
+		},
+		"cooldownMinutes": &graphql.Field{
+			Type:        graphql.Int,
+			Description: "Espera entre tentativas após rejeição (0 usa o padrão global)",
+		},
+		"allowedProofDomains": &graphql.Field{
+			Type: graphql.NewList(graphql.String),
+		},
+		"deniedProofDomains": &graphql.Field{
+			Type: graphql.NewList(graphql.String),
+		},
+		"requireReachableProof": &graphql.Field{
+			Type: graphql.Boolean,
+		},
+		"tags": &graphql.Field{
+			Type: graphql.NewList(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if challenge, ok := p.Source.(*Challenge); ok {
+					return challenge.TagNames(), nil
+				}
+				return nil, nil
+			},
+		},
+		"submissions": &graphql.Field{
+			Type:        graphql.NewList(ChallengeSubmissionType),
+			Description: "Submissions do challenge (carregadas em lote por request)",
+			Resolve:     challengeSubmissionsResolver,
+		},
 		"createdAt": &graphql.Field{
 			Type: graphql.String,
 		},
@@ -37,6 +84,125 @@ var ChallengeType = graphql.NewObject(graphql.ObjectConfig{
 	},
 })
 
+var ChallengeDifficultyEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "ChallengeDifficulty",
+	Values: graphql.EnumValueConfigMap{
+		"BEGINNER":     &graphql.EnumValueConfig{Value: DifficultyBeginner},
+		"INTERMEDIATE": &graphql.EnumValueConfig{Value: DifficultyIntermediate},
+		"ADVANCED":     &graphql.EnumValueConfig{Value: DifficultyAdvanced},
+		"EXPERT":       &graphql.EnumValueConfig{Value: DifficultyExpert},
+	},
+})
+
+var ChallengeSortFieldEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "ChallengeSortField",
+	Values: graphql.EnumValueConfigMap{
+		"CREATED_AT":     &graphql.EnumValueConfig{Value: SortFieldCreatedAt},
+		"XP_REWARD":      &graphql.EnumValueConfig{Value: SortFieldXPReward},
+		"DIFFICULTY":     &graphql.EnumValueConfig{Value: SortFieldDifficulty},
+		"ESTIMATED_TIME": &graphql.EnumValueConfig{Value: SortFieldEstimatedTime},
+		"TITLE":          &graphql.EnumValueConfig{Value: SortFieldTitle},
+		"RELEVANCE":      &graphql.EnumValueConfig{Value: SortFieldRelevance},
+	},
+})
+
+var SortDirectionEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "SortDirection",
+	Values: graphql.EnumValueConfigMap{
+		"ASC":  &graphql.EnumValueConfig{Value: SortDirectionAsc},
+		"DESC": &graphql.EnumValueConfig{Value: SortDirectionDesc},
+	},
+})
+
+var ChallengeFilterInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "ChallengeFilter",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"status": &graphql.InputObjectFieldConfig{
+			Type: graphql.String,
+		},
+		"category": &graphql.InputObjectFieldConfig{
+			Type: graphql.String,
+		},
+		"tags": &graphql.InputObjectFieldConfig{
+			Type: graphql.NewList(graphql.NewNonNull(graphql.String)),
+		},
+		"difficulty": &graphql.InputObjectFieldConfig{
+			Type: ChallengeDifficultyEnum,
+		},
+		"minXP": &graphql.InputObjectFieldConfig{
+			Type: graphql.Int,
+		},
+		"maxXP": &graphql.InputObjectFieldConfig{
+			Type: graphql.Int,
+		},
+	},
+})
+
+var ChallengeSortInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "ChallengeSort",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"field": &graphql.InputObjectFieldConfig{
+			Type:        ChallengeSortFieldEnum,
+			Description: "Padrão: RELEVANCE quando há busca, CREATED_AT caso contrário",
+		},
+		"direction": &graphql.InputObjectFieldConfig{
+			Type:        SortDirectionEnum,
+			Description: "Padrão: DESC",
+		},
+	},
+})
+
+var EvidenceTypeEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "EvidenceType",
+	Values: graphql.EnumValueConfigMap{
+		"URL":  &graphql.EnumValueConfig{Value: EvidenceTypeURL},
+		"TEXT": &graphql.EnumValueConfig{Value: EvidenceTypeText},
+		"FILE": &graphql.EnumValueConfig{Value: EvidenceTypeFile},
+	},
+})
+
+var EvidenceInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "EvidenceInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"type": &graphql.InputObjectFieldConfig{
+			Type: graphql.NewNonNull(EvidenceTypeEnum),
+		},
+		"url": &graphql.InputObjectFieldConfig{
+			Type: graphql.String,
+		},
+		"text": &graphql.InputObjectFieldConfig{
+			Type: graphql.String,
+		},
+		"fileRef": &graphql.InputObjectFieldConfig{
+			Type: graphql.String,
+		},
+	},
+})
+
+var SubmissionEvidenceType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SubmissionEvidence",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+		},
+		"type": &graphql.Field{
+			Type: EvidenceTypeEnum,
+		},
+		"url": &graphql.Field{
+			Type: graphql.String,
+		},
+		"text": &graphql.Field{
+			Type: graphql.String,
+		},
+		"fileRef": &graphql.Field{
+			Type: graphql.String,
+		},
+		"createdAt": &graphql.Field{
+			Type: graphql.String,
+		},
+	},
+})
+
 var ChallengeSubmissionType = graphql.NewObject(graphql.ObjectConfig{
 	Name: "ChallengeSubmission",
 	Fields: graphql.Fields{
@@ -49,12 +215,38 @@ var ChallengeSubmissionType = graphql.NewObject(graphql.ObjectConfig{
 		"userID": &graphql.Field{
 			Type: graphql.String,
 		},
+		"attemptNumber": &graphql.Field{
+			Type: graphql.Int,
+		},
 		"proofURL": &graphql.Field{
 			Type: graphql.String,
 		},
 		"status": &graphql.Field{
 			Type: graphql.String,
 		},
+		"decisionReason": &graphql.Field{
+			Type: graphql.String,
+		},
+		"revokedBy": &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if submission, ok := p.Source.(*ChallengeSubmission); ok && submission.RevokedBy != nil {
+					return strconv.FormatUint(uint64(*submission.RevokedBy), 10), nil
+				}
+				return nil, nil
+			},
+		},
+		"revokedAt": &graphql.Field{
+			Type: graphql.String,
+		},
+		"evidence": &graphql.Field{
+			Type: graphql.NewList(SubmissionEvidenceType),
+		},
+		"votes": &graphql.Field{
+			Type:        graphql.NewList(ChallengeVoteType),
+			Description: "Votos da submission (carregados em lote por request)",
+			Resolve:     submissionVotesResolver,
+		},
 		"createdAt": &graphql.Field{
 			Type: graphql.String,
 		},
@@ -88,14 +280,102 @@ var ChallengeVoteType = graphql.NewObject(graphql.ObjectConfig{
 	},
 })
 
+var SubmissionAttemptType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SubmissionAttempt",
+	Fields: graphql.Fields{
+		"attemptNumber": &graphql.Field{
+			Type: graphql.Int,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if attempt, ok := p.Source.(*SubmissionAttempt); ok {
+					return attempt.Submission.AttemptNumber, nil
+				}
+				return nil, nil
+			},
+		},
+		"submission": &graphql.Field{
+			Type: ChallengeSubmissionType,
+		},
+		"votes": &graphql.Field{
+			Type: graphql.NewList(ChallengeVoteType),
+		},
+	},
+})
+
+var SubmissionStatusEventType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SubmissionStatusEvent",
+	Fields: graphql.Fields{
+		"submissionID": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+		},
+		"challengeID": &graphql.Field{
+			Type: graphql.ID,
+		},
+		"userID": &graphql.Field{
+			Type: graphql.ID,
+		},
+		"status": &graphql.Field{
+			Type: graphql.String,
+		},
+		"reason": &graphql.Field{
+			Type: graphql.String,
+		},
+	},
+})
+
+var ChallengeConnectionType = pagination.NewConnectionType("Challenge", ChallengeType)
+
+var ChallengeSubmissionConnectionType = pagination.NewConnectionType("ChallengeSubmission", ChallengeSubmissionType)
+
+// submissionStatusByEvent - status resultante de cada evento de decisão
+var submissionStatusByEvent = map[string]string{
+	"ChallengeApproved":           SubmissionStatusApproved,
+	"ChallengeRejected":           SubmissionStatusRejected,
+	"ChallengeRevoked":            SubmissionStatusRevoked,
+	"ChallengeRevocationReverted": SubmissionStatusApproved,
+}
+
 // ===== RESOLVER FUNCTIONS =====
 
+// challengeSubmissionsResolver - uma query para as submissions de todos os challenges da resposta
+func challengeSubmissionsResolver(p graphql.ResolveParams) (interface{}, error) {
+	challenge, ok := p.Source.(*Challenge)
+	if !ok {
+		return nil, nil
+	}
+	loaders := LoadersFromContext(p.Context)
+	if loaders == nil {
+		return nil, nil
+	}
+
+	thunk := loaders.SubmissionsByChallenge.LoadThunk(p.Context, challenge.ID)
+	return func() (interface{}, error) {
+		return thunk()
+	}, nil
+}
+
+// submissionVotesResolver - uma query para os votos de todas as submissions da resposta
+func submissionVotesResolver(p graphql.ResolveParams) (interface{}, error) {
+	submission, ok := p.Source.(*ChallengeSubmission)
+	if !ok {
+		return nil, nil
+	}
+	loaders := LoadersFromContext(p.Context)
+	if loaders == nil {
+		return nil, nil
+	}
+
+	thunk := loaders.VotesBySubmission.LoadThunk(p.Context, submission.ID)
+	return func() (interface{}, error) {
+		return thunk()
+	}, nil
+}
+
 func challengeResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
 	return func(p graphql.ResolveParams) (interface{}, error) {
 		id := p.Args["id"].(string)
 		challengeID, err := strconv.ParseUint(id, 10, 32)
 		if err != nil {
-			return nil, fmt.Errorf("ID inválido: %v", err)
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
 		}
 
 		logger.Info("Buscando challenge")
@@ -105,20 +385,88 @@ func challengeResolver(service Service, logger logger.Logger) graphql.FieldResol
 
 func challengesResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
 	return func(p graphql.ResolveParams) (interface{}, error) {
-		limit := 10
-		offset := 0
-		if l, ok := p.Args["limit"].(int); ok {
-			limit = l
+		var input SearchChallengesInput
+		if search, ok := p.Args["search"].(string); ok {
+			input.Search = search
 		}
-		if o, ok := p.Args["offset"].(int); ok {
-			offset = o
+		if filter, ok := p.Args["filter"].(map[string]interface{}); ok {
+			input.Filter = parseChallengeFilter(filter)
+		}
+		if sort, ok := p.Args["sort"].(map[string]interface{}); ok {
+			input.Sort = parseChallengeSort(sort)
 		}
 
 		logger.Info("Listando challenges")
-		return service.ListChallenges(p.Context, limit, offset)
+		return service.SearchChallenges(p.Context, input, pagination.ArgsFromMap(p.Args))
 	}
 }
 
+func submissionsResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		challengeID, err := strconv.ParseUint(p.Args["challengeID"].(string), 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+
+		logger.Info("Listando submissions")
+		return service.ListSubmissionsConnection(p.Context, uint(challengeID), pagination.ArgsFromMap(p.Args))
+	}
+}
+
+func submissionAttemptsResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		challengeID, err := strconv.ParseUint(p.Args["challengeID"].(string), 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+		userID, err := strconv.ParseUint(p.Args["userID"].(string), 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+
+		logger.Info("Buscando tentativas de submission")
+		return service.GetSubmissionAttempts(p.Context, uint(userID), uint(challengeID))
+	}
+}
+
+func parseChallengeFilter(args map[string]interface{}) ChallengeFilter {
+	var filter ChallengeFilter
+	if status, ok := args["status"].(string); ok {
+		filter.Status = status
+	}
+	if category, ok := args["category"].(string); ok {
+		filter.Category = category
+	}
+	if difficulty, ok := args["difficulty"].(string); ok {
+		filter.Difficulty = difficulty
+	}
+	if tags, ok := args["tags"].([]interface{}); ok {
+		for _, tag := range tags {
+			if name, ok := tag.(string); ok {
+				filter.Tags = append(filter.Tags, name)
+			}
+		}
+	}
+	if minXP, ok := args["minXP"].(int); ok {
+		filter.MinXP = &minXP
+	}
+	if maxXP, ok := args["maxXP"].(int); ok {
+		filter.MaxXP = &maxXP
+	}
+	return filter
+}
+
+func parseChallengeSort(args map[string]interface{}) ChallengeSort {
+	var sort ChallengeSort
+	if field, ok := args["field"].(string); ok {
+		sort.Field = field
+	}
+	if direction, ok := args["direction"].(string); ok {
+		sort.Direction = direction
+	}
+	return sort
+}
+
 func createChallengeResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
 	return func(p graphql.ResolveParams) (interface{}, error) {
 		input := CreateChallengeInput{
@@ -126,6 +474,33 @@ func createChallengeResolver(service Service, logger logger.Logger) graphql.Fiel
 			Description: p.Args["description"].(string),
 			XPReward:    p.Args["xpReward"].(int),
 		}
+		if category, ok := p.Args["category"].(string); ok {
+			input.Category = category
+		}
+		if difficulty, ok := p.Args["difficulty"].(string); ok {
+			input.Difficulty = difficulty
+		}
+		if minutes, ok := p.Args["estimatedMinutes"].(int); ok {
+			input.EstimatedMinutes = minutes
+		}
+		if maxAttempts, ok := p.Args["maxAttempts"].(int); ok {
+			input.MaxAttempts = maxAttempts
+		}
+		if cooldown, ok := p.Args["cooldownMinutes"].(int); ok {
+			input.CooldownMinutes = cooldown
+		}
+		input.AllowedProofDomains = stringListArg(p.Args["allowedProofDomains"])
+		input.DeniedProofDomains = stringListArg(p.Args["deniedProofDomains"])
+		if reachable, ok := p.Args["requireReachableProof"].(bool); ok {
+			input.RequireReachableProof = reachable
+		}
+		if tags, ok := p.Args["tags"].([]interface{}); ok {
+			for _, tag := range tags {
+				if name, ok := tag.(string); ok {
+					input.Tags = append(input.Tags, name)
+				}
+			}
+		}
 
 		logger.Info("Criando challenge")
 		return service.CreateChallenge(p.Context, input)
@@ -138,14 +513,55 @@ func submitChallengeResolver(service Service, logger logger.Logger) graphql.Fiel
 			ChallengeID: p.Args["challengeID"].(string),
 			ProofURL:    p.Args["proofURL"].(string),
 		}
+		if items, ok := p.Args["evidence"].([]interface{}); ok {
+			for _, item := range items {
+				if fields, ok := item.(map[string]interface{}); ok {
+					input.Evidence = append(input.Evidence, parseEvidenceInput(fields))
+				}
+			}
+		}
 
-		// TODO: Extrair userID do contexto de autenticação
-		userID := uint(1)
+		userID, err := viewerID(p.Context)
+		if err != nil {
+			return nil, err
+		}
 		logger.Info("Submetendo challenge")
 		return service.SubmitChallenge(p.Context, userID, input)
 	}
 }
 
+func parseEvidenceInput(args map[string]interface{}) EvidenceInput {
+	var evidence EvidenceInput
+	if evidenceType, ok := args["type"].(string); ok {
+		evidence.Type = evidenceType
+	}
+	if url, ok := args["url"].(string); ok {
+		evidence.URL = url
+	}
+	if text, ok := args["text"].(string); ok {
+		evidence.Text = text
+	}
+	if fileRef, ok := args["fileRef"].(string); ok {
+		evidence.FileRef = fileRef
+	}
+	return evidence
+}
+
+func stringListArg(arg interface{}) []string {
+	items, ok := arg.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if value, ok := item.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func voteChallengeResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
 	return func(p graphql.ResolveParams) (interface{}, error) {
 		input := VoteChallengeInput{
@@ -154,15 +570,53 @@ func voteChallengeResolver(service Service, logger logger.Logger) graphql.FieldR
 			TimeCheck:    p.Args["timeCheck"].(int),
 		}
 
-		// TODO: Extrair userID do contexto de autenticação
-		userID := uint(1)
+		userID, err := viewerID(p.Context)
+		if err != nil {
+			return nil, err
+		}
 		logger.Info("Votando em submission")
 		return service.VoteOnSubmission(p.Context, userID, input)
 	}
 }
 
+// viewerID - usuário autenticado da request; chamadas anônimas são recusadas
+func viewerID(ctx context.Context) (uint, error) {
+	viewer := auth.ViewerFromContext(ctx)
+	if viewer.IsAnonymous() {
+		return 0, errors.Unauthorized("authentication required")
+	}
+	return viewer.UserID, nil
+}
+
+func revokeSubmissionResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		input := RevokeSubmissionInput{
+			SubmissionID: p.Args["submissionID"].(string),
+			Reason:       p.Args["reason"].(string),
+		}
+
+		// Revogação é restrita a admins; o override da moderação chama o
+		// service diretamente com o moderador responsável
+		viewer := auth.ViewerFromContext(p.Context)
+		if !viewer.Admin {
+			return nil, errors.Unauthorized("admin role required")
+		}
+
+		logger.Info("Revogando submission")
+		return service.RevokeSubmission(p.Context, viewer.UserID, input)
+	}
+}
+
 // ===== SCHEMA CONFIGURATION =====
 
+// FieldCosts - custos para a análise de complexidade (campos com consulta própria)
+var FieldCosts = complexity.Costs{
+	"Query.challenges":          5,  // busca full-text
+	"Challenge.submissions":     2,  // dataloader por request
+	"ChallengeSubmission.votes": 2,  // dataloader por request
+	"Mutation.submitChallenge":  10, // validação da prova pode fazer HEAD na URL
+}
+
 func Queries(challengeService Service, logger logger.Logger) *graphql.Fields {
 	return &graphql.Fields{
 		"challenge": &graphql.Field{
@@ -176,19 +630,44 @@ func Queries(challengeService Service, logger logger.Logger) *graphql.Fields {
 			Resolve: challengeResolver(challengeService, logger),
 		},
 		"challenges": &graphql.Field{
-			Type:        graphql.NewList(ChallengeType),
-			Description: "Retorna lista de challenges",
+			Type:        graphql.NewNonNull(ChallengeConnectionType),
+			Description: "Retorna challenges paginados por cursor, com busca full-text, filtros e ordenação",
+			Args: pagination.ConnectionArgs(graphql.FieldConfigArgument{
+				"filter": &graphql.ArgumentConfig{
+					Type: ChallengeFilterInput,
+				},
+				"sort": &graphql.ArgumentConfig{
+					Type: ChallengeSortInput,
+				},
+				"search": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Termos buscados no título e na descrição",
+				},
+			}),
+			Resolve: challengesResolver(challengeService, logger),
+		},
+		"submissions": &graphql.Field{
+			Type:        graphql.NewNonNull(ChallengeSubmissionConnectionType),
+			Description: "Retorna as submissions de um challenge paginadas por cursor (mais recentes primeiro)",
+			Args: pagination.ConnectionArgs(graphql.FieldConfigArgument{
+				"challengeID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			}),
+			Resolve: submissionsResolver(challengeService, logger),
+		},
+		"submissionAttempts": &graphql.Field{
+			Type:        graphql.NewList(SubmissionAttemptType),
+			Description: "Histórico de tentativas de um usuário em um challenge, com os votos de cada uma",
 			Args: graphql.FieldConfigArgument{
-				"limit": &graphql.ArgumentConfig{
-					Type:         graphql.Int,
-					DefaultValue: 10,
+				"challengeID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
 				},
-				"offset": &graphql.ArgumentConfig{
-					Type:         graphql.Int,
-					DefaultValue: 0,
+				"userID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
 				},
 			},
-			Resolve: challengesResolver(challengeService, logger),
+			Resolve: submissionAttemptsResolver(challengeService, logger),
 		},
 	}
 }
@@ -208,6 +687,36 @@ func Mutations(challengeService Service, logger logger.Logger) *graphql.Fields {
 				"xpReward": &graphql.ArgumentConfig{
 					Type: graphql.NewNonNull(graphql.Int),
 				},
+				"category": &graphql.ArgumentConfig{
+					Type: graphql.String,
+				},
+				"tags": &graphql.ArgumentConfig{
+					Type: graphql.NewList(graphql.NewNonNull(graphql.String)),
+				},
+				"difficulty": &graphql.ArgumentConfig{
+					Type: ChallengeDifficultyEnum,
+				},
+				"estimatedMinutes": &graphql.ArgumentConfig{
+					Type: graphql.Int,
+				},
+				"maxAttempts": &graphql.ArgumentConfig{
+					Type: graphql.Int,
+				},
+				"cooldownMinutes": &graphql.ArgumentConfig{
+					Type: graphql.Int,
+				},
+				"allowedProofDomains": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+					Description: "Domínios aceitos nas provas (inclui subdomínios); vazio aceita qualquer domínio",
+				},
+				"deniedProofDomains": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+					Description: "Domínios recusados nas provas (têm precedência sobre os permitidos)",
+				},
+				"requireReachableProof": &graphql.ArgumentConfig{
+					Type:        graphql.Boolean,
+					Description: "Verifica com HEAD se as URLs de prova estão acessíveis",
+				},
 			},
 			Resolve: createChallengeResolver(challengeService, logger),
 		},
@@ -221,6 +730,10 @@ func Mutations(challengeService Service, logger logger.Logger) *graphql.Fields {
 				"proofURL": &graphql.ArgumentConfig{
 					Type: graphql.NewNonNull(graphql.String),
 				},
+				"evidence": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(EvidenceInputType)),
+					Description: "Evidências adicionais (URL, texto ou referência de arquivo)",
+				},
 			},
 			Resolve: submitChallengeResolver(challengeService, logger),
 		},
@@ -240,5 +753,111 @@ func Mutations(challengeService Service, logger logger.Logger) *graphql.Fields {
 			},
 			Resolve: voteChallengeResolver(challengeService, logger),
 		},
+		"revokeSubmission": &graphql.Field{
+			Type:        ChallengeSubmissionType,
+			Description: "Revoga uma submission aprovada (fraude), removendo o XP concedido",
+			Args: graphql.FieldConfigArgument{
+				"submissionID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+				"reason": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: revokeSubmissionResolver(challengeService, logger),
+		},
+	}
+}
+
+func Subscriptions(bus graphqlws.EventSubscriber, logger logger.Logger) *graphql.Fields {
+	return &graphql.Fields{
+		"submissionStatusChanged": &graphql.Field{
+			Type:        SubmissionStatusEventType,
+			Description: "Notifica aprovação, rejeição ou revogação de uma submission",
+			Args: graphql.FieldConfigArgument{
+				"submissionID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Subscribe: submissionStatusChangedSubscriber(bus, logger),
+			Resolve:   subscriptionPayload,
+		},
+		"voteAdded": &graphql.Field{
+			Type:        ChallengeVoteType,
+			Description: "Notifica novos votos; sem submissionID recebe votos de todas as submissions",
+			Args: graphql.FieldConfigArgument{
+				"submissionID": &graphql.ArgumentConfig{
+					Type: graphql.String,
+				},
+			},
+			Subscribe: voteAddedSubscriber(bus, logger),
+			Resolve:   subscriptionPayload,
+		},
+	}
+}
+
+// subscriptionPayload - o payload do evento já é o valor do campo
+func subscriptionPayload(p graphql.ResolveParams) (interface{}, error) {
+	return p.Source, nil
+}
+
+func submissionStatusChangedSubscriber(bus graphqlws.EventSubscriber, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		submissionID, err := strconv.ParseUint(p.Args["submissionID"].(string), 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+
+		eventTypes := make([]string, 0, len(submissionStatusByEvent))
+		for eventType := range submissionStatusByEvent {
+			eventTypes = append(eventTypes, eventType)
+		}
+
+		logger.Info("Inscrevendo em submissionStatusChanged")
+		return graphqlws.EventStream(p.Context, bus, eventTypes, func(event eventbus.Event) (interface{}, bool) {
+			if graphqlws.EventUint(event.Data, "submissionID") != uint(submissionID) {
+				return nil, false
+			}
+			reason, _ := event.Data["reason"].(string)
+			return &SubmissionStatusEvent{
+				SubmissionID: uint(submissionID),
+				ChallengeID:  graphqlws.EventUint(event.Data, "challengeID"),
+				UserID:       graphqlws.EventUint(event.Data, "userID"),
+				Status:       submissionStatusByEvent[event.Type],
+				Reason:       reason,
+			}, true
+		}), nil
+	}
+}
+
+func voteAddedSubscriber(bus graphqlws.EventSubscriber, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		var submissionID uint64
+		if id, ok := p.Args["submissionID"].(string); ok {
+			parsed, err := strconv.ParseUint(id, 10, 32)
+			if err != nil {
+				return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+			}
+			submissionID = parsed
+		}
+
+		logger.Info("Inscrevendo em voteAdded")
+		return graphqlws.EventStream(p.Context, bus, []string{"ChallengeVoteAdded"}, func(event eventbus.Event) (interface{}, bool) {
+			voteSubmissionID := graphqlws.EventUint(event.Data, "submissionID")
+			if submissionID != 0 && voteSubmissionID != uint(submissionID) {
+				return nil, false
+			}
+			approved, _ := event.Data["approved"].(bool)
+			timeCheck, _ := event.Data["timeCheck"].(int)
+			isValid, _ := event.Data["isValid"].(bool)
+			return &ChallengeVote{
+				ID:           graphqlws.EventUint(event.Data, "voteID"),
+				SubmissionID: voteSubmissionID,
+				UserID:       graphqlws.EventUint(event.Data, "userID"),
+				Approved:     approved,
+				TimeCheck:    timeCheck,
+				IsValid:      isValid,
+			}, true
+		}), nil
 	}
 }
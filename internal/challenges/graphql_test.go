@@ -0,0 +1,44 @@
+package challenges_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rafaelcoelhox/labbend/internal/challenges"
+	"github.com/rafaelcoelhox/labbend/internal/mocks"
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	apperrors "github.com/rafaelcoelhox/labbend/pkg/errors"
+)
+
+func TestRevokeSubmissionMutation_RequiresAdmin_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockChallengesService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	revoke := (*challenges.Mutations(mockService, mockLogger))["revokeSubmission"].Resolve
+	args := map[string]interface{}{"submissionID": "3", "reason": "Fraude"}
+
+	t.Run("should reject anonymous and non-admin callers", func(t *testing.T) {
+		for _, viewer := range []auth.Viewer{{}, {UserID: 9}} {
+			_, err := revoke(graphql.ResolveParams{Context: auth.WithViewer(context.Background(), viewer), Args: args})
+			assert.True(t, apperrors.Is(err, apperrors.ErrUnauthorized))
+		}
+	})
+
+	t.Run("should revoke as the authenticated admin", func(t *testing.T) {
+		mockService.EXPECT().
+			RevokeSubmission(gomock.Any(), uint(4), challenges.RevokeSubmissionInput{SubmissionID: "3", Reason: "Fraude"}).
+			Return(&challenges.ChallengeSubmission{ID: 3, Status: challenges.SubmissionStatusRevoked}, nil)
+
+		ctx := auth.WithViewer(context.Background(), auth.Viewer{UserID: 4, Admin: true})
+		_, err := revoke(graphql.ResolveParams{Context: ctx, Args: args})
+		assert.NoError(t, err)
+	})
+}
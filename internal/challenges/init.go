@@ -1,9 +1,14 @@
 package challenges
 
-import "github.com/rafaelcoelhox/labbend/pkg/database"
+import "github.com/rafaelcoelhox/labbend/pkg/module"
 
-// init - registra automaticamente os modelos do módulo challenges
+// init - registra automaticamente o módulo challenges
 func init() {
-	database.RegisterModel(&Challenge{})
-	database.RegisterModel(&ChallengeVote{})
+	module.Register(module.Definition{
+		Name:       ModuleName,
+		DependsOn:  []string{"users"},
+		New:        NewModule,
+		Migrations: migrations,
+		Models:     models,
+	})
 }
@@ -0,0 +1,85 @@
+package challenges_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/internal/challenges"
+	"github.com/rafaelcoelhox/labbend/internal/mocks"
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/dataloader"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/saga"
+)
+
+func TestChallengeLoaders_SubmissionsAndVotesAreBatched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockChallengesRepository(ctrl)
+	mockUserService := mocks.NewMockChallengesUserService(ctrl)
+	mockEventBus := mocks.NewMockChallengesEventBus(ctrl)
+	log, _ := logger.New()
+	service := challenges.NewService(mockRepo, mockUserService, log, mockEventBus,
+		database.NewTxManager(nil), saga.NewSagaManager(log), challenges.DefaultSubmissionPolicy(), nil, nil)
+
+	mockRepo.EXPECT().
+		SearchChallenges(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]*challenges.Challenge{{ID: 1}, {ID: 2}, {ID: 3}}, nil).
+		Times(1)
+
+	// Uma query de submissions para os três challenges...
+	mockRepo.EXPECT().
+		GetSubmissionsByChallengeIDs(gomock.Any(), gomock.InAnyOrder([]uint{1, 2, 3})).
+		Return([]*challenges.ChallengeSubmission{
+			{ID: 10, ChallengeID: 1},
+			{ID: 11, ChallengeID: 1},
+			{ID: 20, ChallengeID: 2},
+		}, nil).
+		Times(1)
+
+	// ...e uma query de votos para todas as submissions
+	mockRepo.EXPECT().
+		GetVotesBySubmissionIDs(gomock.Any(), gomock.InAnyOrder([]uint{10, 11, 20})).
+		Return([]*challenges.ChallengeVote{
+			{ID: 100, SubmissionID: 10, Approved: true},
+			{ID: 101, SubmissionID: 10, Approved: false},
+			{ID: 200, SubmissionID: 20, Approved: true},
+		}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().GetSubmissionsByChallengeID(gomock.Any(), gomock.Any()).Times(0)
+	mockRepo.EXPECT().GetVotesBySubmissionID(gomock.Any(), gomock.Any()).Times(0)
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: *challenges.Queries(service, log),
+		}),
+	})
+	require.NoError(t, err)
+
+	ctx := challenges.WithLoaders(context.Background(), service, dataloader.DefaultConfig())
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ challenges { edges { node { id submissions { id votes { id approved } } } } } }`,
+		Context:       ctx,
+	})
+
+	require.Empty(t, result.Errors)
+	edges := result.Data.(map[string]interface{})["challenges"].(map[string]interface{})["edges"].([]interface{})
+	require.Len(t, edges, 3)
+	node := func(i int) map[string]interface{} {
+		return edges[i].(map[string]interface{})["node"].(map[string]interface{})
+	}
+
+	first := node(0)["submissions"].([]interface{})
+	require.Len(t, first, 2)
+	assert.Len(t, first[0].(map[string]interface{})["votes"], 2)
+	assert.Empty(t, node(2)["submissions"])
+}
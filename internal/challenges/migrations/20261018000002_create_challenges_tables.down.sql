@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "challenge_votes";
+DROP TABLE IF EXISTS "submission_evidence";
+DROP TABLE IF EXISTS "challenge_submissions";
+DROP TABLE IF EXISTS "challenge_tags";
+DROP TABLE IF EXISTS "challenges";
+DROP TABLE IF EXISTS "tags";
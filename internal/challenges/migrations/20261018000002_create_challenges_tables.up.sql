@@ -0,0 +1,86 @@
+-- Schema inicial do módulo challenges (equivalente ao AutoMigrate anterior)
+CREATE TABLE IF NOT EXISTS "tags" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_tags_name" ON "tags" ("name");
+
+CREATE TABLE IF NOT EXISTS "challenges" (
+    "id" bigserial,
+    "title" text NOT NULL,
+    "description" text,
+    "xp_reward" bigint NOT NULL,
+    "status" text NOT NULL DEFAULT 'active',
+    "category" text,
+    "difficulty" text NOT NULL DEFAULT 'beginner',
+    "estimated_minutes" bigint NOT NULL DEFAULT 0,
+    "max_attempts" bigint NOT NULL DEFAULT 0,
+    "cooldown_minutes" bigint NOT NULL DEFAULT 0,
+    "allowed_proof_domains" jsonb,
+    "denied_proof_domains" jsonb,
+    "require_reachable_proof" boolean NOT NULL DEFAULT false,
+    "search_vector" tsvector GENERATED ALWAYS AS (to_tsvector('portuguese', coalesce(title, '') || ' ' || coalesce(description, ''))) STORED,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_challenges_xp_reward" ON "challenges" ("xp_reward");
+CREATE INDEX IF NOT EXISTS "idx_challenges_deleted_at" ON "challenges" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_challenges_search" ON "challenges" USING gin("search_vector");
+CREATE INDEX IF NOT EXISTS "idx_challenges_difficulty" ON "challenges" ("difficulty");
+CREATE INDEX IF NOT EXISTS "idx_challenges_category" ON "challenges" ("category");
+
+CREATE TABLE IF NOT EXISTS "challenge_tags" (
+    "challenge_id" bigint,
+    "tag_id" bigint,
+    PRIMARY KEY ("challenge_id","tag_id"),
+    CONSTRAINT "fk_challenge_tags_challenge" FOREIGN KEY ("challenge_id") REFERENCES "challenges"("id"),
+    CONSTRAINT "fk_challenge_tags_tag" FOREIGN KEY ("tag_id") REFERENCES "tags"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "challenge_submissions" (
+    "id" bigserial,
+    "challenge_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "attempt_number" bigint NOT NULL DEFAULT 1,
+    "proof_url" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'pending',
+    "decision_reason" text,
+    "revoked_by" bigint,
+    "revoked_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_challenge_submissions_user_id" ON "challenge_submissions" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_submission_attempt" ON "challenge_submissions" ("challenge_id","user_id","attempt_number");
+CREATE INDEX IF NOT EXISTS "idx_challenge_submissions_challenge_id" ON "challenge_submissions" ("challenge_id");
+
+CREATE TABLE IF NOT EXISTS "submission_evidence" (
+    "id" bigserial,
+    "submission_id" bigint NOT NULL,
+    "type" text NOT NULL,
+    "url" text,
+    "text" text,
+    "file_ref" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_challenge_submissions_evidence" FOREIGN KEY ("submission_id") REFERENCES "challenge_submissions"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_submission_evidence_submission_id" ON "submission_evidence" ("submission_id");
+
+CREATE TABLE IF NOT EXISTS "challenge_votes" (
+    "id" bigserial,
+    "submission_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "approved" boolean NOT NULL,
+    "time_check" bigint NOT NULL,
+    "is_valid" boolean NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_challenge_votes_user_id" ON "challenge_votes" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_challenge_votes_submission_id" ON "challenge_votes" ("submission_id");
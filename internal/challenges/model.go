@@ -2,30 +2,99 @@ package challenges
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
 )
 
 type Challenge struct {
-	ID          uint           `json:"id" gorm:"primarykey"`
-	Title       string         `json:"title" gorm:"not null"`
-	Description string         `json:"description" gorm:"type:text"`
-	XPReward    int            `json:"xp_reward" gorm:"not null"`
-	Status      string         `json:"status" gorm:"not null;default:'active'"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID               uint   `json:"id" gorm:"primarykey"`
+	Title            string `json:"title" gorm:"not null"`
+	Description      string `json:"description" gorm:"type:text"`
+	XPReward         int    `json:"xp_reward" gorm:"not null;index"`
+	Status           string `json:"status" gorm:"not null;default:'active'"`
+	Category         string `json:"category" gorm:"index"`
+	Difficulty       string `json:"difficulty" gorm:"not null;default:'beginner';index"`
+	EstimatedMinutes int    `json:"estimated_minutes" gorm:"not null;default:0"`
+	MaxAttempts      int    `json:"max_attempts" gorm:"not null;default:0"`
+	CooldownMinutes  int    `json:"cooldown_minutes" gorm:"not null;default:0"`
+
+	AllowedProofDomains   database.StringList `json:"allowed_proof_domains"`
+	DeniedProofDomains    database.StringList `json:"denied_proof_domains"`
+	RequireReachableProof bool                `json:"require_reachable_proof" gorm:"not null;default:false"`
+
+	Tags         []Tag             `json:"tags" gorm:"many2many:challenge_tags;"`
+	SearchVector database.TSVector `json:"-" gorm:"type:tsvector GENERATED ALWAYS AS (to_tsvector('portuguese', coalesce(title, '') || ' ' || coalesce(description, ''))) STORED;->:false;<-:false"`
+	SearchRank   float64           `json:"-" gorm:"column:search_rank;->;-:migration"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt    `json:"-" gorm:"index"`
+}
+
+// Tag - etiqueta livre associada a challenges (many-to-many via challenge_tags)
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type ChallengeSubmission struct {
-	ID          uint      `json:"id" gorm:"primarykey"`
-	ChallengeID uint      `json:"challenge_id" gorm:"not null;index"`
-	UserID      uint      `json:"user_id" gorm:"not null;index"`
-	ProofURL    string    `json:"proof_url" gorm:"not null"`
-	Status      string    `json:"status" gorm:"not null;default:'pending'"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID             uint       `json:"id" gorm:"primarykey"`
+	ChallengeID    uint       `json:"challenge_id" gorm:"not null;index;uniqueIndex:idx_submission_attempt,priority:1"`
+	UserID         uint       `json:"user_id" gorm:"not null;index;uniqueIndex:idx_submission_attempt,priority:2"`
+	AttemptNumber  int        `json:"attempt_number" gorm:"not null;default:1;uniqueIndex:idx_submission_attempt,priority:3"`
+	ProofURL       string     `json:"proof_url" gorm:"not null"`
+	Status         string     `json:"status" gorm:"not null;default:'pending'"`
+	DecisionReason string     `json:"decision_reason"`
+	RevokedBy      *uint      `json:"revoked_by"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Evidence []SubmissionEvidence `json:"evidence" gorm:"foreignKey:SubmissionID"`
+}
+
+// SubmissionEvidence - evidência adicional anexada a uma submission
+type SubmissionEvidence struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	SubmissionID uint      `json:"submission_id" gorm:"not null;index"`
+	Type         string    `json:"type" gorm:"not null"`
+	URL          string    `json:"url"`
+	Text         string    `json:"text" gorm:"type:text"`
+	FileRef      string    `json:"file_ref"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// SubmissionAttempt - tentativa de um usuário em um challenge com seus votos
+type SubmissionAttempt struct {
+	Submission *ChallengeSubmission
+	Votes      []*ChallengeVote
+}
+
+// SubmissionStatusEvent - mudança de status de uma submission entregue via subscription
+type SubmissionStatusEvent struct {
+	SubmissionID uint
+	ChallengeID  uint
+	UserID       uint
+	Status       string
+	Reason       string
+}
+
+// SubmissionPolicy - regras de resubmissão aplicadas quando o challenge não define as suas
+type SubmissionPolicy struct {
+	MaxAttempts int
+	Cooldown    time.Duration
+}
+
+// DefaultSubmissionPolicy - 3 tentativas com 1 hora de espera após cada rejeição
+func DefaultSubmissionPolicy() SubmissionPolicy {
+	return SubmissionPolicy{
+		MaxAttempts: 3,
+		Cooldown:    time.Hour,
+	}
 }
 
 type ChallengeVote struct {
@@ -42,24 +111,96 @@ const (
 	ChallengeStatusActive   = "active"
 	ChallengeStatusInactive = "inactive"
 
+	DifficultyBeginner     = "beginner"
+	DifficultyIntermediate = "intermediate"
+	DifficultyAdvanced     = "advanced"
+	DifficultyExpert       = "expert"
+
 	SubmissionStatusPending  = "pending"
 	SubmissionStatusApproved = "approved"
 	SubmissionStatusRejected = "rejected"
+	SubmissionStatusRevoked  = "revoked"
+
+	EvidenceTypeURL  = "url"
+	EvidenceTypeText = "text"
+	EvidenceTypeFile = "file"
+
+	DecisionReasonCommunityApproved = "Approved by community vote"
+	DecisionReasonCommunityRejected = "Rejected by community vote"
+)
+
+const (
+	SortFieldCreatedAt     = "created_at"
+	SortFieldXPReward      = "xp_reward"
+	SortFieldDifficulty    = "difficulty"
+	SortFieldEstimatedTime = "estimated_minutes"
+	SortFieldTitle         = "title"
+	SortFieldRelevance     = "relevance"
+
+	SortDirectionAsc  = "asc"
+	SortDirectionDesc = "desc"
 )
 
 type CreateChallengeInput struct {
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description"`
-	XPReward    int    `json:"xp_reward" validate:"required,min=1"`
+	Title            string   `json:"title" validate:"required"`
+	Description      string   `json:"description"`
+	XPReward         int      `json:"xp_reward" validate:"required,min=1"`
+	Category         string   `json:"category"`
+	Tags             []string `json:"tags"`
+	Difficulty       string   `json:"difficulty" validate:"omitempty,oneof=beginner intermediate advanced expert"`
+	EstimatedMinutes int      `json:"estimated_minutes" validate:"min=0"`
+	MaxAttempts      int      `json:"max_attempts" validate:"min=0"`
+	CooldownMinutes  int      `json:"cooldown_minutes" validate:"min=0"`
+
+	AllowedProofDomains   []string `json:"allowed_proof_domains"`
+	DeniedProofDomains    []string `json:"denied_proof_domains"`
+	RequireReachableProof bool     `json:"require_reachable_proof"`
+}
+
+// ChallengeFilter - filtros opcionais para busca de challenges
+type ChallengeFilter struct {
+	Status     string
+	Category   string
+	Tags       []string
+	Difficulty string
+	MinXP      *int
+	MaxXP      *int
+}
+
+// ChallengeSort - ordenação da busca de challenges
+type ChallengeSort struct {
+	Field     string
+	Direction string
+}
+
+// SearchChallengesInput - parâmetros da busca de challenges (paginação via pagination.Args)
+type SearchChallengesInput struct {
+	Search string
+	Filter ChallengeFilter
+	Sort   ChallengeSort
 }
 
 type SubmitChallengeInput struct {
-	ChallengeID string `json:"challengeID" validate:"required"`
-	ProofURL    string `json:"proofURL" validate:"required,url"`
+	ChallengeID string          `json:"challengeID" validate:"required,numeric"`
+	ProofURL    string          `json:"proofURL" validate:"required,url,urlscheme=https http"`
+	Evidence    []EvidenceInput `json:"evidence" validate:"max=10,dive"`
+}
+
+// EvidenceInput - evidência enviada junto com a submission
+type EvidenceInput struct {
+	Type    string `json:"type" validate:"required,oneof=url text file"`
+	URL     string `json:"url"`
+	Text    string `json:"text" validate:"max=5000"`
+	FileRef string `json:"fileRef"`
+}
+
+type RevokeSubmissionInput struct {
+	SubmissionID string `json:"submissionID" validate:"required,numeric"`
+	Reason       string `json:"reason" validate:"required,notblank"`
 }
 
 type VoteChallengeInput struct {
-	SubmissionID string `json:"submissionID" validate:"required"`
+	SubmissionID string `json:"submissionID" validate:"required,numeric"`
 	Approved     bool   `json:"approved"`
 	TimeCheck    int    `json:"timeCheck" validate:"required,min=1"`
 }
@@ -68,10 +209,18 @@ func (Challenge) TableName() string {
 	return "challenges"
 }
 
+func (Tag) TableName() string {
+	return "tags"
+}
+
 func (ChallengeSubmission) TableName() string {
 	return "challenge_submissions"
 }
 
+func (SubmissionEvidence) TableName() string {
+	return "submission_evidence"
+}
+
 func (ChallengeVote) TableName() string {
 	return "challenge_votes"
 }
@@ -86,9 +235,87 @@ func (c *Challenge) Validate() error {
 	if c.Status == "" {
 		c.Status = ChallengeStatusActive
 	}
+	if c.Difficulty == "" {
+		c.Difficulty = DifficultyBeginner
+	}
+	if !IsValidDifficulty(c.Difficulty) {
+		return ErrInvalidDifficulty
+	}
+	if c.EstimatedMinutes < 0 {
+		return ErrInvalidEstimatedTime
+	}
+	if c.MaxAttempts < 0 {
+		return ErrInvalidMaxAttempts
+	}
+	if c.CooldownMinutes < 0 {
+		return ErrInvalidCooldown
+	}
 	return nil
 }
 
+// AttemptLimit - limite de tentativas do challenge (0 usa o padrão da política)
+func (c *Challenge) AttemptLimit(policy SubmissionPolicy) int {
+	if c.MaxAttempts > 0 {
+		return c.MaxAttempts
+	}
+	return policy.MaxAttempts
+}
+
+// ResubmissionCooldown - espera entre tentativas (0 usa o padrão da política)
+func (c *Challenge) ResubmissionCooldown(policy SubmissionPolicy) time.Duration {
+	if c.CooldownMinutes > 0 {
+		return time.Duration(c.CooldownMinutes) * time.Minute
+	}
+	return policy.Cooldown
+}
+
+// TagNames - retorna os nomes das tags do challenge
+func (c *Challenge) TagNames() []string {
+	names := make([]string, 0, len(c.Tags))
+	for _, tag := range c.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func IsValidDifficulty(difficulty string) bool {
+	switch difficulty {
+	case DifficultyBeginner, DifficultyIntermediate, DifficultyAdvanced, DifficultyExpert:
+		return true
+	}
+	return false
+}
+
+// DifficultyRank - posição da dificuldade na ordenação (desconhecidas ficam por último)
+func DifficultyRank(difficulty string) int {
+	switch difficulty {
+	case DifficultyBeginner:
+		return 1
+	case DifficultyIntermediate:
+		return 2
+	case DifficultyAdvanced:
+		return 3
+	case DifficultyExpert:
+		return 4
+	}
+	return 5
+}
+
+// NormalizeTags - remove espaços, converte para minúsculas e elimina duplicadas
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
 func (cs *ChallengeSubmission) IsPending() bool {
 	return cs.Status == SubmissionStatusPending
 }
@@ -101,6 +328,10 @@ func (cs *ChallengeSubmission) IsRejected() bool {
 	return cs.Status == SubmissionStatusRejected
 }
 
+func (cs *ChallengeSubmission) IsRevoked() bool {
+	return cs.Status == SubmissionStatusRevoked
+}
+
 func NewChallengeVote(submissionID, userID uint, approved bool, timeCheck int) *ChallengeVote {
 	const minValidTime = 60
 
@@ -121,4 +352,9 @@ var (
 	ErrNotPending       = errors.New("submission is not pending")
 	ErrAlreadyVoted     = errors.New("user has already voted on this submission")
 	ErrInsufficientTime = errors.New("insufficient time spent reviewing")
+
+	ErrInvalidDifficulty    = errors.New("difficulty must be one of beginner, intermediate, advanced, expert")
+	ErrInvalidEstimatedTime = errors.New("estimated time cannot be negative")
+	ErrInvalidMaxAttempts   = errors.New("max attempts cannot be negative")
+	ErrInvalidCooldown      = errors.New("cooldown cannot be negative")
 )
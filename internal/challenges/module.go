@@ -0,0 +1,95 @@
+package challenges
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/rafaelcoelhox/labbend/pkg/complexity"
+	"github.com/rafaelcoelhox/labbend/pkg/dataloader"
+	"github.com/rafaelcoelhox/labbend/pkg/graphqlws"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/module"
+	"github.com/rafaelcoelhox/labbend/pkg/rest"
+)
+
+// ModuleName - nome do módulo no registry (usado em DependsOn)
+const ModuleName = "challenges"
+
+// Settings - configuração lida de Deps.Config; sem ela valem os defaults
+type Settings interface {
+	ChallengesSubmissionPolicy() SubmissionPolicy
+	ChallengesProofFetchTimeout() time.Duration
+}
+
+// Module - registro do módulo challenges na aplicação
+type Module struct {
+	module.Base
+	service      Service
+	logger       logger.Logger
+	loaderConfig dataloader.Config
+}
+
+// NewModule - factory registrada no init.go; depende do módulo users
+func NewModule(deps *module.Deps) (module.Module, error) {
+	userService, ok := deps.Service("users").(UserService)
+	if !ok {
+		return nil, fmt.Errorf("users service does not implement challenges.UserService")
+	}
+
+	policy := DefaultSubmissionPolicy()
+	fetchTimeout := 5 * time.Second
+	if settings, ok := deps.Config.(Settings); ok {
+		policy = settings.ChallengesSubmissionPolicy()
+		fetchTimeout = settings.ChallengesProofFetchTimeout()
+	}
+
+	repo := NewRepository(deps.DB)
+	service := NewService(repo, userService, deps.Logger, deps.EventBus, deps.TxManager, deps.SagaManager,
+		policy, NewProofValidator(NewHTTPProofFetcher(fetchTimeout)), deps.Cache(ModuleName))
+
+	return &Module{
+		service:      service,
+		logger:       deps.Logger,
+		loaderConfig: deps.LoaderConfig,
+	}, nil
+}
+
+func (m *Module) Name() string         { return ModuleName }
+func (m *Module) Service() interface{} { return m.service }
+
+func (m *Module) Queries() graphql.Fields {
+	return *Queries(m.service, m.logger)
+}
+
+func (m *Module) Mutations() graphql.Fields {
+	return *Mutations(m.service, m.logger)
+}
+
+func (m *Module) Subscriptions(bus graphqlws.EventSubscriber) graphql.Fields {
+	return *Subscriptions(bus, m.logger)
+}
+
+func (m *Module) Costs() complexity.Costs {
+	return FieldCosts
+}
+
+// RESTRoutes - endpoints REST em /api/v1
+func (m *Module) RESTRoutes() []rest.Route {
+	return RESTRoutes(m.service)
+}
+
+// models - modelos GORM do módulo; registrados em database.RegisterModel
+// para o AutoMigrate do SQLite (desenvolvimento local e testes)
+var models = []interface{}{&Tag{}, &Challenge{}, &ChallengeSubmission{}, &SubmissionEvidence{}, &ChallengeVote{}}
+
+func (m *Module) Models() []interface{} {
+	return models
+}
+
+// WithRequestContext - dataloaders por request
+func (m *Module) WithRequestContext(ctx context.Context) context.Context {
+	return WithLoaders(ctx, m.service, m.loaderConfig)
+}
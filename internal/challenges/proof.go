@@ -0,0 +1,297 @@
+package challenges
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// MaxEvidenceItems - limite de evidências por submission
+	MaxEvidenceItems = 10
+	// MaxEvidenceTextLength - tamanho máximo de uma evidência textual
+	MaxEvidenceTextLength = 5000
+)
+
+// ProofFetcher - verifica se uma URL de prova está acessível (plugável para testes)
+type ProofFetcher interface {
+	Head(ctx context.Context, rawURL string) (int, error)
+}
+
+// HTTPProofFetcher - ProofFetcher baseado em net/http. A URL vem do usuário,
+// então o dialer só conecta em IPs públicos (checados após o DNS, o que cobre
+// DNS rebinding) e cada redirect passa pela mesma checagem
+type HTTPProofFetcher struct {
+	client      *http.Client
+	allowedAddr func(netip.Addr) bool
+}
+
+// ProofFetcherOption - configuração do HTTPProofFetcher
+type ProofFetcherOption func(*HTTPProofFetcher)
+
+// WithAddressFilter - substitui a checagem de IPs públicos (ex.: liberar
+// loopback em testes com httptest)
+func WithAddressFilter(allowed func(netip.Addr) bool) ProofFetcherOption {
+	return func(f *HTTPProofFetcher) {
+		f.allowedAddr = allowed
+	}
+}
+
+// maxProofRedirects - redirects seguidos por um HEAD de prova
+const maxProofRedirects = 5
+
+func NewHTTPProofFetcher(timeout time.Duration, opts ...ProofFetcherOption) *HTTPProofFetcher {
+	f := &HTTPProofFetcher{allowedAddr: IsPublicAddr}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	dialer := &net.Dialer{Timeout: timeout, Control: f.controlDial}
+	transport := &http.Transport{
+		// Sem proxy: a checagem do dialer valeria para o proxy, não para o destino
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	f.client = &http.Client{
+		Timeout:       timeout,
+		Transport:     transport,
+		CheckRedirect: checkProofRedirect,
+	}
+	return f
+}
+
+// controlDial - roda após a resolução de DNS, com o IP que será conectado
+func (f *HTTPProofFetcher) controlDial(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return ErrProofAddressNotAllowed
+	}
+	if !f.allowedAddr(addrPort.Addr().Unmap()) {
+		return ErrProofAddressNotAllowed
+	}
+	return nil
+}
+
+// checkProofRedirect - limita a cadeia e os schemes; o IP de cada destino é
+// checado pelo dialer
+func checkProofRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxProofRedirects {
+		return ErrProofTooManyRedirects
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return ErrProofSchemeNotAllowed
+	}
+	return nil
+}
+
+// nonPublicPrefixes - faixas não cobertas pelos métodos de netip.Addr
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "esta" rede
+	netip.MustParsePrefix("100.64.0.0/10"),  // CGNAT
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"),  // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),    // reservado e broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"), // NAT64 local
+	netip.MustParsePrefix("2001:db8::/32"),  // documentação
+	netip.MustParsePrefix("100::/64"),       // discard
+	netip.MustParsePrefix("2002::/16"),      // 6to4 (pode embutir IPv4 privado)
+}
+
+// IsPublicAddr - o IP é roteável na internet: rejeita loopback, RFC1918,
+// link-local (incluindo 169.254.169.254 de metadata), multicast, ULA e faixas reservadas
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// Head - executa um HEAD na URL e retorna o status HTTP
+func (f *HTTPProofFetcher) Head(ctx context.Context, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// ProofValidator - pipeline de validação das provas de uma submission:
+// sintaxe da URL, allowlist de schemes, domínios permitidos/negados do
+// challenge e, opcionalmente, acessibilidade via ProofFetcher
+type ProofValidator struct {
+	allowedSchemes map[string]bool
+	fetcher        ProofFetcher
+}
+
+// NewProofValidator - cria o validador; fetcher nil desativa a checagem de acessibilidade
+func NewProofValidator(fetcher ProofFetcher, allowedSchemes ...string) *ProofValidator {
+	if len(allowedSchemes) == 0 {
+		allowedSchemes = []string{"https", "http"}
+	}
+
+	schemes := make(map[string]bool, len(allowedSchemes))
+	for _, scheme := range allowedSchemes {
+		schemes[strings.ToLower(scheme)] = true
+	}
+
+	return &ProofValidator{
+		allowedSchemes: schemes,
+		fetcher:        fetcher,
+	}
+}
+
+// Validate - valida a URL principal e todas as evidências da submission
+func (v *ProofValidator) Validate(ctx context.Context, challenge *Challenge, proofURL string, evidence []EvidenceInput) error {
+	if len(evidence) > MaxEvidenceItems {
+		return ErrTooManyEvidence
+	}
+
+	if err := v.ValidateURL(ctx, challenge, proofURL); err != nil {
+		return err
+	}
+
+	for i, item := range evidence {
+		if err := v.validateEvidence(ctx, challenge, item); err != nil {
+			return fmt.Errorf("evidence %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// ValidateURL - aplica todas as etapas da pipeline a uma URL
+func (v *ProofValidator) ValidateURL(ctx context.Context, challenge *Challenge, rawURL string) error {
+	if strings.TrimSpace(rawURL) == "" {
+		return ErrInvalidProofURL
+	}
+
+	parsed, err := url.ParseRequestURI(rawURL)
+	if err != nil || parsed.Host == "" {
+		return ErrMalformedProofURL
+	}
+
+	if !v.allowedSchemes[strings.ToLower(parsed.Scheme)] {
+		return ErrProofSchemeNotAllowed
+	}
+
+	if err := checkProofDomain(challenge, parsed.Hostname()); err != nil {
+		return err
+	}
+
+	if challenge.RequireReachableProof && v.fetcher != nil {
+		status, err := v.fetcher.Head(ctx, parsed.String())
+		if err != nil || status < 200 || status >= 400 {
+			return ErrProofUnreachable
+		}
+	}
+	return nil
+}
+
+func (v *ProofValidator) validateEvidence(ctx context.Context, challenge *Challenge, item EvidenceInput) error {
+	switch item.Type {
+	case EvidenceTypeURL:
+		return v.ValidateURL(ctx, challenge, item.URL)
+	case EvidenceTypeText:
+		text := strings.TrimSpace(item.Text)
+		if text == "" {
+			return ErrEmptyEvidenceText
+		}
+		if len(text) > MaxEvidenceTextLength {
+			return ErrEvidenceTextTooLong
+		}
+		return nil
+	case EvidenceTypeFile:
+		if strings.TrimSpace(item.FileRef) == "" {
+			return ErrEmptyEvidenceFile
+		}
+		return nil
+	}
+	return ErrInvalidEvidenceType
+}
+
+// checkProofDomain - a lista de negados tem precedência; com allowlist definida o host precisa casar
+func checkProofDomain(challenge *Challenge, host string) error {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, denied := range challenge.DeniedProofDomains {
+		if matchesDomain(host, denied) {
+			return ErrProofDomainDenied
+		}
+	}
+
+	if len(challenge.AllowedProofDomains) == 0 {
+		return nil
+	}
+	for _, allowed := range challenge.AllowedProofDomains {
+		if matchesDomain(host, allowed) {
+			return nil
+		}
+	}
+	return ErrProofDomainNotAllowed
+}
+
+// matchesDomain - casa o domínio exato ou qualquer subdomínio dele
+func matchesDomain(host, domain string) bool {
+	domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "."))
+	if domain == "" {
+		return false
+	}
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// NormalizeDomains - remove espaços, converte para minúsculas e elimina duplicados
+func NormalizeDomains(domains []string) []string {
+	seen := make(map[string]bool, len(domains))
+	normalized := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "."))
+		if domain == "" || seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		normalized = append(normalized, domain)
+	}
+	return normalized
+}
+
+var (
+	ErrMalformedProofURL      = errors.New("proof URL is malformed")
+	ErrProofSchemeNotAllowed  = errors.New("proof URL scheme is not allowed")
+	ErrProofDomainDenied      = errors.New("proof URL domain is denied for this challenge")
+	ErrProofDomainNotAllowed  = errors.New("proof URL domain is not allowed for this challenge")
+	ErrProofUnreachable       = errors.New("proof URL is not reachable")
+	ErrProofAddressNotAllowed = errors.New("proof URL resolves to a non-public address")
+	ErrProofTooManyRedirects  = fmt.Errorf("proof URL exceeded %d redirects", maxProofRedirects)
+
+	ErrTooManyEvidence     = fmt.Errorf("at most %d evidence items are allowed", MaxEvidenceItems)
+	ErrInvalidEvidenceType = errors.New("evidence type must be one of url, text, file")
+	ErrEmptyEvidenceText   = errors.New("text evidence cannot be empty")
+	ErrEvidenceTextTooLong = fmt.Errorf("text evidence cannot exceed %d characters", MaxEvidenceTextLength)
+	ErrEmptyEvidenceFile   = errors.New("file evidence requires a file reference")
+)
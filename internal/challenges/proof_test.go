@@ -0,0 +1,192 @@
+package challenges_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/rafaelcoelhox/labbend/internal/challenges"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProofValidator_ValidateURL(t *testing.T) {
+	validator := challenges.NewProofValidator(nil)
+	challenge := &challenges.Challenge{
+		AllowedProofDomains: []string{"github.com", "youtube.com"},
+		DeniedProofDomains:  []string{"gist.github.com"},
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		err  error
+	}{
+		{"domínio permitido", "https://github.com/user/repo", nil},
+		{"subdomínio permitido", "https://www.youtube.com/watch?v=1", nil},
+		{"vazia", "", challenges.ErrInvalidProofURL},
+		{"malformada", "github.com/user/repo", challenges.ErrMalformedProofURL},
+		{"scheme não permitido", "ftp://github.com/file", challenges.ErrProofSchemeNotAllowed},
+		{"domínio negado", "https://gist.github.com/user/1", challenges.ErrProofDomainDenied},
+		{"fora da allowlist", "https://example.com/proof", challenges.ErrProofDomainNotAllowed},
+		{"sufixo sem ponto não casa", "https://notgithub.com/proof", challenges.ErrProofDomainNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateURL(context.Background(), challenge, tt.url)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestProofValidator_Reachability(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	validator := challenges.NewProofValidator(challenges.NewHTTPProofFetcher(time.Second, allowLoopback))
+	challenge := &challenges.Challenge{RequireReachableProof: true}
+
+	assert.NoError(t, validator.ValidateURL(context.Background(), challenge, server.URL+"/proof"))
+	assert.Equal(t, challenges.ErrProofUnreachable,
+		validator.ValidateURL(context.Background(), challenge, server.URL+"/missing"))
+
+	// Sem RequireReachableProof o fetcher não é consultado
+	assert.NoError(t, validator.ValidateURL(context.Background(), &challenges.Challenge{}, server.URL+"/missing"))
+}
+
+// allowLoopback - libera o httptest (127.0.0.1) mantendo as demais faixas bloqueadas
+var allowLoopback = challenges.WithAddressFilter(func(addr netip.Addr) bool {
+	return addr.IsLoopback() || challenges.IsPublicAddr(addr)
+})
+
+func TestHTTPProofFetcher_RejectsNonPublicAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	}))
+	defer server.Close()
+
+	fetcher := challenges.NewHTTPProofFetcher(time.Second)
+
+	for _, rawURL := range []string{
+		server.URL + "/proof",
+		"http://localhost:" + serverPort(t, server) + "/proof",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://10.0.0.1/",
+	} {
+		_, err := fetcher.Head(context.Background(), rawURL)
+		assert.ErrorIs(t, err, challenges.ErrProofAddressNotAllowed, rawURL)
+	}
+
+	validator := challenges.NewProofValidator(fetcher)
+	assert.Equal(t, challenges.ErrProofUnreachable,
+		validator.ValidateURL(context.Background(), &challenges.Challenge{RequireReachableProof: true}, server.URL+"/proof"))
+}
+
+func TestHTTPProofFetcher_Redirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metadata":
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		case "/file":
+			http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	// Apenas o primeiro salto (loopback) é liberado; os destinos passam pela checagem
+	fetcher := challenges.NewHTTPProofFetcher(time.Second, allowLoopback)
+
+	_, err := fetcher.Head(context.Background(), server.URL+"/metadata")
+	assert.ErrorIs(t, err, challenges.ErrProofAddressNotAllowed)
+
+	_, err = fetcher.Head(context.Background(), server.URL+"/loop")
+	assert.ErrorIs(t, err, challenges.ErrProofTooManyRedirects)
+
+	_, err = fetcher.Head(context.Background(), server.URL+"/file")
+	assert.ErrorIs(t, err, challenges.ErrProofSchemeNotAllowed)
+
+	status, err := fetcher.Head(context.Background(), server.URL+"/proof")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"8.8.8.8", true},
+		{"2606:4700:4700::1111", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.0.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"255.255.255.255", false},
+		{"224.0.0.1", false},
+		{"::1", false},
+		{"::", false},
+		{"fe80::1", false},
+		{"fd00:ec2::254", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.public, challenges.IsPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func serverPort(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	parsed, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed.Port()
+}
+
+func TestProofValidator_Evidence(t *testing.T) {
+	validator := challenges.NewProofValidator(nil)
+	challenge := &challenges.Challenge{}
+
+	err := validator.Validate(context.Background(), challenge, "https://example.com/proof", []challenges.EvidenceInput{
+		{Type: challenges.EvidenceTypeURL, URL: "https://example.com/screenshot.png"},
+		{Type: challenges.EvidenceTypeText, Text: "Concluído em 42 minutos"},
+		{Type: challenges.EvidenceTypeFile, FileRef: "uploads/7/proof.pdf"},
+	})
+	assert.NoError(t, err)
+
+	err = validator.Validate(context.Background(), challenge, "https://example.com/proof", []challenges.EvidenceInput{
+		{Type: challenges.EvidenceTypeText, Text: "   "},
+	})
+	assert.ErrorIs(t, err, challenges.ErrEmptyEvidenceText)
+
+	err = validator.Validate(context.Background(), challenge, "https://example.com/proof", []challenges.EvidenceInput{
+		{Type: "video"},
+	})
+	assert.ErrorIs(t, err, challenges.ErrInvalidEvidenceType)
+
+	tooMany := make([]challenges.EvidenceInput, challenges.MaxEvidenceItems+1)
+	err = validator.Validate(context.Background(), challenge, "https://example.com/proof", tooMany)
+	assert.ErrorIs(t, err, challenges.ErrTooManyEvidence)
+}
@@ -2,36 +2,49 @@ package challenges
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/rafaelcoelhox/labbend/pkg/database"
 	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
 )
 
 type Repository interface {
 	CreateChallenge(ctx context.Context, challenge *Challenge) error
 	GetChallengeByID(ctx context.Context, id uint) (*Challenge, error)
 	ListChallenges(ctx context.Context, limit, offset int) ([]*Challenge, error)
+	SearchChallenges(ctx context.Context, input SearchChallengesInput, page pagination.Page) ([]*Challenge, error)
+	FindOrCreateTags(ctx context.Context, names []string) ([]Tag, error)
 
 	CreateSubmission(ctx context.Context, submission *ChallengeSubmission) error
 	GetSubmissionByID(ctx context.Context, id uint) (*ChallengeSubmission, error)
 	GetSubmissionsByChallengeID(ctx context.Context, challengeID uint) ([]*ChallengeSubmission, error)
+	GetSubmissionsByChallengeIDs(ctx context.Context, challengeIDs []uint) ([]*ChallengeSubmission, error)
+	GetSubmissionsByChallengeIDPaginated(ctx context.Context, challengeID uint, page pagination.Page) ([]*ChallengeSubmission, error)
 	UpdateSubmission(ctx context.Context, submission *ChallengeSubmission) error
-	HasUserSubmitted(ctx context.Context, userID, challengeID uint) (bool, error)
+	MarkSubmissionRevoked(ctx context.Context, submission *ChallengeSubmission) error
+	MarkSubmissionApproved(ctx context.Context, submission *ChallengeSubmission) error
+	GetUserSubmissions(ctx context.Context, userID, challengeID uint) ([]*ChallengeSubmission, error)
+	LockUserSubmissions(ctx context.Context, userID, challengeID uint) ([]*ChallengeSubmission, error)
 
 	CreateVote(ctx context.Context, vote *ChallengeVote) error
 	GetVotesBySubmissionID(ctx context.Context, submissionID uint) ([]*ChallengeVote, error)
+	GetVotesBySubmissionIDs(ctx context.Context, submissionIDs []uint) ([]*ChallengeVote, error)
 	CountVotesBySubmissionID(ctx context.Context, submissionID uint) (int64, error)
 	HasUserVoted(ctx context.Context, userID, submissionID uint) (bool, error)
+}
 
-	// Métodos transacionais
-	CreateChallengeWithTx(ctx context.Context, tx *gorm.DB, challenge *Challenge) error
-	GetChallengeByIDWithTx(ctx context.Context, tx *gorm.DB, id uint) (*Challenge, error)
-	CreateSubmissionWithTx(ctx context.Context, tx *gorm.DB, submission *ChallengeSubmission) error
-	GetSubmissionByIDWithTx(ctx context.Context, tx *gorm.DB, id uint) (*ChallengeSubmission, error)
-	UpdateSubmissionWithTx(ctx context.Context, tx *gorm.DB, submission *ChallengeSubmission) error
-	CreateVoteWithTx(ctx context.Context, tx *gorm.DB, vote *ChallengeVote) error
+// submissionKeyset - ordenação das submissions por cursor (mais recentes primeiro)
+var submissionKeyset = pagination.Keyset{
+	Name:       "challenge_submissions.created_at",
+	Column:     "challenge_submissions.created_at",
+	IDColumn:   "challenge_submissions.id",
+	Kind:       pagination.KindTime,
+	Descending: true,
 }
 
 type repository struct {
@@ -48,7 +61,7 @@ func (r *repository) CreateChallenge(ctx context.Context, challenge *Challenge)
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	if err := r.db.WithContext(ctx).Create(challenge).Error; err != nil {
+	if err := database.FromContext(ctx, r.db).Create(challenge).Error; err != nil {
 		return errors.Internal(err)
 	}
 	return nil
@@ -59,7 +72,7 @@ func (r *repository) GetChallengeByID(ctx context.Context, id uint) (*Challenge,
 	defer cancel()
 
 	var challenge Challenge
-	err := r.db.WithContext(ctx).First(&challenge, id).Error
+	err := database.FromContext(ctx, r.db).Scopes(database.ReadReplica).Preload("Tags").First(&challenge, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.NotFound("challenge", id)
@@ -74,7 +87,9 @@ func (r *repository) ListChallenges(ctx context.Context, limit, offset int) ([]*
 	defer cancel()
 
 	var challenges []*Challenge
-	err := r.db.WithContext(ctx).
+	err := database.FromContext(ctx, r.db).
+		Scopes(database.ReadReplica).
+		Preload("Tags").
 		Where("status = ?", ChallengeStatusActive).
 		Limit(limit).
 		Offset(offset).
@@ -86,13 +101,115 @@ func (r *repository) ListChallenges(ctx context.Context, limit, offset int) ([]*
 	return challenges, nil
 }
 
+// SearchChallenges - busca full-text (tsvector) com filtros e ordenação, paginada por keyset;
+// retorna até page.Limit+1 challenges
+func (r *repository) SearchChallenges(ctx context.Context, input SearchChallengesInput, page pagination.Page) ([]*Challenge, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := database.FromContext(ctx, r.db).Scopes(database.ReadReplica).Model(&Challenge{}).Preload("Tags")
+
+	if input.Search != "" {
+		// search_rank alimenta os cursores da ordenação por relevância
+		query = query.
+			Select("challenges.*, ts_rank(challenges.search_vector, plainto_tsquery('portuguese', ?)) AS search_rank", input.Search).
+			Where("challenges.search_vector @@ plainto_tsquery('portuguese', ?)", input.Search)
+	}
+
+	filter := input.Filter
+	if filter.Status != "" {
+		query = query.Where("challenges.status = ?", filter.Status)
+	}
+	if filter.Category != "" {
+		query = query.Where("challenges.category = ?", filter.Category)
+	}
+	if filter.Difficulty != "" {
+		query = query.Where("challenges.difficulty = ?", filter.Difficulty)
+	}
+	if filter.MinXP != nil {
+		query = query.Where("challenges.xp_reward >= ?", *filter.MinXP)
+	}
+	if filter.MaxXP != nil {
+		query = query.Where("challenges.xp_reward <= ?", *filter.MaxXP)
+	}
+	if len(filter.Tags) > 0 {
+		// Challenge precisa possuir todas as tags informadas
+		tagged := r.db.
+			Table("challenge_tags").
+			Select("challenge_tags.challenge_id").
+			Joins("JOIN tags ON tags.id = challenge_tags.tag_id").
+			Where("tags.name IN ?", filter.Tags).
+			Group("challenge_tags.challenge_id").
+			Having("COUNT(DISTINCT tags.name) = ?", len(filter.Tags))
+		query = query.Where("challenges.id IN (?)", tagged)
+	}
+
+	keyset, _ := challengeOrdering(input.Sort, input.Search)
+	query, err := keyset.Apply(query, page)
+	if err != nil {
+		return nil, err
+	}
+
+	var challenges []*Challenge
+	if err := query.Find(&challenges).Error; err != nil {
+		return nil, errors.Internal(err)
+	}
+	return challenges, nil
+}
+
+// challengeOrdering - traduz ChallengeSort em keyset seguro (sem interpolar input do usuário)
+// e no extrator do valor ordenado usado para gerar os cursores
+func challengeOrdering(sort ChallengeSort, search string) (pagination.Keyset, func(*Challenge) interface{}) {
+	keyset := pagination.Keyset{
+		Name:       "challenges." + SortFieldCreatedAt,
+		Column:     "challenges.created_at",
+		IDColumn:   "challenges.id",
+		Kind:       pagination.KindTime,
+		Descending: sort.Direction != SortDirectionAsc,
+	}
+	value := func(c *Challenge) interface{} { return c.CreatedAt }
+
+	switch sort.Field {
+	case SortFieldXPReward:
+		keyset.Column, keyset.Kind = "challenges.xp_reward", pagination.KindInt
+		value = func(c *Challenge) interface{} { return c.XPReward }
+	case SortFieldTitle:
+		keyset.Column, keyset.Kind = "challenges.title", pagination.KindString
+		value = func(c *Challenge) interface{} { return c.Title }
+	case SortFieldEstimatedTime:
+		keyset.Column, keyset.Kind = "challenges.estimated_minutes", pagination.KindInt
+		value = func(c *Challenge) interface{} { return c.EstimatedMinutes }
+	case SortFieldDifficulty:
+		keyset.Column, keyset.Kind = "CASE challenges.difficulty WHEN ? THEN 1 WHEN ? THEN 2 WHEN ? THEN 3 WHEN ? THEN 4 ELSE 5 END", pagination.KindInt
+		keyset.Vars = []interface{}{DifficultyBeginner, DifficultyIntermediate, DifficultyAdvanced, DifficultyExpert}
+		value = func(c *Challenge) interface{} { return DifficultyRank(c.Difficulty) }
+	case SortFieldRelevance:
+		if search == "" {
+			return keyset, value
+		}
+		keyset.Column, keyset.Kind = "ts_rank(challenges.search_vector, plainto_tsquery('portuguese', ?))", pagination.KindFloat
+		keyset.Vars = []interface{}{search}
+		value = func(c *Challenge) interface{} { return c.SearchRank }
+	default:
+		return keyset, value
+	}
+
+	keyset.Name = "challenges." + sort.Field
+	return keyset, value
+}
+
 // === SUBMISSION OPERATIONS ===
 
 func (r *repository) CreateSubmission(ctx context.Context, submission *ChallengeSubmission) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	if err := r.db.WithContext(ctx).Create(submission).Error; err != nil {
+	if err := database.FromContext(ctx, r.db).Create(submission).Error; err != nil {
+		// Dois envios concorrentes calcularam a mesma tentativa: o índice
+		// idx_submission_attempt deixa só um passar
+		if database.IsUniqueViolation(err, "idx_submission_attempt", "challenge_submissions.attempt_number") {
+			return errors.AlreadyExists("submission", "attempt_number", submission.AttemptNumber)
+		}
 		return errors.Internal(err)
 	}
 	return nil
@@ -103,7 +220,7 @@ func (r *repository) GetSubmissionByID(ctx context.Context, id uint) (*Challenge
 	defer cancel()
 
 	var submission ChallengeSubmission
-	err := r.db.WithContext(ctx).First(&submission, id).Error
+	err := database.FromContext(ctx, r.db).Preload("Evidence").First(&submission, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.NotFound("submission", id)
@@ -118,7 +235,8 @@ func (r *repository) GetSubmissionsByChallengeID(ctx context.Context, challengeI
 	defer cancel()
 
 	var submissions []*ChallengeSubmission
-	err := r.db.WithContext(ctx).
+	err := database.FromContext(ctx, r.db).
+		Preload("Evidence").
 		Where("challenge_id = ?", challengeID).
 		Order("created_at DESC").
 		Find(&submissions).Error
@@ -128,30 +246,145 @@ func (r *repository) GetSubmissionsByChallengeID(ctx context.Context, challengeI
 	return submissions, nil
 }
 
+// GetSubmissionsByChallengeIDs - submissions de vários challenges em uma única query
+func (r *repository) GetSubmissionsByChallengeIDs(ctx context.Context, challengeIDs []uint) ([]*ChallengeSubmission, error) {
+	if len(challengeIDs) == 0 {
+		return []*ChallengeSubmission{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var submissions []*ChallengeSubmission
+	err := database.FromContext(ctx, r.db).
+		Preload("Evidence").
+		Where("challenge_id IN ?", challengeIDs).
+		Order("created_at DESC").
+		Find(&submissions).Error
+	if err != nil {
+		return nil, errors.Internal(err)
+	}
+	return submissions, nil
+}
+
+// GetSubmissionsByChallengeIDPaginated - submissions do challenge por keyset; retorna até page.Limit+1
+func (r *repository) GetSubmissionsByChallengeIDPaginated(ctx context.Context, challengeID uint, page pagination.Page) ([]*ChallengeSubmission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := database.FromContext(ctx, r.db).
+		Model(&ChallengeSubmission{}).
+		Preload("Evidence").
+		Where("challenge_id = ?", challengeID)
+
+	query, err := submissionKeyset.Apply(query, page)
+	if err != nil {
+		return nil, err
+	}
+
+	var submissions []*ChallengeSubmission
+	if err := query.Find(&submissions).Error; err != nil {
+		return nil, errors.Internal(err)
+	}
+	return submissions, nil
+}
+
 func (r *repository) UpdateSubmission(ctx context.Context, submission *ChallengeSubmission) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	err := r.db.WithContext(ctx).Save(submission).Error
+	err := database.FromContext(ctx, r.db).Save(submission).Error
 	if err != nil {
 		return errors.Internal(err)
 	}
 	return nil
 }
 
-func (r *repository) HasUserSubmitted(ctx context.Context, userID, challengeID uint) (bool, error) {
+// MarkSubmissionRevoked - grava a revogação só se a submission ainda estiver
+// aprovada: duas revogações concorrentes não passam ambas pela checagem
+func (r *repository) MarkSubmissionRevoked(ctx context.Context, submission *ChallengeSubmission) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	var count int64
-	err := r.db.WithContext(ctx).
+	result := database.FromContext(ctx, r.db).
 		Model(&ChallengeSubmission{}).
+		Where("id = ? AND status = ?", submission.ID, SubmissionStatusApproved).
+		Updates(map[string]interface{}{
+			"status":          SubmissionStatusRevoked,
+			"decision_reason": submission.DecisionReason,
+			"revoked_by":      submission.RevokedBy,
+			"revoked_at":      submission.RevokedAt,
+		})
+	if result.Error != nil {
+		return errors.Internal(result.Error)
+	}
+	if result.RowsAffected != 1 {
+		return errors.InvalidInput("submission is no longer approved")
+	}
+	return nil
+}
+
+// MarkSubmissionApproved - grava a aprovação só se a submission ainda estiver
+// pendente ou rejeitada: duas aprovações concorrentes (ex.: votação e apelação)
+// não concedem o XP duas vezes
+func (r *repository) MarkSubmissionApproved(ctx context.Context, submission *ChallengeSubmission) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := database.FromContext(ctx, r.db).
+		Model(&ChallengeSubmission{}).
+		Where("id = ? AND status IN ?", submission.ID, []string{SubmissionStatusPending, SubmissionStatusRejected}).
+		Updates(map[string]interface{}{
+			"status":          SubmissionStatusApproved,
+			"decision_reason": submission.DecisionReason,
+		})
+	if result.Error != nil {
+		return errors.Internal(result.Error)
+	}
+	if result.RowsAffected != 1 {
+		return errors.InvalidInput("submission is no longer pending or rejected")
+	}
+	return nil
+}
+
+// GetUserSubmissions - tentativas do usuário no challenge, da primeira para a última
+func (r *repository) GetUserSubmissions(ctx context.Context, userID, challengeID uint) ([]*ChallengeSubmission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var submissions []*ChallengeSubmission
+	err := database.FromContext(ctx, r.db).
+		Preload("Evidence").
 		Where("user_id = ? AND challenge_id = ?", userID, challengeID).
-		Count(&count).Error
+		Order("attempt_number ASC").
+		Find(&submissions).Error
 	if err != nil {
-		return false, errors.Internal(err)
+		return nil, errors.Internal(err)
 	}
-	return count > 0, nil
+	return submissions, nil
+}
+
+// LockUserSubmissions - como GetUserSubmissions (sem evidências), travando as
+// tentativas até o fim da transação em ctx (SELECT ... FOR UPDATE; no SQLite
+// a escrita já é serializada)
+func (r *repository) LockUserSubmissions(ctx context.Context, userID, challengeID uint) ([]*ChallengeSubmission, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db := database.FromContext(ctx, r.db)
+	if !database.IsSQLite(db) {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var submissions []*ChallengeSubmission
+	err := db.
+		Where("user_id = ? AND challenge_id = ?", userID, challengeID).
+		Order("attempt_number ASC").
+		Find(&submissions).Error
+	if err != nil {
+		return nil, errors.Internal(err)
+	}
+	return submissions, nil
 }
 
 // === VOTE OPERATIONS ===
@@ -160,7 +393,7 @@ func (r *repository) CreateVote(ctx context.Context, vote *ChallengeVote) error
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	if err := r.db.WithContext(ctx).Create(vote).Error; err != nil {
+	if err := database.FromContext(ctx, r.db).Create(vote).Error; err != nil {
 		return errors.Internal(err)
 	}
 	return nil
@@ -171,7 +404,7 @@ func (r *repository) GetVotesBySubmissionID(ctx context.Context, submissionID ui
 	defer cancel()
 
 	var votes []*ChallengeVote
-	err := r.db.WithContext(ctx).
+	err := database.FromContext(ctx, r.db).
 		Where("submission_id = ?", submissionID).
 		Order("created_at DESC").
 		Find(&votes).Error
@@ -181,12 +414,32 @@ func (r *repository) GetVotesBySubmissionID(ctx context.Context, submissionID ui
 	return votes, nil
 }
 
+// GetVotesBySubmissionIDs - votos de várias submissions em uma única query
+func (r *repository) GetVotesBySubmissionIDs(ctx context.Context, submissionIDs []uint) ([]*ChallengeVote, error) {
+	if len(submissionIDs) == 0 {
+		return []*ChallengeVote{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var votes []*ChallengeVote
+	err := database.FromContext(ctx, r.db).
+		Where("submission_id IN ?", submissionIDs).
+		Order("created_at DESC").
+		Find(&votes).Error
+	if err != nil {
+		return nil, errors.Internal(err)
+	}
+	return votes, nil
+}
+
 func (r *repository) CountVotesBySubmissionID(ctx context.Context, submissionID uint) (int64, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := database.FromContext(ctx, r.db).
 		Model(&ChallengeVote{}).
 		Where("submission_id = ?", submissionID).
 		Count(&count).Error
@@ -201,7 +454,7 @@ func (r *repository) HasUserVoted(ctx context.Context, userID, submissionID uint
 	defer cancel()
 
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := database.FromContext(ctx, r.db).
 		Model(&ChallengeVote{}).
 		Where("user_id = ? AND submission_id = ?", userID, submissionID).
 		Count(&count).Error
@@ -211,74 +464,39 @@ func (r *repository) HasUserVoted(ctx context.Context, userID, submissionID uint
 	return count > 0, nil
 }
 
-// Métodos transacionais
-func (r *repository) CreateChallengeWithTx(ctx context.Context, tx *gorm.DB, challenge *Challenge) error {
+// FindOrCreateTags - garante que as tags existam e retorna seus registros, na
+// ordem de names. INSERT ... ON CONFLICT DO NOTHING seguido de SELECT: criações
+// concorrentes da mesma tag não falham e, dentro de InTransaction, as tags só
+// ficam visíveis com o commit do challenge
+func (r *repository) FindOrCreateTags(ctx context.Context, names []string) ([]Tag, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	if err := tx.WithContext(ctx).Create(challenge).Error; err != nil {
-		return errors.Internal(err)
+	db := database.FromContext(ctx, r.db)
+	candidates := make([]Tag, len(names))
+	for i, name := range names {
+		candidates[i] = Tag{Name: name}
 	}
-	return nil
-}
-
-func (r *repository) GetChallengeByIDWithTx(ctx context.Context, tx *gorm.DB, id uint) (*Challenge, error) {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
-	var challenge Challenge
-	err := tx.WithContext(ctx).First(&challenge, id).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.NotFound("challenge", id)
-		}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&candidates).Error; err != nil {
 		return nil, errors.Internal(err)
 	}
-	return &challenge, nil
-}
-
-func (r *repository) CreateSubmissionWithTx(ctx context.Context, tx *gorm.DB, submission *ChallengeSubmission) error {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
-	if err := tx.WithContext(ctx).Create(submission).Error; err != nil {
-		return errors.Internal(err)
-	}
-	return nil
-}
 
-func (r *repository) GetSubmissionByIDWithTx(ctx context.Context, tx *gorm.DB, id uint) (*ChallengeSubmission, error) {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
-	var submission ChallengeSubmission
-	err := tx.WithContext(ctx).First(&submission, id).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.NotFound("submission", id)
-		}
+	var found []Tag
+	if err := db.Where("name IN ?", names).Find(&found).Error; err != nil {
 		return nil, errors.Internal(err)
 	}
-	return &submission, nil
-}
-
-func (r *repository) UpdateSubmissionWithTx(ctx context.Context, tx *gorm.DB, submission *ChallengeSubmission) error {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
-	err := tx.WithContext(ctx).Save(submission).Error
-	if err != nil {
-		return errors.Internal(err)
+	byName := make(map[string]Tag, len(found))
+	for _, tag := range found {
+		byName[tag.Name] = tag
 	}
-	return nil
-}
 
-func (r *repository) CreateVoteWithTx(ctx context.Context, tx *gorm.DB, vote *ChallengeVote) error {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
-	if err := tx.WithContext(ctx).Create(vote).Error; err != nil {
-		return errors.Internal(err)
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tag, ok := byName[name]
+		if !ok {
+			return nil, errors.Internal(fmt.Errorf("tag %q not found after insert", name))
+		}
+		tags = append(tags, tag)
 	}
-	return nil
+	return tags, nil
 }
@@ -0,0 +1,228 @@
+package challenges
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
+	"github.com/rafaelcoelhox/labbend/pkg/rest"
+)
+
+// ===== REST ROUTES (/api/v1) =====
+
+// RESTRoutes - endpoints REST de challenges, submissions e votos
+func RESTRoutes(service Service) []rest.Route {
+	challengeID := rest.IDParam("id", "ID do challenge")
+	submissionID := rest.IDParam("id", "ID da submission")
+
+	return []rest.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/challenges",
+			OperationID: "listChallenges",
+			Summary:     "Busca challenges com filtros (paginação por cursor)",
+			Tags:        []string{"challenges"},
+			Params: append([]rest.Param{
+				{Name: "search", In: "query", Description: "Busca textual em título e descrição"},
+				{Name: "category", In: "query"},
+				{Name: "difficulty", In: "query", Description: "beginner, intermediate, advanced ou expert"},
+				{Name: "tags", In: "query", Description: "Tags separadas por vírgula"},
+				{Name: "sort", In: "query", Description: "created_at, xp_reward, difficulty, estimated_minutes, title ou relevance"},
+				{Name: "direction", In: "query", Description: "asc ou desc"},
+			}, rest.PageParams...),
+			Response: pagination.Connection[*Challenge]{},
+			Handler:  listChallengesHandler(service),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/challenges",
+			OperationID: "createChallenge",
+			Summary:     "Cria um challenge",
+			Tags:        []string{"challenges"},
+			Body:        CreateChallengeInput{},
+			Response:    Challenge{},
+			Status:      http.StatusCreated,
+			Handler:     createChallengeHandler(service),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/challenges/:id",
+			OperationID: "getChallenge",
+			Summary:     "Busca um challenge",
+			Tags:        []string{"challenges"},
+			Params:      []rest.Param{challengeID},
+			Response:    Challenge{},
+			Handler:     getChallengeHandler(service),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/challenges/:id/submissions",
+			OperationID: "listChallengeSubmissions",
+			Summary:     "Submissions de um challenge (paginação por cursor)",
+			Tags:        []string{"submissions"},
+			Params:      append([]rest.Param{challengeID}, rest.PageParams...),
+			Response:    pagination.Connection[*ChallengeSubmission]{},
+			Handler:     listSubmissionsHandler(service),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/submissions",
+			OperationID: "submitChallenge",
+			Summary:     "Envia uma submission para um challenge",
+			Tags:        []string{"submissions"},
+			Body:        SubmitChallengeInput{},
+			Response:    ChallengeSubmission{},
+			Status:      http.StatusCreated,
+			Handler:     submitChallengeHandler(service),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/submissions/:id",
+			OperationID: "getSubmission",
+			Summary:     "Busca uma submission",
+			Tags:        []string{"submissions"},
+			Params:      []rest.Param{submissionID},
+			Response:    ChallengeSubmission{},
+			Handler:     getSubmissionHandler(service),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/submissions/:id/votes",
+			OperationID: "listSubmissionVotes",
+			Summary:     "Votos de uma submission",
+			Tags:        []string{"votes"},
+			Params:      []rest.Param{submissionID},
+			Response:    []ChallengeVote{},
+			Handler:     listVotesHandler(service),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/votes",
+			OperationID: "voteOnSubmission",
+			Summary:     "Vota em uma submission pendente",
+			Tags:        []string{"votes"},
+			Body:        VoteChallengeInput{},
+			Response:    ChallengeVote{},
+			Status:      http.StatusCreated,
+			Handler:     voteHandler(service),
+		},
+	}
+}
+
+func listChallengesHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		args, err := rest.Page(c)
+		if err != nil {
+			return nil, err
+		}
+
+		input := SearchChallengesInput{
+			Search: c.Query("search"),
+			Filter: ChallengeFilter{
+				Category:   c.Query("category"),
+				Difficulty: c.Query("difficulty"),
+			},
+			Sort: ChallengeSort{
+				Field:     c.Query("sort"),
+				Direction: c.Query("direction"),
+			},
+		}
+		if tags := c.Query("tags"); tags != "" {
+			input.Filter.Tags = strings.Split(tags, ",")
+		}
+		return service.SearchChallenges(c.Request.Context(), input, args)
+	}
+}
+
+func createChallengeHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		var input CreateChallengeInput
+		if err := rest.Bind(c, &input); err != nil {
+			return nil, err
+		}
+		return service.CreateChallenge(c.Request.Context(), input)
+	}
+}
+
+func getChallengeHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		id, err := rest.PathID(c, "id")
+		if err != nil {
+			return nil, err
+		}
+		return service.GetChallenge(c.Request.Context(), id)
+	}
+}
+
+func listSubmissionsHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		id, err := rest.PathID(c, "id")
+		if err != nil {
+			return nil, err
+		}
+		args, err := rest.Page(c)
+		if err != nil {
+			return nil, err
+		}
+		return service.ListSubmissionsConnection(c.Request.Context(), id, args)
+	}
+}
+
+func submitChallengeHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		var input SubmitChallengeInput
+		if err := rest.Bind(c, &input); err != nil {
+			return nil, err
+		}
+
+		userID, err := viewerID(c.Request.Context())
+		if err != nil {
+			return nil, err
+		}
+		return service.SubmitChallenge(c.Request.Context(), userID, input)
+	}
+}
+
+func getSubmissionHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		id, err := rest.PathID(c, "id")
+		if err != nil {
+			return nil, err
+		}
+		return service.GetSubmission(c.Request.Context(), id)
+	}
+}
+
+func listVotesHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		id, err := rest.PathID(c, "id")
+		if err != nil {
+			return nil, err
+		}
+		votes, err := service.GetVotesBySubmissionID(c.Request.Context(), id)
+		if err != nil {
+			return nil, err
+		}
+		if votes == nil {
+			votes = []*ChallengeVote{}
+		}
+		return votes, nil
+	}
+}
+
+func voteHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		var input VoteChallengeInput
+		if err := rest.Bind(c, &input); err != nil {
+			return nil, err
+		}
+
+		userID, err := viewerID(c.Request.Context())
+		if err != nil {
+			return nil, err
+		}
+		return service.VoteOnSubmission(c.Request.Context(), userID, input)
+	}
+}
@@ -0,0 +1,71 @@
+package challenges_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rafaelcoelhox/labbend/internal/challenges"
+	"github.com/rafaelcoelhox/labbend/internal/mocks"
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	apperrors "github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/rest"
+)
+
+func TestRESTRoutes_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockChallengesService(ctrl)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := rest.NewAPI(router.Group("/api/v1"), rest.Config{Production: true})
+	api.Handle(challenges.RESTRoutes(mockService)...)
+
+	mockService.EXPECT().
+		GetChallenge(gomock.Any(), uint(5)).
+		Return(&challenges.Challenge{ID: 5, Title: "Aprender Go", XPReward: 100}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/challenges/5", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"title":"Aprender Go"`)
+
+	// Votar exige usuário autenticado
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/votes", strings.NewReader(`{"submissionID":"3","approved":true,"timeCheck":40}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Mesmo service e mesmo erro estruturado do GraphQL
+	mockService.EXPECT().
+		VoteOnSubmission(gomock.Any(), uint(4), challenges.VoteChallengeInput{SubmissionID: "3", Approved: true, TimeCheck: 40}).
+		Return(nil, apperrors.InvalidInput("cannot vote on your own submission"))
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/votes", strings.NewReader(`{"submissionID":"3","approved":true,"timeCheck":40}`))
+	req = req.WithContext(auth.WithViewer(req.Context(), auth.Viewer{UserID: 4}))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":{"code":"INVALID_INPUT","message":"cannot vote on your own submission"}}`, w.Body.String())
+
+	// Votos inexistentes viram lista vazia, não null
+	mockService.EXPECT().GetVotesBySubmissionID(gomock.Any(), uint(3)).Return(nil, nil)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/submissions/3/votes", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.JSONEq(t, `[]`, w.Body.String())
+
+	spec := api.OpenAPI()
+	assert.Contains(t, spec.Paths, "/challenges/{id}/submissions")
+	assert.Contains(t, spec.Components.Schemas, "ChallengeConnection")
+	assert.Equal(t, []string{"challengeID", "proofURL"}, spec.Components.Schemas["SubmitChallengeInput"].Required)
+}
@@ -5,29 +5,31 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
-	"gorm.io/gorm"
 
+	"github.com/rafaelcoelhox/labbend/pkg/cache"
 	"github.com/rafaelcoelhox/labbend/pkg/database"
 	"github.com/rafaelcoelhox/labbend/pkg/errors"
 	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
 	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
 	"github.com/rafaelcoelhox/labbend/pkg/saga"
+	"github.com/rafaelcoelhox/labbend/pkg/validation"
 )
 
 // EventBus - interface para comunicação entre módulos
 type EventBus interface {
 	Publish(event eventbus.Event)
-	PublishWithTx(ctx context.Context, tx *gorm.DB, event eventbus.Event) error
 }
 
 // UserService - interface para comunicação com módulo de usuários
 type UserService interface {
 	GiveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error
-	GiveUserXPWithTx(ctx context.Context, tx *gorm.DB, userID uint, sourceType, sourceID string, amount int) error
-	RemoveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error
-	RemoveUserXPWithTx(ctx context.Context, tx *gorm.DB, userID uint, sourceType, sourceID string, amount int) error
+	RemoveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) (int, error)
+	RestoreUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error
 }
 
 // Service - interface de negócio
@@ -36,14 +38,28 @@ type Service interface {
 	CreateChallenge(ctx context.Context, input CreateChallengeInput) (*Challenge, error)
 	GetChallenge(ctx context.Context, id uint) (*Challenge, error)
 	ListChallenges(ctx context.Context, limit, offset int) ([]*Challenge, error)
+	SearchChallenges(ctx context.Context, input SearchChallengesInput, args pagination.Args) (*pagination.Connection[*Challenge], error)
 
 	// Submission management
 	SubmitChallenge(ctx context.Context, userID uint, input SubmitChallengeInput) (*ChallengeSubmission, error)
+	GetSubmission(ctx context.Context, id uint) (*ChallengeSubmission, error)
 	GetSubmissionsByChallengeID(ctx context.Context, challengeID uint) ([]*ChallengeSubmission, error)
+	GetSubmissionsByChallengeIDs(ctx context.Context, challengeIDs []uint) (map[uint][]*ChallengeSubmission, error)
+	ListSubmissionsConnection(ctx context.Context, challengeID uint, args pagination.Args) (*pagination.Connection[*ChallengeSubmission], error)
+	GetSubmissionAttempts(ctx context.Context, userID, challengeID uint) ([]*SubmissionAttempt, error)
 
 	// Voting system
 	VoteOnSubmission(ctx context.Context, userID uint, input VoteChallengeInput) (*ChallengeVote, error)
 	GetVotesBySubmissionID(ctx context.Context, submissionID uint) ([]*ChallengeVote, error)
+	GetVotesBySubmissionIDs(ctx context.Context, submissionIDs []uint) (map[uint][]*ChallengeVote, error)
+
+	// Decisões sobre submissions (usadas também pela moderação); participam da
+	// transação em ctx
+	ApproveSubmission(ctx context.Context, submissionID uint, reason string) (*ChallengeSubmission, error)
+	RejectSubmission(ctx context.Context, submissionID uint, reason string) (*ChallengeSubmission, error)
+
+	// Revogação de aprovações fraudulentas (saga com compensações)
+	RevokeSubmission(ctx context.Context, adminID uint, input RevokeSubmissionInput) (*ChallengeSubmission, error)
 }
 
 type service struct {
@@ -53,9 +69,18 @@ type service struct {
 	eventBus    EventBus
 	txManager   *database.TxManager
 	sagaManager *saga.SagaManager
+	policy      SubmissionPolicy
+	proofs      *ProofValidator
+	cache       cache.Cache
 }
 
-func NewService(repo Repository, userService UserService, logger logger.Logger, eventBus EventBus, txManager *database.TxManager, sagaManager *saga.SagaManager) Service {
+// NewService - proofValidator nil usa um ProofValidator sem checagem de
+// acessibilidade; challengesCache guarda os challenges lidos (nil desativa)
+func NewService(repo Repository, userService UserService, logger logger.Logger, eventBus EventBus, txManager *database.TxManager, sagaManager *saga.SagaManager, policy SubmissionPolicy, proofValidator *ProofValidator, challengesCache cache.Cache) Service {
+	if proofValidator == nil {
+		proofValidator = NewProofValidator(nil)
+	}
+
 	return &service{
 		repo:        repo,
 		userService: userService,
@@ -63,6 +88,9 @@ func NewService(repo Repository, userService UserService, logger logger.Logger,
 		eventBus:    eventBus,
 		txManager:   txManager,
 		sagaManager: sagaManager,
+		policy:      policy,
+		proofs:      proofValidator,
+		cache:       challengesCache,
 	}
 }
 
@@ -72,38 +100,65 @@ func (s *service) CreateChallenge(ctx context.Context, input CreateChallengeInpu
 	s.logger.Info("creating challenge", zap.String("title", input.Title))
 
 	// Validação
-	if input.Title == "" {
-		return nil, errors.InvalidInput("title is required")
-	}
-	if input.XPReward <= 0 {
-		return nil, errors.InvalidInput("xp reward must be positive")
+	if err := validation.Struct(input); err != nil {
+		return nil, err
 	}
 
 	challenge := &Challenge{
-		Title:       input.Title,
-		Description: input.Description,
-		XPReward:    input.XPReward,
-		Status:      ChallengeStatusActive,
+		Title:            input.Title,
+		Description:      input.Description,
+		XPReward:         input.XPReward,
+		Status:           ChallengeStatusActive,
+		Category:         strings.TrimSpace(input.Category),
+		Difficulty:       input.Difficulty,
+		EstimatedMinutes: input.EstimatedMinutes,
+		MaxAttempts:      input.MaxAttempts,
+		CooldownMinutes:  input.CooldownMinutes,
+
+		AllowedProofDomains:   NormalizeDomains(input.AllowedProofDomains),
+		DeniedProofDomains:    NormalizeDomains(input.DeniedProofDomains),
+		RequireReachableProof: input.RequireReachableProof,
 	}
 
 	if err := challenge.Validate(); err != nil {
 		return nil, errors.InvalidInput(err.Error())
 	}
 
-	if err := s.repo.CreateChallenge(ctx, challenge); err != nil {
-		s.logger.Error("failed to create challenge", zap.Error(err))
+	// Tags e challenge na mesma transação: uma falha não deixa tags órfãs
+	err := s.txManager.InTransaction(ctx, func(ctx context.Context) error {
+		if tagNames := NormalizeTags(input.Tags); len(tagNames) > 0 {
+			tags, err := s.repo.FindOrCreateTags(ctx, tagNames)
+			if err != nil {
+				s.logger.Error("failed to resolve challenge tags", zap.Error(err))
+				return err
+			}
+			challenge.Tags = tags
+		}
+
+		if err := s.repo.CreateChallenge(ctx, challenge); err != nil {
+			s.logger.Error("failed to create challenge", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	// Publish event
-	s.eventBus.Publish(eventbus.Event{
-		Type:   "ChallengeCreated",
-		Source: "challenges",
-		Data: map[string]interface{}{
-			"challengeID": challenge.ID,
-			"title":       challenge.Title,
-			"xpReward":    challenge.XPReward,
-		},
+	s.txManager.OnCommit(ctx, func(context.Context) {
+		s.eventBus.Publish(eventbus.Event{
+			Type:   "ChallengeCreated",
+			Source: "challenges",
+			Data: map[string]interface{}{
+				"challengeID": challenge.ID,
+				"title":       challenge.Title,
+				"xpReward":    challenge.XPReward,
+				"category":    challenge.Category,
+				"difficulty":  challenge.Difficulty,
+				"tags":        challenge.TagNames(),
+			},
+		})
 	})
 
 	s.logger.Info("challenge created successfully", zap.Uint("challenge_id", challenge.ID))
@@ -111,7 +166,7 @@ func (s *service) CreateChallenge(ctx context.Context, input CreateChallengeInpu
 }
 
 func (s *service) GetChallenge(ctx context.Context, id uint) (*Challenge, error) {
-	return s.repo.GetChallengeByID(ctx, id)
+	return s.getCachedChallenge(ctx, id)
 }
 
 func (s *service) ListChallenges(ctx context.Context, limit, offset int) ([]*Challenge, error) {
@@ -129,9 +184,63 @@ func (s *service) ListChallenges(ctx context.Context, limit, offset int) ([]*Cha
 	return s.repo.ListChallenges(ctx, limit, offset)
 }
 
+func (s *service) SearchChallenges(ctx context.Context, input SearchChallengesInput, args pagination.Args) (*pagination.Connection[*Challenge], error) {
+	page, err := args.Page()
+	if err != nil {
+		return nil, err
+	}
+
+	input.Search = strings.TrimSpace(input.Search)
+	input.Filter.Tags = NormalizeTags(input.Filter.Tags)
+
+	// Sem filtro explícito de status, mantém o comportamento de ListChallenges
+	if input.Filter.Status == "" {
+		input.Filter.Status = ChallengeStatusActive
+	}
+	if input.Filter.Difficulty != "" && !IsValidDifficulty(input.Filter.Difficulty) {
+		return nil, errors.InvalidInput(ErrInvalidDifficulty.Error())
+	}
+	if input.Filter.MinXP != nil && input.Filter.MaxXP != nil && *input.Filter.MinXP > *input.Filter.MaxXP {
+		return nil, errors.InvalidInput("minXP cannot be greater than maxXP")
+	}
+
+	switch input.Sort.Field {
+	case "":
+		input.Sort.Field = SortFieldCreatedAt
+		if input.Search != "" {
+			input.Sort.Field = SortFieldRelevance
+		}
+	case SortFieldCreatedAt, SortFieldXPReward, SortFieldDifficulty, SortFieldEstimatedTime, SortFieldTitle, SortFieldRelevance:
+	default:
+		return nil, errors.InvalidInput(fmt.Sprintf("invalid sort field: %s", input.Sort.Field))
+	}
+
+	switch input.Sort.Direction {
+	case "":
+		input.Sort.Direction = SortDirectionDesc
+	case SortDirectionAsc, SortDirectionDesc:
+	default:
+		return nil, errors.InvalidInput(fmt.Sprintf("invalid sort direction: %s", input.Sort.Direction))
+	}
+
+	challenges, err := s.repo.SearchChallenges(ctx, input, page)
+	if err != nil {
+		return nil, err
+	}
+
+	keyset, value := challengeOrdering(input.Sort, input.Search)
+	return pagination.NewConnection(challenges, page, func(c *Challenge) pagination.Cursor {
+		return keyset.Cursor(value(c), c.ID)
+	}), nil
+}
+
 // === SUBMISSION MANAGEMENT ===
 
 func (s *service) SubmitChallenge(ctx context.Context, userID uint, input SubmitChallengeInput) (*ChallengeSubmission, error) {
+	if err := validation.Struct(input); err != nil {
+		return nil, err
+	}
+
 	// Converter string para uint
 	challengeID, err := strconv.ParseUint(input.ChallengeID, 10, 32)
 	if err != nil {
@@ -143,7 +252,7 @@ func (s *service) SubmitChallenge(ctx context.Context, userID uint, input Submit
 		zap.Uint("challenge_id", uint(challengeID)))
 
 	// Verificar se challenge existe
-	challenge, err := s.repo.GetChallengeByID(ctx, uint(challengeID))
+	challenge, err := s.getCachedChallenge(ctx, uint(challengeID))
 	if err != nil {
 		return nil, err
 	}
@@ -152,25 +261,27 @@ func (s *service) SubmitChallenge(ctx context.Context, userID uint, input Submit
 		return nil, errors.InvalidInput("challenge is not active")
 	}
 
-	// Verificar se usuário já submeteu
-	hasSubmitted, err := s.repo.HasUserSubmitted(ctx, userID, uint(challengeID))
+	// Verificar tentativas anteriores (limite, cooldown e status)
+	attempts, err := s.repo.GetUserSubmissions(ctx, userID, uint(challengeID))
 	if err != nil {
 		return nil, err
 	}
-	if hasSubmitted {
-		return nil, errors.AlreadyExists("submission", "user", userID)
+	if err := s.checkResubmission(challenge, attempts); err != nil {
+		return nil, err
 	}
 
-	// Validação
-	if input.ProofURL == "" {
-		return nil, errors.InvalidInput("proof URL is required")
+	// Pipeline de validação das provas (URL, scheme, domínios, acessibilidade)
+	if err := s.proofs.Validate(ctx, challenge, input.ProofURL, input.Evidence); err != nil {
+		return nil, errors.InvalidInput(err.Error())
 	}
 
 	submission := &ChallengeSubmission{
-		ChallengeID: uint(challengeID),
-		UserID:      userID,
-		ProofURL:    input.ProofURL,
-		Status:      SubmissionStatusPending,
+		ChallengeID:   uint(challengeID),
+		UserID:        userID,
+		AttemptNumber: len(attempts) + 1,
+		ProofURL:      input.ProofURL,
+		Status:        SubmissionStatusPending,
+		Evidence:      newSubmissionEvidence(input.Evidence),
 	}
 
 	if err := s.repo.CreateSubmission(ctx, submission); err != nil {
@@ -179,28 +290,146 @@ func (s *service) SubmitChallenge(ctx context.Context, userID uint, input Submit
 	}
 
 	// Publish event
-	s.eventBus.Publish(eventbus.Event{
-		Type:   "ChallengeSubmitted",
-		Source: "challenges",
-		Data: map[string]interface{}{
-			"submissionID": submission.ID,
-			"challengeID":  submission.ChallengeID,
-			"userID":       userID,
-			"proofURL":     submission.ProofURL,
-		},
+	s.txManager.OnCommit(ctx, func(context.Context) {
+		s.eventBus.Publish(eventbus.Event{
+			Type:   "ChallengeSubmitted",
+			Source: "challenges",
+			Data: map[string]interface{}{
+				"submissionID":  submission.ID,
+				"challengeID":   submission.ChallengeID,
+				"userID":        userID,
+				"proofURL":      submission.ProofURL,
+				"attemptNumber": submission.AttemptNumber,
+				"evidenceCount": len(submission.Evidence),
+			},
+		})
 	})
 
-	s.logger.Info("challenge submitted successfully", zap.Uint("submission_id", submission.ID))
+	s.logger.Info("challenge submitted successfully",
+		zap.Uint("submission_id", submission.ID),
+		zap.Int("attempt_number", submission.AttemptNumber))
 	return submission, nil
 }
 
+// newSubmissionEvidence - converte as evidências da entrada no modelo persistido
+func newSubmissionEvidence(items []EvidenceInput) []SubmissionEvidence {
+	if len(items) == 0 {
+		return nil
+	}
+
+	evidence := make([]SubmissionEvidence, 0, len(items))
+	for _, item := range items {
+		evidence = append(evidence, SubmissionEvidence{
+			Type:    item.Type,
+			URL:     strings.TrimSpace(item.URL),
+			Text:    strings.TrimSpace(item.Text),
+			FileRef: strings.TrimSpace(item.FileRef),
+		})
+	}
+	return evidence
+}
+
+// checkResubmission - só permite nova tentativa após rejeição, respeitando limite e cooldown
+func (s *service) checkResubmission(challenge *Challenge, attempts []*ChallengeSubmission) error {
+	if len(attempts) == 0 {
+		return nil
+	}
+
+	for _, attempt := range attempts {
+		switch attempt.Status {
+		case SubmissionStatusPending:
+			return errors.InvalidInput("previous attempt is still pending")
+		case SubmissionStatusApproved:
+			return errors.InvalidInput("challenge already completed")
+		case SubmissionStatusRevoked:
+			return errors.InvalidInput("resubmission is not allowed after a revoked approval")
+		}
+	}
+
+	if limit := challenge.AttemptLimit(s.policy); limit > 0 && len(attempts) >= limit {
+		return errors.InvalidInput(fmt.Sprintf("maximum of %d attempts reached", limit))
+	}
+
+	last := attempts[len(attempts)-1]
+	cooldown := challenge.ResubmissionCooldown(s.policy)
+	if remaining := cooldown - time.Since(last.UpdatedAt); remaining > 0 {
+		return errors.InvalidInput(fmt.Sprintf("resubmission available in %s", remaining.Round(time.Second)))
+	}
+
+	return nil
+}
+
+func (s *service) GetSubmission(ctx context.Context, id uint) (*ChallengeSubmission, error) {
+	return s.repo.GetSubmissionByID(ctx, id)
+}
+
 func (s *service) GetSubmissionsByChallengeID(ctx context.Context, challengeID uint) ([]*ChallengeSubmission, error) {
 	return s.repo.GetSubmissionsByChallengeID(ctx, challengeID)
 }
 
+func (s *service) GetSubmissionsByChallengeIDs(ctx context.Context, challengeIDs []uint) (map[uint][]*ChallengeSubmission, error) {
+	submissions, err := s.repo.GetSubmissionsByChallengeIDs(ctx, challengeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byChallenge := make(map[uint][]*ChallengeSubmission, len(challengeIDs))
+	for _, submission := range submissions {
+		byChallenge[submission.ChallengeID] = append(byChallenge[submission.ChallengeID], submission)
+	}
+	return byChallenge, nil
+}
+
+// ListSubmissionsConnection - submissions do challenge paginadas por cursor
+func (s *service) ListSubmissionsConnection(ctx context.Context, challengeID uint, args pagination.Args) (*pagination.Connection[*ChallengeSubmission], error) {
+	page, err := args.Page()
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := s.repo.GetSubmissionsByChallengeIDPaginated(ctx, challengeID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return pagination.NewConnection(submissions, page, func(submission *ChallengeSubmission) pagination.Cursor {
+		return submissionKeyset.Cursor(submission.CreatedAt, submission.ID)
+	}), nil
+}
+
+func (s *service) GetSubmissionAttempts(ctx context.Context, userID, challengeID uint) ([]*SubmissionAttempt, error) {
+	submissions, err := s.repo.GetUserSubmissions(ctx, userID, challengeID)
+	if err != nil {
+		return nil, err
+	}
+
+	submissionIDs := make([]uint, 0, len(submissions))
+	for _, submission := range submissions {
+		submissionIDs = append(submissionIDs, submission.ID)
+	}
+
+	votesBySubmission, err := s.GetVotesBySubmissionIDs(ctx, submissionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := make([]*SubmissionAttempt, 0, len(submissions))
+	for _, submission := range submissions {
+		attempts = append(attempts, &SubmissionAttempt{
+			Submission: submission,
+			Votes:      votesBySubmission[submission.ID],
+		})
+	}
+	return attempts, nil
+}
+
 // === VOTING SYSTEM ===
 
 func (s *service) VoteOnSubmission(ctx context.Context, userID uint, input VoteChallengeInput) (*ChallengeVote, error) {
+	if err := validation.Struct(input); err != nil {
+		return nil, err
+	}
+
 	// Converter string para uint
 	submissionID, err := strconv.ParseUint(input.SubmissionID, 10, 32)
 	if err != nil {
@@ -245,21 +474,25 @@ func (s *service) VoteOnSubmission(ctx context.Context, userID uint, input VoteC
 	}
 
 	// Publish event
-	s.eventBus.Publish(eventbus.Event{
-		Type:   "ChallengeVoteAdded",
-		Source: "challenges",
-		Data: map[string]interface{}{
-			"voteID":       vote.ID,
-			"submissionID": vote.SubmissionID,
-			"userID":       userID,
-			"approved":     vote.Approved,
-			"timeCheck":    vote.TimeCheck,
-			"isValid":      vote.IsValid,
-		},
+	s.txManager.OnCommit(ctx, func(context.Context) {
+		s.eventBus.Publish(eventbus.Event{
+			Type:   "ChallengeVoteAdded",
+			Source: "challenges",
+			Data: map[string]interface{}{
+				"voteID":       vote.ID,
+				"submissionID": vote.SubmissionID,
+				"userID":       userID,
+				"approved":     vote.Approved,
+				"timeCheck":    vote.TimeCheck,
+				"isValid":      vote.IsValid,
+			},
+		})
 	})
 
-	// Verificar se deve processar resultado
-	go s.processVotingResult(context.Background(), submission)
+	// Verificar se deve processar resultado (só depois que o voto estiver gravado)
+	s.txManager.OnCommit(ctx, func(context.Context) {
+		go s.processVotingResult(context.Background(), submission)
+	})
 
 	s.logger.Info("vote created successfully", zap.Uint("vote_id", vote.ID))
 	return vote, nil
@@ -269,6 +502,173 @@ func (s *service) GetVotesBySubmissionID(ctx context.Context, submissionID uint)
 	return s.repo.GetVotesBySubmissionID(ctx, submissionID)
 }
 
+func (s *service) GetVotesBySubmissionIDs(ctx context.Context, submissionIDs []uint) (map[uint][]*ChallengeVote, error) {
+	votes, err := s.repo.GetVotesBySubmissionIDs(ctx, submissionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	bySubmission := make(map[uint][]*ChallengeVote, len(submissionIDs))
+	for _, vote := range votes {
+		bySubmission[vote.SubmissionID] = append(bySubmission[vote.SubmissionID], vote)
+	}
+	return bySubmission, nil
+}
+
+// === REVOCATION ===
+
+// RevokeSubmission - reverte uma aprovação fraudulenta usando uma saga:
+//  1. marca a submission como revogada
+//  2. remove o XP concedido
+//  3. publica ChallengeRevoked para que badges e leaderboard revoguem suas entradas
+//  4. notifica o usuário
+//
+// Se algum passo falhar, os anteriores são compensados em ordem reversa. Os
+// eventos saem via OnCommit, então dentro de uma transação do chamador (ex.:
+// override da moderação) só são publicados após o commit.
+func (s *service) RevokeSubmission(ctx context.Context, adminID uint, input RevokeSubmissionInput) (*ChallengeSubmission, error) {
+	if err := validation.Struct(input); err != nil {
+		return nil, err
+	}
+	submissionID, err := strconv.ParseUint(input.SubmissionID, 10, 32)
+	if err != nil {
+		return nil, errors.InvalidInput("invalid submission ID")
+	}
+	reason := strings.TrimSpace(input.Reason)
+
+	s.logger.Info("revoking submission",
+		zap.Uint("submission_id", uint(submissionID)),
+		zap.Uint("admin_id", adminID))
+
+	submission, err := s.repo.GetSubmissionByID(ctx, uint(submissionID))
+	if err != nil {
+		return nil, err
+	}
+	if !submission.IsApproved() {
+		return nil, errors.InvalidInput("only approved submissions can be revoked")
+	}
+
+	challenge, err := s.repo.GetChallengeByID(ctx, submission.ChallengeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if submission.ChallengeID > math.MaxInt32 {
+		return nil, fmt.Errorf("challenge ID too large for safe conversion")
+	}
+	challengeIDStr := strconv.Itoa(int(submission.ChallengeID)) // #nosec G115 - validated above
+
+	previousStatus := submission.Status
+	previousReason := submission.DecisionReason
+	var removedXP int
+
+	revocation := saga.NewSagaBuilder(fmt.Sprintf("revoke-submission-%d", submission.ID), s.logger).
+		Step("mark_revoked", "Marca a submission como revogada").
+		Execute(func(ctx context.Context) error {
+			now := time.Now()
+			revoked := *submission
+			revoked.Status = SubmissionStatusRevoked
+			revoked.DecisionReason = reason
+			revoked.RevokedBy = &adminID
+			revoked.RevokedAt = &now
+			// Update condicional: se outra revogação chegou antes, nada é removido
+			if err := s.repo.MarkSubmissionRevoked(ctx, &revoked); err != nil {
+				return err
+			}
+			*submission = revoked
+			return nil
+		}).
+		Compensate(func(ctx context.Context) error {
+			submission.Status = previousStatus
+			submission.DecisionReason = previousReason
+			submission.RevokedBy = nil
+			submission.RevokedAt = nil
+			return s.repo.UpdateSubmission(ctx, submission)
+		}).
+		Add().
+		Step("remove_xp", "Remove o XP concedido pela aprovação").
+		Execute(func(ctx context.Context) error {
+			var err error
+			removedXP, err = s.userService.RemoveUserXP(ctx, submission.UserID, "challenge", challengeIDStr, challenge.XPReward)
+			return err
+		}).
+		Compensate(func(ctx context.Context) error {
+			// Devolve exatamente o que foi removido: GiveUserXP reaplicaria as
+			// regras (multiplicadores, limite diário) sobre o XPReward
+			if removedXP == 0 {
+				return nil
+			}
+			return s.userService.RestoreUserXP(ctx, submission.UserID, "challenge", challengeIDStr, removedXP)
+		}).
+		Add().
+		Step("revoke_rewards", "Publica ChallengeRevoked para revogar badges e entradas de leaderboard").
+		Execute(func(ctx context.Context) error {
+			s.txManager.OnCommit(ctx, func(context.Context) {
+				s.eventBus.Publish(eventbus.Event{
+					Type:   "ChallengeRevoked",
+					Source: "challenges",
+					Data: map[string]interface{}{
+						"submissionID": submission.ID,
+						"challengeID":  submission.ChallengeID,
+						"userID":       submission.UserID,
+						"xpRevoked":    removedXP,
+						"revokedBy":    adminID,
+						"reason":       reason,
+					},
+				})
+			})
+			return nil
+		}).
+		Compensate(func(ctx context.Context) error {
+			s.txManager.OnCommit(ctx, func(context.Context) {
+				s.eventBus.Publish(eventbus.Event{
+					Type:   "ChallengeRevocationReverted",
+					Source: "challenges",
+					Data: map[string]interface{}{
+						"submissionID": submission.ID,
+						"challengeID":  submission.ChallengeID,
+						"userID":       submission.UserID,
+					},
+				})
+			})
+			return nil
+		}).
+		Add().
+		Step("notify_user", "Notifica o usuário sobre a revogação").
+		Execute(func(ctx context.Context) error {
+			s.txManager.OnCommit(ctx, func(context.Context) {
+				s.eventBus.Publish(eventbus.Event{
+					Type:   "NotificationRequested",
+					Source: "challenges",
+					Data: map[string]interface{}{
+						"userID":       submission.UserID,
+						"kind":         "submission_revoked",
+						"submissionID": submission.ID,
+						"challengeID":  submission.ChallengeID,
+						"title":        challenge.Title,
+						"reason":       reason,
+					},
+				})
+			})
+			return nil
+		}).
+		Add().
+		Build()
+
+	if err := s.sagaManager.ExecuteSaga(ctx, revocation); err != nil {
+		s.logger.Error("failed to revoke submission",
+			zap.Uint("submission_id", submission.ID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("submission revoked successfully",
+		zap.Uint("submission_id", submission.ID),
+		zap.Uint("user_id", submission.UserID),
+		zap.Int("xp_revoked", removedXP))
+	return submission, nil
+}
+
 // === PRIVATE HELPERS ===
 
 func (s *service) processVotingResult(ctx context.Context, submission *ChallengeSubmission) {
@@ -321,27 +721,86 @@ func (s *service) processVotingResult(ctx context.Context, submission *Challenge
 	}
 }
 
-// Refatorar approveSubmission para usar transações
+// approveSubmission - aprovação pela votação da comunidade
 func (s *service) approveSubmission(ctx context.Context, submission *ChallengeSubmission) {
 	s.logger.Info("approving submission with transaction", zap.Uint("submission_id", submission.ID))
 
-	// Usar transação para garantir atomicidade
-	err := s.txManager.WithTransaction(ctx, func(tx *gorm.DB) error {
-		// 1. Buscar challenge
-		challenge, err := s.repo.GetChallengeByIDWithTx(ctx, tx, submission.ChallengeID)
+	_, err := s.ApproveSubmission(ctx, submission.ID, DecisionReasonCommunityApproved)
+
+	if err != nil {
+		s.logger.Error("failed to approve submission", zap.Error(err))
+		return
+	}
+
+	s.logger.Info("submission approved successfully",
+		zap.Uint("submission_id", submission.ID),
+		zap.Uint("user_id", submission.UserID))
+}
+
+// rejectSubmission - rejeição pela votação da comunidade
+func (s *service) rejectSubmission(ctx context.Context, submission *ChallengeSubmission) {
+	s.logger.Info("rejecting submission with transaction", zap.Uint("submission_id", submission.ID))
+
+	_, err := s.RejectSubmission(ctx, submission.ID, DecisionReasonCommunityRejected)
+
+	if err != nil {
+		s.logger.Error("failed to reject submission", zap.Error(err))
+		return
+	}
+
+	s.logger.Info("submission rejected successfully", zap.Uint("submission_id", submission.ID))
+}
+
+// ApproveSubmission - aprova uma submission pendente ou rejeitada e concede o
+// XP do challenge na mesma transação
+func (s *service) ApproveSubmission(ctx context.Context, submissionID uint, reason string) (*ChallengeSubmission, error) {
+	var submission *ChallengeSubmission
+	err := s.txManager.InTransaction(ctx, func(ctx context.Context) error {
+		// 1. Recarregar submission dentro da transação (evita decidir sobre estado obsoleto)
+		var err error
+		submission, err = s.repo.GetSubmissionByID(ctx, submissionID)
+		if err != nil {
+			return err
+		}
+		if submission.IsApproved() {
+			return errors.InvalidInput("submission is already approved")
+		}
+		if submission.IsRevoked() {
+			return errors.InvalidInput("submission was revoked")
+		}
+
+		// 2. Outra tentativa aprovada ou pendente já responde pelo XP do
+		// challenge (ex.: apelação de uma tentativa antiga). As tentativas
+		// ficam travadas até o commit, então aprovações concorrentes de
+		// tentativas diferentes do mesmo usuário passam uma de cada vez
+		attempts, err := s.repo.LockUserSubmissions(ctx, submission.UserID, submission.ChallengeID)
+		if err != nil {
+			return err
+		}
+		for _, attempt := range attempts {
+			if attempt.ID != submission.ID && (attempt.IsApproved() || attempt.IsPending()) {
+				return errors.InvalidInput("another attempt for this challenge is approved or pending")
+			}
+		}
+
+		// 3. Buscar challenge
+		challenge, err := s.repo.GetChallengeByID(ctx, submission.ChallengeID)
 		if err != nil {
 			s.logger.Error("failed to get challenge for approval", zap.Error(err))
 			return err
 		}
 
-		// 2. Atualizar status da submission
-		submission.Status = SubmissionStatusApproved
-		if err := s.repo.UpdateSubmissionWithTx(ctx, tx, submission); err != nil {
+		// 4. Atualizar status da submission (condicional: só pending/rejected)
+		approved := *submission
+		approved.Status = SubmissionStatusApproved
+		approved.DecisionReason = reason
+		if err := s.repo.MarkSubmissionApproved(ctx, &approved); err != nil {
 			s.logger.Error("failed to update submission status", zap.Error(err))
 			return err
 		}
+		*submission = approved
 
-		// 3. Conceder XP ao usuário (dentro da mesma transação)
+		// 5. Conceder XP ao usuário (dentro da mesma transação)
 		// Validar se ChallengeID pode ser convertido com segurança
 		if submission.ChallengeID > math.MaxInt32 {
 			s.logger.Error("challenge ID too large for safe conversion", zap.Uint("challengeID", submission.ChallengeID))
@@ -349,75 +808,74 @@ func (s *service) approveSubmission(ctx context.Context, submission *ChallengeSu
 		}
 
 		challengeIDStr := strconv.Itoa(int(submission.ChallengeID)) // #nosec G115 - validated above
-		if err := s.userService.GiveUserXPWithTx(ctx, tx, submission.UserID, "challenge",
+		if err := s.userService.GiveUserXP(ctx, submission.UserID, "challenge",
 			challengeIDStr, challenge.XPReward); err != nil {
 			s.logger.Error("failed to give XP to user", zap.Error(err))
 			return err
 		}
 
-		// 4. Publicar evento transacional
-		if err := s.eventBus.PublishWithTx(ctx, tx, eventbus.Event{
-			Type:   "ChallengeApproved",
-			Source: "challenges",
-			Data: map[string]interface{}{
-				"submissionID": submission.ID,
-				"challengeID":  submission.ChallengeID,
-				"userID":       submission.UserID,
-				"xpAwarded":    challenge.XPReward,
-			},
-		}); err != nil {
-			s.logger.Error("failed to publish approval event", zap.Error(err))
-			return err
-		}
-
+		// 6. Publicar evento só depois do commit
+		s.txManager.OnCommit(ctx, func(context.Context) {
+			s.eventBus.Publish(eventbus.Event{
+				Type:   "ChallengeApproved",
+				Source: "challenges",
+				Data: map[string]interface{}{
+					"submissionID": approved.ID,
+					"challengeID":  approved.ChallengeID,
+					"userID":       approved.UserID,
+					"xpAwarded":    challenge.XPReward,
+					"reason":       reason,
+				},
+			})
+		})
 		return nil
 	})
-
 	if err != nil {
-		s.logger.Error("failed to approve submission", zap.Error(err))
-		return
+		return nil, err
 	}
-
-	s.logger.Info("submission approved successfully",
-		zap.Uint("submission_id", submission.ID),
-		zap.Uint("user_id", submission.UserID))
+	return submission, nil
 }
 
-// Refatorar rejectSubmission para usar transações
-func (s *service) rejectSubmission(ctx context.Context, submission *ChallengeSubmission) {
-	s.logger.Info("rejecting submission with transaction", zap.Uint("submission_id", submission.ID))
-
-	// Usar transação para garantir atomicidade
-	err := s.txManager.WithTransaction(ctx, func(tx *gorm.DB) error {
-		// 1. Atualizar status da submission
-		submission.Status = SubmissionStatusRejected
-		if err := s.repo.UpdateSubmissionWithTx(ctx, tx, submission); err != nil {
-			s.logger.Error("failed to update submission status", zap.Error(err))
+// RejectSubmission - rejeita uma submission pendente
+func (s *service) RejectSubmission(ctx context.Context, submissionID uint, reason string) (*ChallengeSubmission, error) {
+	var submission *ChallengeSubmission
+	err := s.txManager.InTransaction(ctx, func(ctx context.Context) error {
+		// 1. Recarregar submission dentro da transação
+		var err error
+		submission, err = s.repo.GetSubmissionByID(ctx, submissionID)
+		if err != nil {
 			return err
 		}
+		if !submission.IsPending() {
+			return errors.InvalidInput("submission is not pending")
+		}
 
-		// 2. Publicar evento transacional
-		if err := s.eventBus.PublishWithTx(ctx, tx, eventbus.Event{
-			Type:   "ChallengeRejected",
-			Source: "challenges",
-			Data: map[string]interface{}{
-				"submissionID": submission.ID,
-				"challengeID":  submission.ChallengeID,
-				"userID":       submission.UserID,
-				"reason":       "Rejected by community vote",
-			},
-		}); err != nil {
-			s.logger.Error("failed to publish rejection event", zap.Error(err))
+		// 2. Atualizar status da submission
+		submission.Status = SubmissionStatusRejected
+		submission.DecisionReason = reason
+		if err := s.repo.UpdateSubmission(ctx, submission); err != nil {
+			s.logger.Error("failed to update submission status", zap.Error(err))
 			return err
 		}
 
+		// 3. Publicar evento só depois do commit
+		rejected := *submission
+		s.txManager.OnCommit(ctx, func(context.Context) {
+			s.eventBus.Publish(eventbus.Event{
+				Type:   "ChallengeRejected",
+				Source: "challenges",
+				Data: map[string]interface{}{
+					"submissionID": rejected.ID,
+					"challengeID":  rejected.ChallengeID,
+					"userID":       rejected.UserID,
+					"reason":       reason,
+				},
+			})
+		})
 		return nil
 	})
-
 	if err != nil {
-		s.logger.Error("failed to reject submission", zap.Error(err))
-		return
+		return nil, err
 	}
-
-	s.logger.Info("submission rejected successfully", zap.Uint("submission_id", submission.ID))
+	return submission, nil
 }
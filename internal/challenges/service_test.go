@@ -2,16 +2,21 @@ package challenges_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/rafaelcoelhox/labbend/internal/challenges"
 	"github.com/rafaelcoelhox/labbend/internal/mocks"
 	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/database/databasetest"
+	apperrors "github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
 	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
 	"github.com/rafaelcoelhox/labbend/pkg/saga"
 	"github.com/stretchr/testify/assert"
-	"gorm.io/gorm"
 )
 
 func TestChallengeService_WithGomock(t *testing.T) {
@@ -24,10 +29,8 @@ func TestChallengeService_WithGomock(t *testing.T) {
 	mockLogger := mocks.NewMockLogger(ctrl)
 	mockEventBus := mocks.NewMockChallengesEventBus(ctrl)
 
-	// Criar implementações reais para TxManager e SagaManager (para testes)
-	// Como são structs, vamos usar implementações reais ou nil em testes
-	var db *gorm.DB // nil para testes unitários
-	txManager := database.NewTxManager(db)
+	// CreateChallenge abre uma transação: TxManager sobre o SQLite de teste
+	txManager := database.NewTxManager(databasetest.New(t))
 	testLogger, _ := logger.New()
 	sagaManager := saga.NewSagaManager(testLogger)
 
@@ -37,7 +40,7 @@ func TestChallengeService_WithGomock(t *testing.T) {
 	assert.NotNil(t, mockLogger)
 	assert.NotNil(t, mockEventBus)
 
-	service := challenges.NewService(mockRepo, mockUserService, mockLogger, mockEventBus, txManager, sagaManager)
+	service := challenges.NewService(mockRepo, mockUserService, mockLogger, mockEventBus, txManager, sagaManager, challenges.DefaultSubmissionPolicy(), nil, nil)
 
 	input := challenges.CreateChallengeInput{
 		Title:       "Test Challenge",
@@ -84,3 +87,465 @@ func TestChallengeService_WithGomock(t *testing.T) {
 
 	t.Log("✅ Mocks gerados pelo gomock funcionam corretamente para challenges")
 }
+
+func TestChallengeService_SearchChallenges_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockChallengesRepository(ctrl)
+	mockUserService := mocks.NewMockChallengesUserService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockChallengesEventBus(ctrl)
+
+	testLogger, _ := logger.New()
+	service := challenges.NewService(mockRepo, mockUserService, mockLogger, mockEventBus,
+		database.NewTxManager(nil), saga.NewSagaManager(testLogger), challenges.DefaultSubmissionPolicy(), nil, nil)
+
+	minXP := 50
+	mockRepo.EXPECT().
+		SearchChallenges(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input challenges.SearchChallengesInput, page pagination.Page) ([]*challenges.Challenge, error) {
+			// Defaults aplicados pelo service
+			assert.Equal(t, pagination.MaxLimit, page.Limit)
+			assert.Equal(t, "golang", input.Search)
+			assert.Equal(t, challenges.ChallengeStatusActive, input.Filter.Status)
+			assert.Equal(t, []string{"go", "backend"}, input.Filter.Tags)
+			assert.Equal(t, challenges.SortFieldRelevance, input.Sort.Field)
+			assert.Equal(t, challenges.SortDirectionDesc, input.Sort.Direction)
+			return []*challenges.Challenge{{ID: 1, Title: "Aprender Go"}}, nil
+		}).
+		Times(1)
+
+	first := 500
+	result, err := service.SearchChallenges(context.Background(), challenges.SearchChallengesInput{
+		Search: "  golang ",
+		Filter: challenges.ChallengeFilter{
+			Tags:  []string{"Go", " backend", "go"},
+			MinXP: &minXP,
+		},
+	}, pagination.Args{First: &first})
+	assert.NoError(t, err)
+	assert.Len(t, result.Edges, 1)
+	assert.False(t, result.PageInfo.HasNextPage)
+
+	// Parâmetros inválidos não chegam ao repositório
+	_, err = service.SearchChallenges(context.Background(), challenges.SearchChallengesInput{
+		Sort: challenges.ChallengeSort{Field: "id; DROP TABLE challenges"},
+	}, pagination.Args{})
+	assert.Error(t, err)
+
+	_, err = service.SearchChallenges(context.Background(), challenges.SearchChallengesInput{}, pagination.Args{After: "não-é-um-cursor"})
+	assert.Error(t, err)
+
+	maxXP := 10
+	_, err = service.SearchChallenges(context.Background(), challenges.SearchChallengesInput{
+		Filter: challenges.ChallengeFilter{MinXP: &minXP, MaxXP: &maxXP},
+	}, pagination.Args{})
+	assert.Error(t, err)
+}
+
+func TestChallengeService_RevokeSubmission_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockChallengesRepository(ctrl)
+	mockUserService := mocks.NewMockChallengesUserService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockChallengesEventBus(ctrl)
+
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	service := challenges.NewService(mockRepo, mockUserService, mockLogger, mockEventBus,
+		database.NewTxManager(nil), saga.NewSagaManager(mockLogger), challenges.DefaultSubmissionPolicy(), nil, nil)
+
+	submission := &challenges.ChallengeSubmission{ID: 3, ChallengeID: 2, UserID: 9, Status: challenges.SubmissionStatusApproved}
+
+	mockRepo.EXPECT().GetSubmissionByID(gomock.Any(), uint(3)).Return(submission, nil)
+	mockRepo.EXPECT().GetChallengeByID(gomock.Any(), uint(2)).Return(&challenges.Challenge{ID: 2, XPReward: 150}, nil)
+	mockRepo.EXPECT().MarkSubmissionRevoked(gomock.Any(), gomock.Any()).Return(nil)
+	mockUserService.EXPECT().RemoveUserXP(gomock.Any(), uint(9), "challenge", "2", 150).Return(150, nil)
+
+	var published []string
+	mockEventBus.EXPECT().
+		Publish(gomock.Any()).
+		Do(func(event eventbus.Event) { published = append(published, event.Type) }).
+		Times(2)
+
+	result, err := service.RevokeSubmission(context.Background(), 1, challenges.RevokeSubmissionInput{
+		SubmissionID: "3",
+		Reason:       "Prova reutilizada de outro usuário",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, challenges.SubmissionStatusRevoked, result.Status)
+	assert.NotNil(t, result.RevokedAt)
+	assert.Equal(t, []string{"ChallengeRevoked", "NotificationRequested"}, published)
+}
+
+func TestChallengeService_RevokeSubmission_Compensation_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockChallengesRepository(ctrl)
+	mockUserService := mocks.NewMockChallengesUserService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockChallengesEventBus(ctrl)
+
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	service := challenges.NewService(mockRepo, mockUserService, mockLogger, mockEventBus,
+		database.NewTxManager(nil), saga.NewSagaManager(mockLogger), challenges.DefaultSubmissionPolicy(), nil, nil)
+
+	submission := &challenges.ChallengeSubmission{
+		ID:             3,
+		ChallengeID:    2,
+		UserID:         9,
+		Status:         challenges.SubmissionStatusApproved,
+		DecisionReason: challenges.DecisionReasonCommunityApproved,
+	}
+
+	mockRepo.EXPECT().GetSubmissionByID(gomock.Any(), uint(3)).Return(submission, nil)
+	mockRepo.EXPECT().GetChallengeByID(gomock.Any(), uint(2)).Return(&challenges.Challenge{ID: 2, XPReward: 150}, nil)
+
+	var statuses []string
+	mockRepo.EXPECT().
+		MarkSubmissionRevoked(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, s *challenges.ChallengeSubmission) { statuses = append(statuses, s.Status) }).
+		Return(nil)
+	mockRepo.EXPECT().
+		UpdateSubmission(gomock.Any(), submission).
+		Do(func(ctx context.Context, s *challenges.ChallengeSubmission) { statuses = append(statuses, s.Status) }).
+		Return(nil)
+
+	// Falha ao remover XP dispara a compensação do primeiro passo
+	mockUserService.EXPECT().
+		RemoveUserXP(gomock.Any(), uint(9), "challenge", "2", 150).
+		Return(0, errors.New("users service unavailable"))
+
+	_, err := service.RevokeSubmission(context.Background(), 1, challenges.RevokeSubmissionInput{
+		SubmissionID: "3",
+		Reason:       "Fraude",
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{challenges.SubmissionStatusRevoked, challenges.SubmissionStatusApproved}, statuses)
+	assert.Equal(t, challenges.DecisionReasonCommunityApproved, submission.DecisionReason)
+	assert.Nil(t, submission.RevokedAt)
+}
+
+// failingCreateRepository - repositório real cuja criação do challenge falha
+type failingCreateRepository struct {
+	challenges.Repository
+}
+
+func (failingCreateRepository) CreateChallenge(context.Context, *challenges.Challenge) error {
+	return errors.New("insert failed")
+}
+
+func TestChallengeService_CreateChallenge_Tags(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+	repo := challenges.NewRepository(db)
+	testLogger, _ := logger.New()
+	ctrl := gomock.NewController(t)
+	bus := mocks.NewMockChallengesEventBus(ctrl)
+	bus.EXPECT().Publish(gomock.Any()).AnyTimes()
+	newService := func(repo challenges.Repository) challenges.Service {
+		return challenges.NewService(repo, nil, testLogger, bus, database.NewTxManager(db),
+			saga.NewSagaManager(testLogger), challenges.DefaultSubmissionPolicy(), nil, nil)
+	}
+	input := challenges.CreateChallengeInput{Title: "Deploy", Description: "Suba a API", XPReward: 100, Tags: []string{"Go", "backend"}}
+
+	t.Run("should reuse existing tags in input order", func(t *testing.T) {
+		existing, err := repo.FindOrCreateTags(ctx, []string{"backend"})
+		assert.NoError(t, err)
+
+		challenge, err := newService(repo).CreateChallenge(ctx, input)
+		assert.NoError(t, err)
+		assert.Len(t, challenge.Tags, 2)
+		assert.Equal(t, "go", challenge.Tags[0].Name)
+		assert.Equal(t, existing[0].ID, challenge.Tags[1].ID)
+
+		again, err := repo.FindOrCreateTags(ctx, []string{"backend", "go"})
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{challenge.Tags[1].ID, challenge.Tags[0].ID}, []uint{again[0].ID, again[1].ID})
+	})
+
+	t.Run("should not keep new tags when the challenge insert fails", func(t *testing.T) {
+		failing := input
+		failing.Tags = []string{"orphan"}
+
+		_, err := newService(failingCreateRepository{repo}).CreateChallenge(ctx, failing)
+		assert.Error(t, err)
+
+		var count int64
+		assert.NoError(t, db.Model(&challenges.Tag{}).Where("name = ?", "orphan").Count(&count).Error)
+		assert.Zero(t, count)
+	})
+}
+
+func TestChallengeRepository_CreateSubmission_DuplicateAttempt(t *testing.T) {
+	ctx := context.Background()
+	repo := challenges.NewRepository(databasetest.New(t))
+
+	challenge := &challenges.Challenge{Title: "Deploy", XPReward: 150}
+	assert.NoError(t, repo.CreateChallenge(ctx, challenge))
+
+	// Dois envios concorrentes calcularam a mesma tentativa: o segundo esbarra no índice
+	first := &challenges.ChallengeSubmission{ChallengeID: challenge.ID, UserID: 9, ProofURL: "https://github.com/a/b", AttemptNumber: 1}
+	assert.NoError(t, repo.CreateSubmission(ctx, first))
+
+	second := &challenges.ChallengeSubmission{ChallengeID: challenge.ID, UserID: 9, ProofURL: "https://github.com/a/c", AttemptNumber: 1}
+	err := repo.CreateSubmission(ctx, second)
+	assert.True(t, apperrors.Is(err, apperrors.ErrAlreadyExists))
+
+	// Outra tentativa do mesmo usuário continua permitida
+	second.ID = 0
+	second.AttemptNumber = 2
+	assert.NoError(t, repo.CreateSubmission(ctx, second))
+}
+
+func TestChallengeService_RevokeSubmission_ConcurrentRevoke(t *testing.T) {
+	ctx := context.Background()
+	repo := challenges.NewRepository(databasetest.New(t))
+
+	challenge := &challenges.Challenge{Title: "Deploy", XPReward: 150}
+	assert.NoError(t, repo.CreateChallenge(ctx, challenge))
+	submission := &challenges.ChallengeSubmission{ChallengeID: challenge.ID, UserID: 9, ProofURL: "https://github.com/a/b", Status: challenges.SubmissionStatusApproved}
+	assert.NoError(t, repo.CreateSubmission(ctx, submission))
+
+	t.Run("conditional update should let only one revoke through", func(t *testing.T) {
+		// As duas revogações leram a submission ainda aprovada
+		revoked := *submission
+		revoked.Status = challenges.SubmissionStatusRevoked
+		assert.NoError(t, repo.MarkSubmissionRevoked(ctx, &revoked))
+
+		err := repo.MarkSubmissionRevoked(ctx, &revoked)
+		assert.True(t, apperrors.Is(err, apperrors.ErrInvalidInput))
+
+		found, err := repo.GetSubmissionByID(ctx, submission.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, challenges.SubmissionStatusRevoked, found.Status)
+	})
+
+	t.Run("losing revoke should not remove XP", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockChallengesRepository(ctrl)
+		mockUserService := mocks.NewMockChallengesUserService(ctrl)
+		mockEventBus := mocks.NewMockChallengesEventBus(ctrl)
+		testLogger, _ := logger.New()
+
+		service := challenges.NewService(mockRepo, mockUserService, testLogger, mockEventBus,
+			database.NewTxManager(nil), saga.NewSagaManager(testLogger), challenges.DefaultSubmissionPolicy(), nil, nil)
+
+		mockRepo.EXPECT().GetSubmissionByID(gomock.Any(), uint(3)).
+			Return(&challenges.ChallengeSubmission{ID: 3, ChallengeID: 2, UserID: 9, Status: challenges.SubmissionStatusApproved}, nil)
+		mockRepo.EXPECT().GetChallengeByID(gomock.Any(), uint(2)).Return(&challenges.Challenge{ID: 2, XPReward: 150}, nil)
+		mockRepo.EXPECT().MarkSubmissionRevoked(gomock.Any(), gomock.Any()).
+			Return(apperrors.InvalidInput("submission is no longer approved"))
+
+		_, err := service.RevokeSubmission(ctx, 1, challenges.RevokeSubmissionInput{SubmissionID: "3", Reason: "Fraude"})
+		assert.True(t, apperrors.Is(err, apperrors.ErrInvalidInput))
+	})
+}
+
+func TestChallengeService_SubmitChallenge_Resubmission_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockChallengesRepository(ctrl)
+	mockUserService := mocks.NewMockChallengesUserService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockChallengesEventBus(ctrl)
+
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	policy := challenges.SubmissionPolicy{MaxAttempts: 2, Cooldown: time.Hour}
+	service := challenges.NewService(mockRepo, mockUserService, mockLogger, mockEventBus,
+		database.NewTxManager(nil), saga.NewSagaManager(mockLogger), policy, nil, nil)
+
+	challenge := &challenges.Challenge{ID: 5, XPReward: 100, Status: challenges.ChallengeStatusActive}
+	input := challenges.SubmitChallengeInput{ChallengeID: "5", ProofURL: "https://example.com/proof"}
+	rejected := &challenges.ChallengeSubmission{
+		ID:            1,
+		ChallengeID:   5,
+		UserID:        7,
+		AttemptNumber: 1,
+		Status:        challenges.SubmissionStatusRejected,
+		UpdatedAt:     time.Now().Add(-2 * time.Hour),
+	}
+
+	mockRepo.EXPECT().GetChallengeByID(gomock.Any(), uint(5)).Return(challenge, nil).AnyTimes()
+
+	// Segunda tentativa após o cooldown é aceita
+	mockRepo.EXPECT().
+		GetUserSubmissions(gomock.Any(), uint(7), uint(5)).
+		Return([]*challenges.ChallengeSubmission{rejected}, nil)
+	mockRepo.EXPECT().CreateSubmission(gomock.Any(), gomock.Any()).Return(nil)
+	mockEventBus.EXPECT().Publish(gomock.Any())
+
+	submission, err := service.SubmitChallenge(context.Background(), 7, input)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, submission.AttemptNumber)
+
+	// Ainda dentro do cooldown
+	recent := *rejected
+	recent.UpdatedAt = time.Now()
+	mockRepo.EXPECT().
+		GetUserSubmissions(gomock.Any(), uint(7), uint(5)).
+		Return([]*challenges.ChallengeSubmission{&recent}, nil)
+
+	_, err = service.SubmitChallenge(context.Background(), 7, input)
+	assert.Error(t, err)
+
+	// Limite de tentativas atingido
+	second := *rejected
+	second.AttemptNumber = 2
+	mockRepo.EXPECT().
+		GetUserSubmissions(gomock.Any(), uint(7), uint(5)).
+		Return([]*challenges.ChallengeSubmission{rejected, &second}, nil)
+
+	_, err = service.SubmitChallenge(context.Background(), 7, input)
+	assert.Error(t, err)
+
+	// Tentativa pendente bloqueia nova submissão
+	pending := *rejected
+	pending.Status = challenges.SubmissionStatusPending
+	mockRepo.EXPECT().
+		GetUserSubmissions(gomock.Any(), uint(7), uint(5)).
+		Return([]*challenges.ChallengeSubmission{&pending}, nil)
+
+	_, err = service.SubmitChallenge(context.Background(), 7, input)
+	assert.Error(t, err)
+}
+
+func TestChallengeService_SubmitChallenge_ValidationFields_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Nenhuma expectativa: entradas inválidas não chegam ao repositório
+	mockRepo := mocks.NewMockChallengesRepository(ctrl)
+	mockUserService := mocks.NewMockChallengesUserService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockChallengesEventBus(ctrl)
+
+	testLogger, _ := logger.New()
+	service := challenges.NewService(mockRepo, mockUserService, mockLogger, mockEventBus,
+		database.NewTxManager(nil), saga.NewSagaManager(testLogger), challenges.DefaultSubmissionPolicy(), nil, nil)
+
+	_, err := service.SubmitChallenge(context.Background(), 7, challenges.SubmitChallengeInput{
+		ChallengeID: "abc",
+		ProofURL:    "ftp://example.com/proof",
+		Evidence:    []challenges.EvidenceInput{{Type: "video"}},
+	})
+
+	assert.True(t, apperrors.Is(err, apperrors.ErrInvalidInput))
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "challengeID", Message: "must be numeric"},
+		{Field: "proofURL", Message: "must use one of the schemes: https, http"},
+		{Field: "evidence[0].type", Message: "must be one of: url, text, file"},
+	}, apperrors.Fields(err))
+}
+
+func TestChallengeService_ApproveSubmission_PublishesAfterCommit_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockChallengesRepository(ctrl)
+	mockUserService := mocks.NewMockChallengesUserService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockChallengesEventBus(ctrl)
+
+	txManager := database.NewTxManager(databasetest.New(t))
+	service := challenges.NewService(mockRepo, mockUserService, mockLogger, mockEventBus,
+		txManager, saga.NewSagaManager(mockLogger), challenges.DefaultSubmissionPolicy(), nil, nil)
+
+	mockRepo.EXPECT().GetSubmissionByID(gomock.Any(), uint(3)).DoAndReturn(
+		func(context.Context, uint) (*challenges.ChallengeSubmission, error) {
+			return &challenges.ChallengeSubmission{ID: 3, ChallengeID: 2, UserID: 9, Status: challenges.SubmissionStatusPending}, nil
+		}).Times(2)
+	mockRepo.EXPECT().LockUserSubmissions(gomock.Any(), uint(9), uint(2)).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().GetChallengeByID(gomock.Any(), uint(2)).Return(&challenges.Challenge{ID: 2, XPReward: 150}, nil).Times(2)
+	mockRepo.EXPECT().MarkSubmissionApproved(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockUserService.EXPECT().GiveUserXP(gomock.Any(), uint(9), "challenge", "2", 150).Return(nil).Times(2)
+
+	var published []string
+	mockEventBus.EXPECT().
+		Publish(gomock.Any()).
+		Do(func(event eventbus.Event) { published = append(published, event.Type) }).
+		AnyTimes()
+
+	t.Run("should publish ChallengeApproved after commit", func(t *testing.T) {
+		err := txManager.InTransaction(context.Background(), func(ctx context.Context) error {
+			_, err := service.ApproveSubmission(ctx, 3, "Prova conferida pela moderação")
+			assert.Empty(t, published, "event must wait for the commit")
+			return err
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ChallengeApproved"}, published)
+	})
+
+	t.Run("should not publish on rollback", func(t *testing.T) {
+		published = nil
+		err := txManager.InTransaction(context.Background(), func(ctx context.Context) error {
+			if _, err := service.ApproveSubmission(ctx, 3, "Prova conferida pela moderação"); err != nil {
+				return err
+			}
+			return errors.New("later step failed")
+		})
+		assert.Error(t, err)
+		assert.Empty(t, published)
+	})
+}
+
+func TestChallengeService_ApproveSubmission_OtherAttempts(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.New(t)
+	repo := challenges.NewRepository(db)
+
+	challenge := &challenges.Challenge{Title: "Deploy", XPReward: 150}
+	assert.NoError(t, repo.CreateChallenge(ctx, challenge))
+	rejected := &challenges.ChallengeSubmission{ChallengeID: challenge.ID, UserID: 9, ProofURL: "https://github.com/a/b", AttemptNumber: 1, Status: challenges.SubmissionStatusRejected}
+	assert.NoError(t, repo.CreateSubmission(ctx, rejected))
+
+	t.Run("conditional update should let only one approval through", func(t *testing.T) {
+		// As duas aprovações leram a submission ainda rejeitada
+		approved := *rejected
+		approved.Status = challenges.SubmissionStatusApproved
+		assert.NoError(t, repo.MarkSubmissionApproved(ctx, &approved))
+
+		err := repo.MarkSubmissionApproved(ctx, &approved)
+		assert.True(t, apperrors.Is(err, apperrors.ErrInvalidInput))
+
+		// Volta ao estado rejeitado para o próximo caso
+		assert.NoError(t, db.Model(&challenges.ChallengeSubmission{}).Where("id = ?", rejected.ID).
+			Update("status", challenges.SubmissionStatusRejected).Error)
+	})
+
+	t.Run("should refuse an old attempt when a newer one was approved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// GiveUserXP não é esperado: o XP do challenge já veio da tentativa nova
+		mockUserService := mocks.NewMockChallengesUserService(ctrl)
+		mockEventBus := mocks.NewMockChallengesEventBus(ctrl)
+		testLogger, _ := logger.New()
+
+		service := challenges.NewService(repo, mockUserService, testLogger, mockEventBus,
+			database.NewTxManager(db), saga.NewSagaManager(testLogger), challenges.DefaultSubmissionPolicy(), nil, nil)
+
+		newer := &challenges.ChallengeSubmission{ChallengeID: challenge.ID, UserID: 9, ProofURL: "https://github.com/a/c", AttemptNumber: 2, Status: challenges.SubmissionStatusApproved}
+		assert.NoError(t, repo.CreateSubmission(ctx, newer))
+
+		_, err := service.ApproveSubmission(ctx, rejected.ID, "Apelação aceita")
+		assert.True(t, apperrors.Is(err, apperrors.ErrInvalidInput))
+
+		found, err := repo.GetSubmissionByID(ctx, rejected.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, challenges.SubmissionStatusRejected, found.Status)
+	})
+}
@@ -1,71 +1,98 @@
 package schemas_configuration
 
 import (
-	"maps"
+	"fmt"
+	"sort"
 
 	"github.com/graphql-go/graphql"
-)
 
-// ConfigureSchema configura o schema GraphQL principal da aplicação
-// Agora recebe um registry ao invés de parâmetros individuais
-func ConfigureSchema(registry *ModuleRegistry) (graphql.Schema, error) {
-	// Configura queries de todos os módulos
-	rootQuery := configQueries(registry)
+	"github.com/rafaelcoelhox/labbend/pkg/graphqlws"
+	"github.com/rafaelcoelhox/labbend/pkg/module"
+)
 
-	// Configura mutations de todos os módulos
-	rootMutation := configureMutations(registry)
+// ConfigureSchema configura o schema GraphQL principal a partir dos módulos registrados.
+// Campos com o mesmo nome em dois módulos são erro; bus nil desativa as subscriptions.
+func ConfigureSchema(modules []module.Module, bus graphqlws.EventSubscriber) (graphql.Schema, error) {
+	queries, err := mergeFields("query", modules, func(m module.Module) graphql.Fields {
+		return m.Queries()
+	})
+	if err != nil {
+		return graphql.Schema{}, err
+	}
 
-	// Cria o schema GraphQL principal
-	return graphql.NewSchema(graphql.SchemaConfig{
-		Query:    rootQuery,    // Todas as consultas (queries)
-		Mutation: rootMutation, // Todas as modificações (mutations)
+	mutations, err := mergeFields("mutation", modules, func(m module.Module) graphql.Fields {
+		return m.Mutations()
 	})
-}
+	if err != nil {
+		return graphql.Schema{}, err
+	}
 
-// configQueries combina todas as queries dos módulos em um único objeto GraphQL
-func configQueries(registry *ModuleRegistry) *graphql.Object {
-	allQueries := make(graphql.Fields)
+	config := graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: queries,
+		}),
+		Types: collectTypes(modules),
+	}
 
-	// Itera sobre todos os módulos registrados
-	for _, moduleName := range GetRegisteredModules() {
-		service := registry.Get(moduleName)
-		if service != nil {
-			moduleAdapter := createModuleAdapter(moduleName, service)
-			if moduleAdapter != nil {
-				queries := moduleAdapter.Queries(registry.GetLogger())
-				if queries != nil {
-					maps.Copy(allQueries, *queries)
-				}
-			}
+	if len(mutations) > 0 {
+		config.Mutation = graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Mutation",
+			Fields: mutations,
+		})
+	}
+
+	// Subscriptions apenas quando há event bus
+	if bus != nil {
+		subscriptions, err := mergeFields("subscription", modules, func(m module.Module) graphql.Fields {
+			return m.Subscriptions(bus)
+		})
+		if err != nil {
+			return graphql.Schema{}, err
+		}
+		if len(subscriptions) > 0 {
+			config.Subscription = graphql.NewObject(graphql.ObjectConfig{
+				Name:   "Subscription",
+				Fields: subscriptions,
+			})
 		}
 	}
 
-	return graphql.NewObject(graphql.ObjectConfig{
-		Name:   "Query",
-		Fields: allQueries,
-	})
+	return graphql.NewSchema(config)
 }
 
-// configureMutations combina todas as mutations dos módulos em um único objeto GraphQL
-func configureMutations(registry *ModuleRegistry) *graphql.Object {
-	allMutations := make(graphql.Fields)
+// mergeFields - combina os campos dos módulos, falhando quando dois módulos definem o mesmo nome
+func mergeFields(kind string, modules []module.Module, fieldsOf func(module.Module) graphql.Fields) (graphql.Fields, error) {
+	merged := make(graphql.Fields)
+	owners := make(map[string]string)
+
+	for _, m := range modules {
+		fields := fieldsOf(m)
+
+		// Ordem estável para que o erro de colisão seja determinístico
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
 
-	// Itera sobre todos os módulos registrados
-	for _, moduleName := range GetRegisteredModules() {
-		service := registry.Get(moduleName)
-		if service != nil {
-			moduleAdapter := createModuleAdapter(moduleName, service)
-			if moduleAdapter != nil {
-				mutations := moduleAdapter.Mutations(registry.GetLogger())
-				if mutations != nil {
-					maps.Copy(allMutations, *mutations)
-				}
+		for _, name := range names {
+			if owner, exists := owners[name]; exists {
+				return nil, fmt.Errorf("graphql %s field %q is defined by both module %q and module %q", kind, name, owner, m.Name())
 			}
+			owners[name] = m.Name()
+			merged[name] = fields[name]
 		}
 	}
 
-	return graphql.NewObject(graphql.ObjectConfig{
-		Name:   "Mutation",
-		Fields: allMutations,
-	})
+	return merged, nil
+}
+
+// collectTypes - tipos extras declarados pelos módulos
+func collectTypes(modules []module.Module) []graphql.Type {
+	var types []graphql.Type
+	for _, m := range modules {
+		types = append(types, m.Types()...)
+	}
+	return types
 }
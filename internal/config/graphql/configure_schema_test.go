@@ -0,0 +1,62 @@
+package schemas_configuration_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	schemas_configuration "github.com/rafaelcoelhox/labbend/internal/config/graphql"
+	"github.com/rafaelcoelhox/labbend/pkg/graphqlws"
+	"github.com/rafaelcoelhox/labbend/pkg/module"
+)
+
+// fakeModule - módulo mínimo com campos de query e subscription
+type fakeModule struct {
+	module.Base
+	name          string
+	queries       []string
+	subscriptions []string
+}
+
+func (m *fakeModule) Name() string         { return m.name }
+func (m *fakeModule) Service() interface{} { return nil }
+
+func (m *fakeModule) Queries() graphql.Fields {
+	return fields(m.queries)
+}
+
+func (m *fakeModule) Subscriptions(bus graphqlws.EventSubscriber) graphql.Fields {
+	return fields(m.subscriptions)
+}
+
+func fields(names []string) graphql.Fields {
+	result := make(graphql.Fields, len(names))
+	for _, name := range names {
+		result[name] = &graphql.Field{Type: graphql.String}
+	}
+	return result
+}
+
+func TestConfigureSchema_MergesModules(t *testing.T) {
+	schema, err := schemas_configuration.ConfigureSchema([]module.Module{
+		&fakeModule{name: "a", queries: []string{"foo"}},
+		&fakeModule{name: "b", queries: []string{"bar"}, subscriptions: []string{"barChanged"}},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, schema.QueryType().Fields(), 2)
+	assert.Nil(t, schema.MutationType())
+	// Sem event bus não há subscriptions
+	assert.Nil(t, schema.SubscriptionType())
+}
+
+func TestConfigureSchema_DetectsFieldCollision(t *testing.T) {
+	_, err := schemas_configuration.ConfigureSchema([]module.Module{
+		&fakeModule{name: "users", queries: []string{"user", "users"}},
+		&fakeModule{name: "products", queries: []string{"products", "users"}},
+	}, nil)
+	require.Error(t, err)
+	assert.Equal(t, `graphql query field "users" is defined by both module "users" and module "products"`, err.Error())
+}
@@ -0,0 +1,41 @@
+// Package dailytasks implementa as tarefas diárias da plataforma LabEnd, a
+// fonte de XP daily_task do pacote users.
+//
+// # Funcionalidades
+//
+//   - Templates de tarefas (tabela daily_task_templates)
+//   - Atribuição diária por usuário, sorteada no primeiro acesso do dia no fuso
+//     do Schedule (tabela daily_task_assignments)
+//   - Conclusão manual ou por eventos de outros módulos, com filtro nos dados
+//     do evento e alvo (ex.: 3 votos válidos)
+//   - XP concedido via UserService.GiveUserXP com source daily_task
+//
+// # Dia das Tarefas
+//
+// O dia é a data (2006-01-02) no fuso Schedule.Location. As tarefas do dia são
+// sorteadas de forma determinística a partir do usuário, do dia e da chave do
+// template, então gerações concorrentes chegam ao mesmo conjunto; o índice
+// único (user_id, day, template_id) descarta as duplicadas.
+//
+// # Transações
+//
+// O progresso, a conclusão e o XP acontecem na mesma transação
+// (TxManager.InTransaction): a tarefa só é marcada como concluída uma vez e o
+// XP acompanha a marcação.
+//
+// # Eventos
+//
+// O pacote consome ChallengeSubmitted, ChallengeVoteAdded e ChallengeApproved
+// (TriggerEvents) e publica:
+//   - DailyTaskCompleted: Quando uma tarefa é concluída
+//
+// # Exemplo de Uso
+//
+//	dailyTaskRepo := dailytasks.NewRepository(db)
+//	dailyTaskService := dailytasks.NewService(dailyTaskRepo, userService, logger, eventBus, txManager, dailytasks.DefaultSchedule())
+//
+//	tasks, err := dailyTaskService.TodayTasks(ctx, userID)
+//
+//	// Tarefas manuais
+//	task, err := dailyTaskService.CompleteTask(ctx, userID, tasks[0].ID)
+package dailytasks
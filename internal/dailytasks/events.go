@@ -0,0 +1,23 @@
+package dailytasks
+
+import (
+	"context"
+
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	"github.com/rafaelcoelhox/labbend/pkg/graphqlws"
+)
+
+// progressHandler - avança as tarefas do dia do usuário do evento
+// (Data["userID"]) cujos templates usam o evento como gatilho
+type progressHandler struct {
+	service Service
+}
+
+func (h *progressHandler) HandleEvent(ctx context.Context, event eventbus.Event) error {
+	userID := graphqlws.EventUint(event.Data, "userID")
+	if userID == 0 {
+		return nil
+	}
+	_, err := h.service.RecordEvent(ctx, userID, event.Type, event.Data)
+	return err
+}
@@ -0,0 +1,323 @@
+package dailytasks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	"github.com/rafaelcoelhox/labbend/pkg/complexity"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+// ===== GRAPHQL TYPES =====
+
+var DailyTaskTemplateType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "DailyTaskTemplate",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+		},
+		"key": &graphql.Field{
+			Type: graphql.String,
+		},
+		"title": &graphql.Field{
+			Type: graphql.String,
+		},
+		"description": &graphql.Field{
+			Type: graphql.String,
+		},
+		"xpReward": &graphql.Field{
+			Type: graphql.Int,
+		},
+		"triggerEvent": &graphql.Field{
+			Type:        graphql.String,
+			Description: "Evento que avança a tarefa (nulo = conclusão manual)",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if template, ok := p.Source.(*TaskTemplate); ok && !template.IsManual() {
+					return template.TriggerEvent, nil
+				}
+				return nil, nil
+			},
+		},
+		"triggerMatch": &graphql.Field{
+			Type:        graphql.String,
+			Description: "Campos exigidos no evento, em JSON",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if template, ok := p.Source.(*TaskTemplate); ok && len(template.TriggerMatch) > 0 {
+					return string(template.TriggerMatch), nil
+				}
+				return nil, nil
+			},
+		},
+		"target": &graphql.Field{
+			Type: graphql.Int,
+		},
+		"active": &graphql.Field{
+			Type: graphql.Boolean,
+		},
+		"createdAt": &graphql.Field{
+			Type: graphql.String,
+		},
+	},
+})
+
+var DailyTaskType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "DailyTask",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+		},
+		"userID": &graphql.Field{
+			Type: graphql.String,
+		},
+		"day": &graphql.Field{
+			Type: graphql.String,
+		},
+		"template": &graphql.Field{
+			Type: DailyTaskTemplateType,
+		},
+		"progress": &graphql.Field{
+			Type: graphql.Int,
+		},
+		"target": &graphql.Field{
+			Type: graphql.Int,
+		},
+		"xpReward": &graphql.Field{
+			Type: graphql.Int,
+		},
+		"completed": &graphql.Field{
+			Type: graphql.Boolean,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if assignment, ok := p.Source.(*DailyAssignment); ok {
+					return assignment.IsCompleted(), nil
+				}
+				return false, nil
+			},
+		},
+		"completedAt": &graphql.Field{
+			Type: graphql.String,
+		},
+	},
+})
+
+// ===== RESOLVER FUNCTIONS =====
+
+func todayTasksResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		id := p.Args["userID"].(string)
+		userID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+		// As tarefas de um usuário só são vistas por ele mesmo (ou admins)
+		if !auth.ViewerFromContext(p.Context).CanManage(uint(userID)) {
+			return nil, errors.Unauthorized("cannot read another user's daily tasks")
+		}
+
+		logger.Info("Buscando tarefas do dia")
+		return service.TodayTasks(p.Context, uint(userID))
+	}
+}
+
+func dailyTaskTemplatesResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		activeOnly, _ := p.Args["activeOnly"].(bool)
+
+		logger.Info("Listando templates de tarefas diárias")
+		return service.ListTemplates(p.Context, activeOnly)
+	}
+}
+
+func completeDailyTaskResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		id := p.Args["taskID"].(string)
+		taskID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+
+		viewer := auth.ViewerFromContext(p.Context)
+		if viewer.IsAnonymous() {
+			return nil, errors.Unauthorized("authentication required")
+		}
+
+		logger.Info("Concluindo tarefa diária")
+		return service.CompleteTask(p.Context, viewer.UserID, uint(taskID))
+	}
+}
+
+func createDailyTaskTemplateResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if !auth.ViewerFromContext(p.Context).Admin {
+			return nil, errors.Unauthorized("admin role required")
+		}
+
+		inputMap := p.Args["input"].(map[string]interface{})
+		input := CreateTemplateInput{
+			Key:      inputMap["key"].(string),
+			Title:    inputMap["title"].(string),
+			XPReward: inputMap["xpReward"].(int),
+		}
+		if description, ok := inputMap["description"].(string); ok {
+			input.Description = description
+		}
+		if triggerEvent, ok := inputMap["triggerEvent"].(string); ok {
+			input.TriggerEvent = triggerEvent
+		}
+		if target, ok := inputMap["target"].(int); ok {
+			input.Target = target
+		}
+		if matches, ok := inputMap["triggerMatch"].([]interface{}); ok {
+			input.TriggerMatch = make(map[string]interface{}, len(matches))
+			for _, m := range matches {
+				match := m.(map[string]interface{})
+				input.TriggerMatch[match["field"].(string)] = parseMatchValue(match["value"].(string))
+			}
+		}
+
+		logger.Info("Criando template de tarefa diária")
+		return service.CreateTemplate(p.Context, input)
+	}
+}
+
+func setDailyTaskTemplateActiveResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if !auth.ViewerFromContext(p.Context).Admin {
+			return nil, errors.Unauthorized("admin role required")
+		}
+
+		id := p.Args["id"].(string)
+		templateID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+
+		logger.Info("Alterando template de tarefa diária")
+		return service.SetTemplateActive(p.Context, uint(templateID), p.Args["active"].(bool))
+	}
+}
+
+// parseMatchValue - "true", "false" e números viram os tipos correspondentes
+func parseMatchValue(value string) interface{} {
+	if b, err := strconv.ParseBool(value); err == nil {
+		return b
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		return n
+	}
+	return value
+}
+
+// ===== SCHEMA CONFIGURATION =====
+
+var TriggerMatchInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "DailyTaskTriggerMatchInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"field": &graphql.InputObjectFieldConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"value": &graphql.InputObjectFieldConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+	},
+})
+
+var CreateDailyTaskTemplateInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "CreateDailyTaskTemplateInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"key": &graphql.InputObjectFieldConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"title": &graphql.InputObjectFieldConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"description": &graphql.InputObjectFieldConfig{
+			Type: graphql.String,
+		},
+		"xpReward": &graphql.InputObjectFieldConfig{
+			Type: graphql.NewNonNull(graphql.Int),
+		},
+		"triggerEvent": &graphql.InputObjectFieldConfig{
+			Type:        graphql.String,
+			Description: "ChallengeSubmitted, ChallengeVoteAdded ou ChallengeApproved (vazio = manual)",
+		},
+		"triggerMatch": &graphql.InputObjectFieldConfig{
+			Type: graphql.NewList(graphql.NewNonNull(TriggerMatchInputType)),
+		},
+		"target": &graphql.InputObjectFieldConfig{
+			Type: graphql.Int,
+		},
+	},
+})
+
+// FieldCosts - custos para a análise de complexidade (campos com consulta própria)
+var FieldCosts = complexity.Costs{
+	"Query.todayTasks": 2, // gera as tarefas no primeiro acesso do dia
+}
+
+func Queries(dailyTaskService Service, logger logger.Logger) *graphql.Fields {
+	return &graphql.Fields{
+		"todayTasks": &graphql.Field{
+			Type:        graphql.NewList(DailyTaskType),
+			Description: "Retorna as tarefas do dia do usuário (sorteadas no primeiro acesso)",
+			Args: graphql.FieldConfigArgument{
+				"userID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: todayTasksResolver(dailyTaskService, logger),
+		},
+		"dailyTaskTemplates": &graphql.Field{
+			Type:        graphql.NewList(DailyTaskTemplateType),
+			Description: "Lista os templates de tarefas diárias",
+			Args: graphql.FieldConfigArgument{
+				"activeOnly": &graphql.ArgumentConfig{
+					Type:         graphql.Boolean,
+					DefaultValue: true,
+				},
+			},
+			Resolve: dailyTaskTemplatesResolver(dailyTaskService, logger),
+		},
+	}
+}
+
+func Mutations(dailyTaskService Service, logger logger.Logger) *graphql.Fields {
+	return &graphql.Fields{
+		"completeDailyTask": &graphql.Field{
+			Type:        DailyTaskType,
+			Description: "Conclui uma tarefa manual de hoje e concede o XP",
+			Args: graphql.FieldConfigArgument{
+				"taskID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: completeDailyTaskResolver(dailyTaskService, logger),
+		},
+		"createDailyTaskTemplate": &graphql.Field{
+			Type:        DailyTaskTemplateType,
+			Description: "Cria um template de tarefa diária",
+			Args: graphql.FieldConfigArgument{
+				"input": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(CreateDailyTaskTemplateInputType),
+				},
+			},
+			Resolve: createDailyTaskTemplateResolver(dailyTaskService, logger),
+		},
+		"setDailyTaskTemplateActive": &graphql.Field{
+			Type:        DailyTaskTemplateType,
+			Description: "Ativa ou desativa o sorteio de um template",
+			Args: graphql.FieldConfigArgument{
+				"id": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+				"active": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.Boolean),
+				},
+			},
+			Resolve: setDailyTaskTemplateActiveResolver(dailyTaskService, logger),
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package dailytasks
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+)
+
+func TestGraphQL_RequiresViewer(t *testing.T) {
+	svc, userService, _ := newTestService(t, 1)
+	createTemplates(t, svc)
+	queries := *Queries(svc, svc.logger)
+	mutations := *Mutations(svc, svc.logger)
+
+	owner := auth.WithViewer(context.Background(), auth.Viewer{UserID: 7})
+	other := auth.WithViewer(context.Background(), auth.Viewer{UserID: 8})
+	admin := auth.WithViewer(context.Background(), auth.Viewer{UserID: 1, Admin: true})
+
+	resolve := func(field *graphql.Field, ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return field.Resolve(graphql.ResolveParams{Context: ctx, Args: args})
+	}
+
+	t.Run("todayTasks should be limited to the owner and admins", func(t *testing.T) {
+		args := map[string]interface{}{"userID": "7"}
+
+		_, err := resolve(queries["todayTasks"], context.Background(), args)
+		assert.ErrorIs(t, err, errors.ErrUnauthorized)
+		_, err = resolve(queries["todayTasks"], other, args)
+		assert.ErrorIs(t, err, errors.ErrUnauthorized)
+
+		_, err = resolve(queries["todayTasks"], owner, args)
+		assert.NoError(t, err)
+		_, err = resolve(queries["todayTasks"], admin, args)
+		assert.NoError(t, err)
+	})
+
+	t.Run("completeDailyTask should complete the viewer's task", func(t *testing.T) {
+		tasks, err := svc.TodayTasks(context.Background(), 7)
+		require.NoError(t, err)
+		args := map[string]interface{}{"taskID": tasks[0].SourceID()}
+
+		_, err = resolve(mutations["completeDailyTask"], context.Background(), args)
+		assert.ErrorIs(t, err, errors.ErrUnauthorized)
+
+		// A tarefa é de outro usuário: o viewer não a enxerga
+		_, err = resolve(mutations["completeDailyTask"], other, args)
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+		assert.Empty(t, userService.grants)
+	})
+
+	t.Run("template mutations should require admin", func(t *testing.T) {
+		createArgs := map[string]interface{}{"input": map[string]interface{}{
+			"key": "weekly_review", "title": "Revisão", "xpReward": 20,
+		}}
+		_, err := resolve(mutations["createDailyTaskTemplate"], owner, createArgs)
+		assert.ErrorIs(t, err, errors.ErrUnauthorized)
+
+		created, err := resolve(mutations["createDailyTaskTemplate"], admin, createArgs)
+		require.NoError(t, err)
+		template := created.(*TaskTemplate)
+
+		activeArgs := map[string]interface{}{"id": strconv.FormatUint(uint64(template.ID), 10), "active": false}
+		_, err = resolve(mutations["setDailyTaskTemplateActive"], owner, activeArgs)
+		assert.ErrorIs(t, err, errors.ErrUnauthorized)
+
+		_, err = resolve(mutations["setDailyTaskTemplateActive"], admin, activeArgs)
+		assert.NoError(t, err)
+	})
+}
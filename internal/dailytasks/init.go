@@ -0,0 +1,14 @@
+package dailytasks
+
+import "github.com/rafaelcoelhox/labbend/pkg/module"
+
+// init - registra automaticamente o módulo dailytasks
+func init() {
+	module.Register(module.Definition{
+		Name:       ModuleName,
+		DependsOn:  []string{"users"},
+		New:        NewModule,
+		Migrations: migrations,
+		Models:     models,
+	})
+}
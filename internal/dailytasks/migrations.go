@@ -0,0 +1,8 @@
+package dailytasks
+
+import "embed"
+
+// migrations - migrações SQL versionadas do módulo (aplicadas por cmd/migrate)
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "daily_task_assignments";
+DROP TABLE IF EXISTS "daily_task_templates";
@@ -0,0 +1,43 @@
+-- Tarefas diárias: templates e atribuições por usuário e dia
+CREATE TABLE IF NOT EXISTS "daily_task_templates" (
+    "id" bigserial,
+    "key" text NOT NULL,
+    "title" text NOT NULL,
+    "description" text,
+    "xp_reward" bigint NOT NULL,
+    "trigger_event" text,
+    "trigger_match" jsonb,
+    "target" bigint NOT NULL DEFAULT 1,
+    "active" boolean NOT NULL DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_daily_task_templates_key" ON "daily_task_templates" ("key");
+CREATE INDEX IF NOT EXISTS "idx_daily_task_templates_trigger_event" ON "daily_task_templates" ("trigger_event");
+CREATE INDEX IF NOT EXISTS "idx_daily_task_templates_active" ON "daily_task_templates" ("active");
+
+CREATE TABLE IF NOT EXISTS "daily_task_assignments" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "day" varchar(10) NOT NULL,
+    "template_id" bigint NOT NULL,
+    "progress" bigint NOT NULL DEFAULT 0,
+    "target" bigint NOT NULL,
+    "xp_reward" bigint NOT NULL,
+    "completed_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_daily_task_assignments_template" FOREIGN KEY ("template_id") REFERENCES "daily_task_templates"("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_daily_task_assignments_user_day_template"
+    ON "daily_task_assignments" ("user_id", "day", "template_id");
+
+-- Templates iniciais
+INSERT INTO "daily_task_templates" ("key", "title", "description", "xp_reward", "trigger_event", "trigger_match", "target", "created_at", "updated_at") VALUES
+    ('daily_check_in', 'Check-in diário', 'Confirme sua presença na plataforma', 10, NULL, NULL, 1, now(), now()),
+    ('cast_valid_votes', 'Vote em 3 submissions', 'Dê 3 votos válidos em submissions da comunidade', 30, 'ChallengeVoteAdded', '{"isValid": true}', 3, now(), now()),
+    ('submit_challenge', 'Envie uma submission', 'Envie a prova de um challenge', 50, 'ChallengeSubmitted', NULL, 1, now(), now()),
+    ('challenge_approved', 'Tenha uma submission aprovada', 'Uma submission sua aprovada pela comunidade', 100, 'ChallengeApproved', NULL, 1, now(), now())
+ON CONFLICT ("key") DO NOTHING;
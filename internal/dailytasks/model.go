@@ -0,0 +1,110 @@
+package dailytasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+)
+
+// TaskTemplate - modelo de tarefa diária sorteado para os usuários
+type TaskTemplate struct {
+	ID          uint   `json:"id" gorm:"primarykey"`
+	Key         string `json:"key" gorm:"not null;uniqueIndex"`
+	Title       string `json:"title" gorm:"not null"`
+	Description string `json:"description" gorm:"type:text"`
+	XPReward    int    `json:"xp_reward" gorm:"not null"`
+	// TriggerEvent - evento que avança a tarefa (vazio = conclusão manual)
+	TriggerEvent string `json:"trigger_event" gorm:"index"`
+	// TriggerMatch - campos exigidos no Data do evento (ex.: {"isValid": true})
+	TriggerMatch database.JSON `json:"trigger_match"`
+	// Target - ocorrências do evento necessárias para concluir
+	Target    int       `json:"target" gorm:"not null;default:1"`
+	Active    bool      `json:"active" gorm:"not null;default:true;index"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DailyAssignment - tarefa atribuída a um usuário em um dia; XPReward e
+// Target são copiados do template na geração
+type DailyAssignment struct {
+	ID     uint `json:"id" gorm:"primarykey"`
+	UserID uint `json:"user_id" gorm:"not null;uniqueIndex:idx_daily_task_assignments_user_day_template,priority:1"`
+	// Day - data no fuso do módulo (2006-01-02)
+	Day         string        `json:"day" gorm:"size:10;not null;uniqueIndex:idx_daily_task_assignments_user_day_template,priority:2"`
+	TemplateID  uint          `json:"template_id" gorm:"not null;uniqueIndex:idx_daily_task_assignments_user_day_template,priority:3"`
+	Template    *TaskTemplate `json:"template,omitempty" gorm:"foreignKey:TemplateID"`
+	Progress    int           `json:"progress" gorm:"not null;default:0"`
+	Target      int           `json:"target" gorm:"not null"`
+	XPReward    int           `json:"xp_reward" gorm:"not null"`
+	CompletedAt *time.Time    `json:"completed_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// TriggerEvents - eventos aceitos como gatilho de tarefas
+var TriggerEvents = []string{"ChallengeSubmitted", "ChallengeVoteAdded", "ChallengeApproved"}
+
+type CreateTemplateInput struct {
+	Key          string                 `json:"key" validate:"required,notblank,max=64"`
+	Title        string                 `json:"title" validate:"required,notblank,max=120"`
+	Description  string                 `json:"description"`
+	XPReward     int                    `json:"xpReward" validate:"min=1"`
+	TriggerEvent string                 `json:"triggerEvent"`
+	TriggerMatch map[string]interface{} `json:"triggerMatch"`
+	Target       int                    `json:"target" validate:"min=0"`
+}
+
+func (TaskTemplate) TableName() string {
+	return "daily_task_templates"
+}
+
+func (DailyAssignment) TableName() string {
+	return "daily_task_assignments"
+}
+
+func (t *TaskTemplate) IsManual() bool {
+	return t.TriggerEvent == ""
+}
+
+// Matches - o evento avança tarefas deste template
+func (t *TaskTemplate) Matches(eventType string, data map[string]interface{}) bool {
+	if t.IsManual() || t.TriggerEvent != eventType {
+		return false
+	}
+	if len(t.TriggerMatch) == 0 {
+		return true
+	}
+
+	var match map[string]interface{}
+	if err := json.Unmarshal(t.TriggerMatch, &match); err != nil {
+		return false
+	}
+	for key, expected := range match {
+		// Comparação textual: números do JSON chegam como float64 e os do evento como int/uint
+		if fmt.Sprint(data[key]) != fmt.Sprint(expected) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *DailyAssignment) IsCompleted() bool {
+	return a.CompletedAt != nil
+}
+
+// SourceID - identificador do XP concedido (source daily_task)
+func (a *DailyAssignment) SourceID() string {
+	return strconv.FormatUint(uint64(a.ID), 10)
+}
+
+func isValidTriggerEvent(eventType string) bool {
+	for _, trigger := range TriggerEvents {
+		if trigger == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package dailytasks
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/rafaelcoelhox/labbend/pkg/complexity"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/module"
+)
+
+// ModuleName - nome do módulo no registry (usado em DependsOn)
+const ModuleName = "dailytasks"
+
+// Settings - configuração lida de Deps.Config; sem ela vale DefaultSchedule
+type Settings interface {
+	DailyTasksSchedule() (Schedule, error)
+}
+
+// Module - registro do módulo dailytasks na aplicação
+type Module struct {
+	module.Base
+	service Service
+	logger  logger.Logger
+}
+
+// NewModule - factory registrada no init.go; depende do módulo users
+func NewModule(deps *module.Deps) (module.Module, error) {
+	userService, ok := deps.Service("users").(UserService)
+	if !ok {
+		return nil, fmt.Errorf("users service does not implement dailytasks.UserService")
+	}
+
+	schedule := DefaultSchedule()
+	if settings, ok := deps.Config.(Settings); ok {
+		var err error
+		if schedule, err = settings.DailyTasksSchedule(); err != nil {
+			return nil, err
+		}
+	}
+
+	repo := NewRepository(deps.DB)
+	return &Module{
+		service: NewService(repo, userService, deps.Logger, deps.EventBus, deps.TxManager, schedule),
+		logger:  deps.Logger,
+	}, nil
+}
+
+func (m *Module) Name() string         { return ModuleName }
+func (m *Module) Service() interface{} { return m.service }
+
+func (m *Module) Queries() graphql.Fields {
+	return *Queries(m.service, m.logger)
+}
+
+func (m *Module) Mutations() graphql.Fields {
+	return *Mutations(m.service, m.logger)
+}
+
+// Costs - custos dos campos do módulo para a análise de complexidade
+func (m *Module) Costs() complexity.Costs {
+	return FieldCosts
+}
+
+// EventSubscriptions - progresso das tarefas disparadas por eventos
+func (m *Module) EventSubscriptions() []module.EventSubscription {
+	handler := &progressHandler{service: m.service}
+	subscriptions := make([]module.EventSubscription, 0, len(TriggerEvents))
+	for _, eventType := range TriggerEvents {
+		subscriptions = append(subscriptions, module.EventSubscription{EventType: eventType, Handler: handler})
+	}
+	return subscriptions
+}
+
+// models - modelos GORM do módulo; registrados em database.RegisterModel
+// para o AutoMigrate do SQLite (desenvolvimento local e testes)
+var models = []interface{}{&TaskTemplate{}, &DailyAssignment{}}
+
+func (m *Module) Models() []interface{} {
+	return models
+}
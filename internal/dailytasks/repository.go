@@ -0,0 +1,176 @@
+package dailytasks
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+)
+
+type Repository interface {
+	CreateTemplate(ctx context.Context, template *TaskTemplate) error
+	GetTemplateByID(ctx context.Context, id uint) (*TaskTemplate, error)
+	ListTemplates(ctx context.Context, activeOnly bool) ([]*TaskTemplate, error)
+	SetTemplateActive(ctx context.Context, id uint, active bool) error
+
+	// CreateAssignments - ignora as atribuições que já existem (gerações concorrentes)
+	CreateAssignments(ctx context.Context, assignments []*DailyAssignment) error
+	ListAssignments(ctx context.Context, userID uint, day string) ([]*DailyAssignment, error)
+	GetAssignmentByID(ctx context.Context, id uint) (*DailyAssignment, error)
+	// AddProgress - soma delta ao progresso de uma tarefa não concluída, até o Target
+	AddProgress(ctx context.Context, id uint, delta int) error
+	// CompleteAssignment - marca a tarefa como concluída se o progresso atingiu
+	// o Target; false se ela já estava concluída ou incompleta
+	CompleteAssignment(ctx context.Context, id uint, now time.Time) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// === TEMPLATE OPERATIONS ===
+
+func (r *repository) CreateTemplate(ctx context.Context, template *TaskTemplate) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := database.FromContext(ctx, r.db).Create(template).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.AlreadyExists("daily task template", "key", template.Key)
+		}
+		return errors.Internal(err)
+	}
+	return nil
+}
+
+func (r *repository) GetTemplateByID(ctx context.Context, id uint) (*TaskTemplate, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var template TaskTemplate
+	err := database.FromContext(ctx, r.db).First(&template, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NotFound("daily task template", id)
+		}
+		return nil, errors.Internal(err)
+	}
+	return &template, nil
+}
+
+func (r *repository) ListTemplates(ctx context.Context, activeOnly bool) ([]*TaskTemplate, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := database.FromContext(ctx, r.db)
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+
+	var templates []*TaskTemplate
+	if err := query.Order("id").Find(&templates).Error; err != nil {
+		return nil, errors.Internal(err)
+	}
+	return templates, nil
+}
+
+func (r *repository) SetTemplateActive(ctx context.Context, id uint, active bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := database.FromContext(ctx, r.db).Model(&TaskTemplate{}).Where("id = ?", id).Update("active", active)
+	if result.Error != nil {
+		return errors.Internal(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NotFound("daily task template", id)
+	}
+	return nil
+}
+
+// === ASSIGNMENT OPERATIONS ===
+
+func (r *repository) CreateAssignments(ctx context.Context, assignments []*DailyAssignment) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if len(assignments) == 0 {
+		return nil
+	}
+	err := database.FromContext(ctx, r.db).
+		Omit("Template").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&assignments).Error
+	if err != nil {
+		return errors.Internal(err)
+	}
+	return nil
+}
+
+func (r *repository) ListAssignments(ctx context.Context, userID uint, day string) ([]*DailyAssignment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var assignments []*DailyAssignment
+	err := database.FromContext(ctx, r.db).
+		Preload("Template").
+		Where("user_id = ? AND day = ?", userID, day).
+		Order("id").
+		Find(&assignments).Error
+	if err != nil {
+		return nil, errors.Internal(err)
+	}
+	return assignments, nil
+}
+
+func (r *repository) GetAssignmentByID(ctx context.Context, id uint) (*DailyAssignment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var assignment DailyAssignment
+	err := database.FromContext(ctx, r.db).Preload("Template").First(&assignment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NotFound("daily task", id)
+		}
+		return nil, errors.Internal(err)
+	}
+	return &assignment, nil
+}
+
+func (r *repository) AddProgress(ctx context.Context, id uint, delta int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Incremento no banco: eventos simultâneos do mesmo usuário não se perdem
+	err := database.FromContext(ctx, r.db).
+		Model(&DailyAssignment{}).
+		Where("id = ? AND completed_at IS NULL", id).
+		Update("progress", gorm.Expr("CASE WHEN progress + ? > target THEN target ELSE progress + ? END", delta, delta)).Error
+	if err != nil {
+		return errors.Internal(err)
+	}
+	return nil
+}
+
+func (r *repository) CompleteAssignment(ctx context.Context, id uint, now time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := database.FromContext(ctx, r.db).
+		Model(&DailyAssignment{}).
+		Where("id = ? AND completed_at IS NULL AND progress >= target", id).
+		Update("completed_at", now)
+	if result.Error != nil {
+		return false, errors.Internal(result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
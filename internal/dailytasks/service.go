@@ -0,0 +1,296 @@
+package dailytasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/rafaelcoelhox/labbend/internal/users"
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/validation"
+)
+
+// EventBus - interface para comunicação entre módulos
+type EventBus interface {
+	Publish(event eventbus.Event)
+}
+
+// UserService - interface para comunicação com módulo de users
+type UserService interface {
+	GiveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error
+}
+
+// Schedule - quando começa o dia das tarefas e quantas são sorteadas por usuário
+type Schedule struct {
+	// Location - fuso em que o dia vira (nil = UTC)
+	Location    *time.Location
+	TasksPerDay int
+}
+
+// DefaultSchedule - 3 tarefas por dia, virando à meia-noite UTC
+func DefaultSchedule() Schedule {
+	return Schedule{Location: time.UTC, TasksPerDay: 3}
+}
+
+// Day - dia das tarefas (2006-01-02) que contém t
+func (s Schedule) Day(t time.Time) string {
+	location := s.Location
+	if location == nil {
+		location = time.UTC
+	}
+	return t.In(location).Format(time.DateOnly)
+}
+
+// Service - interface de negócio
+type Service interface {
+	// Templates
+	CreateTemplate(ctx context.Context, input CreateTemplateInput) (*TaskTemplate, error)
+	ListTemplates(ctx context.Context, activeOnly bool) ([]*TaskTemplate, error)
+	SetTemplateActive(ctx context.Context, id uint, active bool) (*TaskTemplate, error)
+
+	// Tarefas do dia
+	TodayTasks(ctx context.Context, userID uint) ([]*DailyAssignment, error)
+	CompleteTask(ctx context.Context, userID, assignmentID uint) (*DailyAssignment, error)
+	// RecordEvent - avança as tarefas de hoje disparadas pelo evento; retorna as concluídas
+	RecordEvent(ctx context.Context, userID uint, eventType string, data map[string]interface{}) ([]*DailyAssignment, error)
+}
+
+type service struct {
+	repo        Repository
+	userService UserService
+	logger      logger.Logger
+	eventBus    EventBus
+	txManager   *database.TxManager
+	schedule    Schedule
+	now         func() time.Time
+}
+
+func NewService(repo Repository, userService UserService, logger logger.Logger, eventBus EventBus, txManager *database.TxManager, schedule Schedule) Service {
+	return &service{
+		repo:        repo,
+		userService: userService,
+		logger:      logger,
+		eventBus:    eventBus,
+		txManager:   txManager,
+		schedule:    schedule,
+		now:         time.Now,
+	}
+}
+
+// === TEMPLATES ===
+
+func (s *service) CreateTemplate(ctx context.Context, input CreateTemplateInput) (*TaskTemplate, error) {
+	if err := validation.Struct(input); err != nil {
+		return nil, err
+	}
+	if input.TriggerEvent != "" && !isValidTriggerEvent(input.TriggerEvent) {
+		return nil, errors.InvalidInput(fmt.Sprintf("unsupported trigger event %q (expected one of %s)",
+			input.TriggerEvent, strings.Join(TriggerEvents, ", ")))
+	}
+
+	template := &TaskTemplate{
+		Key:          strings.TrimSpace(input.Key),
+		Title:        strings.TrimSpace(input.Title),
+		Description:  input.Description,
+		XPReward:     input.XPReward,
+		TriggerEvent: input.TriggerEvent,
+		Target:       max(input.Target, 1),
+		Active:       true,
+	}
+	// Tarefas manuais são concluídas de uma vez
+	if template.IsManual() {
+		template.Target = 1
+	} else if len(input.TriggerMatch) > 0 {
+		match, err := json.Marshal(input.TriggerMatch)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("invalid trigger match: %v", err))
+		}
+		template.TriggerMatch = match
+	}
+
+	if err := s.repo.CreateTemplate(ctx, template); err != nil {
+		s.logger.Error("failed to create daily task template", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("daily task template created", zap.Uint("template_id", template.ID), zap.String("key", template.Key))
+	return template, nil
+}
+
+func (s *service) ListTemplates(ctx context.Context, activeOnly bool) ([]*TaskTemplate, error) {
+	return s.repo.ListTemplates(ctx, activeOnly)
+}
+
+// SetTemplateActive - templates inativos deixam de ser sorteados; as tarefas
+// já atribuídas continuam valendo até o fim do dia
+func (s *service) SetTemplateActive(ctx context.Context, id uint, active bool) (*TaskTemplate, error) {
+	if err := s.repo.SetTemplateActive(ctx, id, active); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTemplateByID(ctx, id)
+}
+
+// === TAREFAS DO DIA ===
+
+func (s *service) TodayTasks(ctx context.Context, userID uint) ([]*DailyAssignment, error) {
+	return s.ensureAssignments(ctx, userID, s.schedule.Day(s.now()))
+}
+
+// ensureAssignments - tarefas do usuário no dia, sorteadas no primeiro acesso
+// (consulta ou evento) do dia
+func (s *service) ensureAssignments(ctx context.Context, userID uint, day string) ([]*DailyAssignment, error) {
+	assignments, err := s.repo.ListAssignments(ctx, userID, day)
+	if err != nil || len(assignments) > 0 {
+		return assignments, err
+	}
+
+	templates, err := s.repo.ListTemplates(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	picked := pickTemplates(templates, userID, day, s.schedule.TasksPerDay)
+	if len(picked) == 0 {
+		return nil, nil
+	}
+
+	assignments = make([]*DailyAssignment, 0, len(picked))
+	for _, template := range picked {
+		assignments = append(assignments, &DailyAssignment{
+			UserID:     userID,
+			Day:        day,
+			TemplateID: template.ID,
+			Target:     template.Target,
+			XPReward:   template.XPReward,
+		})
+	}
+	if err := s.repo.CreateAssignments(ctx, assignments); err != nil {
+		s.logger.Error("failed to assign daily tasks", zap.Error(err), zap.Uint("user_id", userID))
+		return nil, err
+	}
+
+	s.logger.Info("daily tasks assigned", zap.Uint("user_id", userID), zap.String("day", day), zap.Int("tasks", len(assignments)))
+	// Relê: uma geração concorrente pode ter gravado primeiro
+	return s.repo.ListAssignments(ctx, userID, day)
+}
+
+// pickTemplates - sorteio determinístico por usuário e dia: gerações
+// concorrentes escolhem os mesmos templates
+func pickTemplates(templates []*TaskTemplate, userID uint, day string, n int) []*TaskTemplate {
+	ranks := make(map[uint]uint64, len(templates))
+	for _, template := range templates {
+		hash := fnv.New64a()
+		fmt.Fprintf(hash, "%d:%s:%s", userID, day, template.Key)
+		ranks[template.ID] = hash.Sum64()
+	}
+
+	picked := append([]*TaskTemplate(nil), templates...)
+	sort.Slice(picked, func(i, j int) bool {
+		return ranks[picked[i].ID] < ranks[picked[j].ID]
+	})
+	if n >= 0 && len(picked) > n {
+		picked = picked[:n]
+	}
+	return picked
+}
+
+func (s *service) CompleteTask(ctx context.Context, userID, assignmentID uint) (*DailyAssignment, error) {
+	assignment, err := s.repo.GetAssignmentByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.UserID != userID {
+		return nil, errors.NotFound("daily task", assignmentID)
+	}
+	if assignment.Day != s.schedule.Day(s.now()) {
+		return nil, errors.InvalidInput("only today's tasks can be completed")
+	}
+	if assignment.IsCompleted() {
+		return nil, errors.InvalidInput("task is already completed")
+	}
+	if assignment.Template != nil && !assignment.Template.IsManual() {
+		return nil, errors.InvalidInput("task is completed automatically by " + assignment.Template.TriggerEvent)
+	}
+
+	if err := s.advance(ctx, assignment, assignment.Target); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAssignmentByID(ctx, assignmentID)
+}
+
+func (s *service) RecordEvent(ctx context.Context, userID uint, eventType string, data map[string]interface{}) ([]*DailyAssignment, error) {
+	day := s.schedule.Day(s.now())
+	assignments, err := s.ensureAssignments(ctx, userID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	var completed []*DailyAssignment
+	for _, assignment := range assignments {
+		if assignment.IsCompleted() || assignment.Template == nil || !assignment.Template.Matches(eventType, data) {
+			continue
+		}
+		if err := s.advance(ctx, assignment, 1); err != nil {
+			return completed, err
+		}
+
+		updated, err := s.repo.GetAssignmentByID(ctx, assignment.ID)
+		if err != nil {
+			return completed, err
+		}
+		if updated.IsCompleted() {
+			completed = append(completed, updated)
+		}
+	}
+	return completed, nil
+}
+
+// advance - soma delta ao progresso e, se a tarefa foi concluída agora,
+// concede o XP (source daily_task) na mesma transação
+func (s *service) advance(ctx context.Context, assignment *DailyAssignment, delta int) error {
+	return s.txManager.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.AddProgress(ctx, assignment.ID, delta); err != nil {
+			return err
+		}
+
+		completed, err := s.repo.CompleteAssignment(ctx, assignment.ID, s.now())
+		if err != nil || !completed {
+			return err
+		}
+
+		if err := s.userService.GiveUserXP(ctx, assignment.UserID, users.XPSourceDailyTask,
+			assignment.SourceID(), assignment.XPReward); err != nil {
+			s.logger.Error("failed to give daily task XP", zap.Error(err), zap.Uint("assignment_id", assignment.ID))
+			return err
+		}
+
+		templateKey := ""
+		if assignment.Template != nil {
+			templateKey = assignment.Template.Key
+		}
+		s.txManager.OnCommit(ctx, func(context.Context) {
+			s.eventBus.Publish(eventbus.Event{
+				Type:   "DailyTaskCompleted",
+				Source: "dailytasks",
+				Data: map[string]interface{}{
+					"assignmentID": assignment.ID,
+					"userID":       assignment.UserID,
+					"templateKey":  templateKey,
+					"day":          assignment.Day,
+					"xpReward":     assignment.XPReward,
+				},
+			})
+		})
+
+		s.logger.Info("daily task completed", zap.Uint("assignment_id", assignment.ID), zap.Uint("user_id", assignment.UserID))
+		return nil
+	})
+}
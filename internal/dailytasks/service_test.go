@@ -0,0 +1,196 @@
+package dailytasks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/internal/users"
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/database/databasetest"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	applogger "github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+type xpGrant struct {
+	userID     uint
+	sourceType string
+	sourceID   string
+	amount     int
+}
+
+type recordingUserService struct {
+	grants []xpGrant
+}
+
+func (s *recordingUserService) GiveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error {
+	s.grants = append(s.grants, xpGrant{userID, sourceType, sourceID, amount})
+	return nil
+}
+
+type recordingBus struct {
+	events []eventbus.Event
+}
+
+func (b *recordingBus) Publish(event eventbus.Event) {
+	b.events = append(b.events, event)
+}
+
+func newTestService(t *testing.T, perDay int) (*service, *recordingUserService, *recordingBus) {
+	t.Helper()
+
+	db := databasetest.New(t)
+	log, err := applogger.New()
+	require.NoError(t, err)
+
+	userService := &recordingUserService{}
+	bus := &recordingBus{}
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	svc := NewService(NewRepository(db), userService, log, bus, database.NewTxManager(db),
+		Schedule{Location: saoPaulo, TasksPerDay: perDay}).(*service)
+	// 2026-10-18 23:30 em São Paulo (2026-10-19 02:30 UTC)
+	svc.now = func() time.Time { return time.Date(2026, 10, 19, 2, 30, 0, 0, time.UTC) }
+	return svc, userService, bus
+}
+
+func createTemplates(t *testing.T, svc Service) {
+	t.Helper()
+
+	inputs := []CreateTemplateInput{
+		{Key: "daily_check_in", Title: "Check-in diário", XPReward: 10},
+		{Key: "cast_valid_votes", Title: "Vote em 3 submissions", XPReward: 30, TriggerEvent: "ChallengeVoteAdded",
+			TriggerMatch: map[string]interface{}{"isValid": true}, Target: 3},
+	}
+	for _, input := range inputs {
+		_, err := svc.CreateTemplate(context.Background(), input)
+		require.NoError(t, err)
+	}
+}
+
+func taskByKey(t *testing.T, tasks []*DailyAssignment, key string) *DailyAssignment {
+	t.Helper()
+	for _, task := range tasks {
+		if task.Template.Key == key {
+			return task
+		}
+	}
+	t.Fatalf("task %s not assigned", key)
+	return nil
+}
+
+func TestService_CreateTemplate(t *testing.T) {
+	svc, _, _ := newTestService(t, 3)
+	ctx := context.Background()
+
+	t.Run("manual templates should have target 1", func(t *testing.T) {
+		template, err := svc.CreateTemplate(ctx, CreateTemplateInput{Key: "check_in", Title: "Check-in", XPReward: 10, Target: 5})
+		require.NoError(t, err)
+		assert.True(t, template.IsManual())
+		assert.Equal(t, 1, template.Target)
+	})
+
+	t.Run("should reject unsupported trigger events", func(t *testing.T) {
+		_, err := svc.CreateTemplate(ctx, CreateTemplateInput{Key: "login", Title: "Login", XPReward: 10, TriggerEvent: "UserLoggedIn"})
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject duplicated keys", func(t *testing.T) {
+		_, err := svc.CreateTemplate(ctx, CreateTemplateInput{Key: "check_in", Title: "Outro", XPReward: 10})
+		assert.Error(t, err)
+	})
+}
+
+func TestService_TodayTasks(t *testing.T) {
+	svc, _, _ := newTestService(t, 1)
+	ctx := context.Background()
+	createTemplates(t, svc)
+
+	tasks, err := svc.TodayTasks(ctx, 7)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	// O dia segue o fuso do Schedule, não o UTC
+	assert.Equal(t, "2026-10-18", tasks[0].Day)
+
+	again, err := svc.TodayTasks(ctx, 7)
+	require.NoError(t, err)
+	require.Len(t, again, 1)
+	assert.Equal(t, tasks[0].ID, again[0].ID)
+
+	svc.now = func() time.Time { return time.Date(2026, 10, 19, 3, 30, 0, 0, time.UTC) }
+	tomorrow, err := svc.TodayTasks(ctx, 7)
+	require.NoError(t, err)
+	require.Len(t, tomorrow, 1)
+	assert.Equal(t, "2026-10-19", tomorrow[0].Day)
+}
+
+func TestService_CompleteTask(t *testing.T) {
+	svc, userService, bus := newTestService(t, 2)
+	ctx := context.Background()
+	createTemplates(t, svc)
+
+	tasks, err := svc.TodayTasks(ctx, 7)
+	require.NoError(t, err)
+	checkIn := taskByKey(t, tasks, "daily_check_in")
+	votes := taskByKey(t, tasks, "cast_valid_votes")
+
+	t.Run("should complete manual tasks and award XP", func(t *testing.T) {
+		task, err := svc.CompleteTask(ctx, 7, checkIn.ID)
+		require.NoError(t, err)
+		assert.True(t, task.IsCompleted())
+		assert.Equal(t, []xpGrant{{7, users.XPSourceDailyTask, checkIn.SourceID(), 10}}, userService.grants)
+		assert.Equal(t, "DailyTaskCompleted", bus.events[len(bus.events)-1].Type)
+	})
+
+	t.Run("should not complete twice", func(t *testing.T) {
+		_, err := svc.CompleteTask(ctx, 7, checkIn.ID)
+		assert.Error(t, err)
+		assert.Len(t, userService.grants, 1)
+	})
+
+	t.Run("should not complete event tasks manually", func(t *testing.T) {
+		_, err := svc.CompleteTask(ctx, 7, votes.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("should hide tasks of other users", func(t *testing.T) {
+		_, err := svc.CompleteTask(ctx, 8, checkIn.ID)
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+}
+
+func TestService_RecordEvent(t *testing.T) {
+	svc, userService, _ := newTestService(t, 2)
+	ctx := context.Background()
+	createTemplates(t, svc)
+
+	vote := func(valid bool) []*DailyAssignment {
+		completed, err := svc.RecordEvent(ctx, 7, "ChallengeVoteAdded", map[string]interface{}{"userID": uint(7), "isValid": valid})
+		require.NoError(t, err)
+		return completed
+	}
+
+	// O primeiro evento do dia gera as tarefas
+	assert.Empty(t, vote(true))
+	assert.Empty(t, vote(false))
+	assert.Empty(t, vote(true))
+
+	tasks, err := svc.TodayTasks(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 2, taskByKey(t, tasks, "cast_valid_votes").Progress)
+	assert.Empty(t, userService.grants)
+
+	completed := vote(true)
+	require.Len(t, completed, 1)
+	assert.Equal(t, 3, completed[0].Progress)
+	assert.Equal(t, []xpGrant{{7, users.XPSourceDailyTask, completed[0].SourceID(), 30}}, userService.grants)
+
+	// Tarefa concluída não avança nem concede XP de novo
+	assert.Empty(t, vote(true))
+	assert.Len(t, userService.grants, 1)
+}
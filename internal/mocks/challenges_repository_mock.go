@@ -10,7 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	challenges "github.com/rafaelcoelhox/labbend/internal/challenges"
-	gorm "gorm.io/gorm"
+	pagination "github.com/rafaelcoelhox/labbend/pkg/pagination"
 )
 
 // MockChallengesRepository is a mock of Repository interface.
@@ -65,20 +65,6 @@ func (mr *MockChallengesRepositoryMockRecorder) CreateChallenge(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallenge", reflect.TypeOf((*MockChallengesRepository)(nil).CreateChallenge), arg0, arg1)
 }
 
-// CreateChallengeWithTx mocks base method.
-func (m *MockChallengesRepository) CreateChallengeWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 *challenges.Challenge) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateChallengeWithTx", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CreateChallengeWithTx indicates an expected call of CreateChallengeWithTx.
-func (mr *MockChallengesRepositoryMockRecorder) CreateChallengeWithTx(arg0, arg1, arg2 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallengeWithTx", reflect.TypeOf((*MockChallengesRepository)(nil).CreateChallengeWithTx), arg0, arg1, arg2)
-}
-
 // CreateSubmission mocks base method.
 func (m *MockChallengesRepository) CreateSubmission(arg0 context.Context, arg1 *challenges.ChallengeSubmission) error {
 	m.ctrl.T.Helper()
@@ -93,20 +79,6 @@ func (mr *MockChallengesRepositoryMockRecorder) CreateSubmission(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubmission", reflect.TypeOf((*MockChallengesRepository)(nil).CreateSubmission), arg0, arg1)
 }
 
-// CreateSubmissionWithTx mocks base method.
-func (m *MockChallengesRepository) CreateSubmissionWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 *challenges.ChallengeSubmission) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSubmissionWithTx", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CreateSubmissionWithTx indicates an expected call of CreateSubmissionWithTx.
-func (mr *MockChallengesRepositoryMockRecorder) CreateSubmissionWithTx(arg0, arg1, arg2 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubmissionWithTx", reflect.TypeOf((*MockChallengesRepository)(nil).CreateSubmissionWithTx), arg0, arg1, arg2)
-}
-
 // CreateVote mocks base method.
 func (m *MockChallengesRepository) CreateVote(arg0 context.Context, arg1 *challenges.ChallengeVote) error {
 	m.ctrl.T.Helper()
@@ -121,18 +93,19 @@ func (mr *MockChallengesRepositoryMockRecorder) CreateVote(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVote", reflect.TypeOf((*MockChallengesRepository)(nil).CreateVote), arg0, arg1)
 }
 
-// CreateVoteWithTx mocks base method.
-func (m *MockChallengesRepository) CreateVoteWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 *challenges.ChallengeVote) error {
+// FindOrCreateTags mocks base method.
+func (m *MockChallengesRepository) FindOrCreateTags(arg0 context.Context, arg1 []string) ([]challenges.Tag, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVoteWithTx", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "FindOrCreateTags", arg0, arg1)
+	ret0, _ := ret[0].([]challenges.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CreateVoteWithTx indicates an expected call of CreateVoteWithTx.
-func (mr *MockChallengesRepositoryMockRecorder) CreateVoteWithTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+// FindOrCreateTags indicates an expected call of FindOrCreateTags.
+func (mr *MockChallengesRepositoryMockRecorder) FindOrCreateTags(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVoteWithTx", reflect.TypeOf((*MockChallengesRepository)(nil).CreateVoteWithTx), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreateTags", reflect.TypeOf((*MockChallengesRepository)(nil).FindOrCreateTags), arg0, arg1)
 }
 
 // GetChallengeByID mocks base method.
@@ -150,64 +123,79 @@ func (mr *MockChallengesRepositoryMockRecorder) GetChallengeByID(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallengeByID", reflect.TypeOf((*MockChallengesRepository)(nil).GetChallengeByID), arg0, arg1)
 }
 
-// GetChallengeByIDWithTx mocks base method.
-func (m *MockChallengesRepository) GetChallengeByIDWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 uint) (*challenges.Challenge, error) {
+// GetSubmissionByID mocks base method.
+func (m *MockChallengesRepository) GetSubmissionByID(arg0 context.Context, arg1 uint) (*challenges.ChallengeSubmission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetChallengeByIDWithTx", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*challenges.Challenge)
+	ret := m.ctrl.Call(m, "GetSubmissionByID", arg0, arg1)
+	ret0, _ := ret[0].(*challenges.ChallengeSubmission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetChallengeByIDWithTx indicates an expected call of GetChallengeByIDWithTx.
-func (mr *MockChallengesRepositoryMockRecorder) GetChallengeByIDWithTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetSubmissionByID indicates an expected call of GetSubmissionByID.
+func (mr *MockChallengesRepositoryMockRecorder) GetSubmissionByID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallengeByIDWithTx", reflect.TypeOf((*MockChallengesRepository)(nil).GetChallengeByIDWithTx), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionByID", reflect.TypeOf((*MockChallengesRepository)(nil).GetSubmissionByID), arg0, arg1)
 }
 
-// GetSubmissionByID mocks base method.
-func (m *MockChallengesRepository) GetSubmissionByID(arg0 context.Context, arg1 uint) (*challenges.ChallengeSubmission, error) {
+// GetSubmissionsByChallengeID mocks base method.
+func (m *MockChallengesRepository) GetSubmissionsByChallengeID(arg0 context.Context, arg1 uint) ([]*challenges.ChallengeSubmission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSubmissionByID", arg0, arg1)
-	ret0, _ := ret[0].(*challenges.ChallengeSubmission)
+	ret := m.ctrl.Call(m, "GetSubmissionsByChallengeID", arg0, arg1)
+	ret0, _ := ret[0].([]*challenges.ChallengeSubmission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSubmissionByID indicates an expected call of GetSubmissionByID.
-func (mr *MockChallengesRepositoryMockRecorder) GetSubmissionByID(arg0, arg1 interface{}) *gomock.Call {
+// GetSubmissionsByChallengeID indicates an expected call of GetSubmissionsByChallengeID.
+func (mr *MockChallengesRepositoryMockRecorder) GetSubmissionsByChallengeID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionByID", reflect.TypeOf((*MockChallengesRepository)(nil).GetSubmissionByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionsByChallengeID", reflect.TypeOf((*MockChallengesRepository)(nil).GetSubmissionsByChallengeID), arg0, arg1)
 }
 
-// GetSubmissionByIDWithTx mocks base method.
-func (m *MockChallengesRepository) GetSubmissionByIDWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 uint) (*challenges.ChallengeSubmission, error) {
+// GetSubmissionsByChallengeIDPaginated mocks base method.
+func (m *MockChallengesRepository) GetSubmissionsByChallengeIDPaginated(arg0 context.Context, arg1 uint, arg2 pagination.Page) ([]*challenges.ChallengeSubmission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSubmissionByIDWithTx", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*challenges.ChallengeSubmission)
+	ret := m.ctrl.Call(m, "GetSubmissionsByChallengeIDPaginated", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*challenges.ChallengeSubmission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSubmissionByIDWithTx indicates an expected call of GetSubmissionByIDWithTx.
-func (mr *MockChallengesRepositoryMockRecorder) GetSubmissionByIDWithTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetSubmissionsByChallengeIDPaginated indicates an expected call of GetSubmissionsByChallengeIDPaginated.
+func (mr *MockChallengesRepositoryMockRecorder) GetSubmissionsByChallengeIDPaginated(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionByIDWithTx", reflect.TypeOf((*MockChallengesRepository)(nil).GetSubmissionByIDWithTx), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionsByChallengeIDPaginated", reflect.TypeOf((*MockChallengesRepository)(nil).GetSubmissionsByChallengeIDPaginated), arg0, arg1, arg2)
 }
 
-// GetSubmissionsByChallengeID mocks base method.
-func (m *MockChallengesRepository) GetSubmissionsByChallengeID(arg0 context.Context, arg1 uint) ([]*challenges.ChallengeSubmission, error) {
+// GetSubmissionsByChallengeIDs mocks base method.
+func (m *MockChallengesRepository) GetSubmissionsByChallengeIDs(arg0 context.Context, arg1 []uint) ([]*challenges.ChallengeSubmission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSubmissionsByChallengeID", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetSubmissionsByChallengeIDs", arg0, arg1)
 	ret0, _ := ret[0].([]*challenges.ChallengeSubmission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSubmissionsByChallengeID indicates an expected call of GetSubmissionsByChallengeID.
-func (mr *MockChallengesRepositoryMockRecorder) GetSubmissionsByChallengeID(arg0, arg1 interface{}) *gomock.Call {
+// GetSubmissionsByChallengeIDs indicates an expected call of GetSubmissionsByChallengeIDs.
+func (mr *MockChallengesRepositoryMockRecorder) GetSubmissionsByChallengeIDs(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionsByChallengeID", reflect.TypeOf((*MockChallengesRepository)(nil).GetSubmissionsByChallengeID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionsByChallengeIDs", reflect.TypeOf((*MockChallengesRepository)(nil).GetSubmissionsByChallengeIDs), arg0, arg1)
+}
+
+// GetUserSubmissions mocks base method.
+func (m *MockChallengesRepository) GetUserSubmissions(arg0 context.Context, arg1, arg2 uint) ([]*challenges.ChallengeSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSubmissions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*challenges.ChallengeSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSubmissions indicates an expected call of GetUserSubmissions.
+func (mr *MockChallengesRepositoryMockRecorder) GetUserSubmissions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSubmissions", reflect.TypeOf((*MockChallengesRepository)(nil).GetUserSubmissions), arg0, arg1, arg2)
 }
 
 // GetVotesBySubmissionID mocks base method.
@@ -225,19 +213,19 @@ func (mr *MockChallengesRepositoryMockRecorder) GetVotesBySubmissionID(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotesBySubmissionID", reflect.TypeOf((*MockChallengesRepository)(nil).GetVotesBySubmissionID), arg0, arg1)
 }
 
-// HasUserSubmitted mocks base method.
-func (m *MockChallengesRepository) HasUserSubmitted(arg0 context.Context, arg1, arg2 uint) (bool, error) {
+// GetVotesBySubmissionIDs mocks base method.
+func (m *MockChallengesRepository) GetVotesBySubmissionIDs(arg0 context.Context, arg1 []uint) ([]*challenges.ChallengeVote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HasUserSubmitted", arg0, arg1, arg2)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "GetVotesBySubmissionIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*challenges.ChallengeVote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// HasUserSubmitted indicates an expected call of HasUserSubmitted.
-func (mr *MockChallengesRepositoryMockRecorder) HasUserSubmitted(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetVotesBySubmissionIDs indicates an expected call of GetVotesBySubmissionIDs.
+func (mr *MockChallengesRepositoryMockRecorder) GetVotesBySubmissionIDs(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUserSubmitted", reflect.TypeOf((*MockChallengesRepository)(nil).HasUserSubmitted), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotesBySubmissionIDs", reflect.TypeOf((*MockChallengesRepository)(nil).GetVotesBySubmissionIDs), arg0, arg1)
 }
 
 // HasUserVoted mocks base method.
@@ -270,30 +258,74 @@ func (mr *MockChallengesRepositoryMockRecorder) ListChallenges(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChallenges", reflect.TypeOf((*MockChallengesRepository)(nil).ListChallenges), arg0, arg1, arg2)
 }
 
-// UpdateSubmission mocks base method.
-func (m *MockChallengesRepository) UpdateSubmission(arg0 context.Context, arg1 *challenges.ChallengeSubmission) error {
+// LockUserSubmissions mocks base method.
+func (m *MockChallengesRepository) LockUserSubmissions(arg0 context.Context, arg1, arg2 uint) ([]*challenges.ChallengeSubmission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSubmission", arg0, arg1)
+	ret := m.ctrl.Call(m, "LockUserSubmissions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*challenges.ChallengeSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockUserSubmissions indicates an expected call of LockUserSubmissions.
+func (mr *MockChallengesRepositoryMockRecorder) LockUserSubmissions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUserSubmissions", reflect.TypeOf((*MockChallengesRepository)(nil).LockUserSubmissions), arg0, arg1, arg2)
+}
+
+// MarkSubmissionApproved mocks base method.
+func (m *MockChallengesRepository) MarkSubmissionApproved(arg0 context.Context, arg1 *challenges.ChallengeSubmission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSubmissionApproved", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateSubmission indicates an expected call of UpdateSubmission.
-func (mr *MockChallengesRepositoryMockRecorder) UpdateSubmission(arg0, arg1 interface{}) *gomock.Call {
+// MarkSubmissionApproved indicates an expected call of MarkSubmissionApproved.
+func (mr *MockChallengesRepositoryMockRecorder) MarkSubmissionApproved(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubmission", reflect.TypeOf((*MockChallengesRepository)(nil).UpdateSubmission), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSubmissionApproved", reflect.TypeOf((*MockChallengesRepository)(nil).MarkSubmissionApproved), arg0, arg1)
+}
+
+// MarkSubmissionRevoked mocks base method.
+func (m *MockChallengesRepository) MarkSubmissionRevoked(arg0 context.Context, arg1 *challenges.ChallengeSubmission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSubmissionRevoked", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSubmissionRevoked indicates an expected call of MarkSubmissionRevoked.
+func (mr *MockChallengesRepositoryMockRecorder) MarkSubmissionRevoked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSubmissionRevoked", reflect.TypeOf((*MockChallengesRepository)(nil).MarkSubmissionRevoked), arg0, arg1)
+}
+
+// SearchChallenges mocks base method.
+func (m *MockChallengesRepository) SearchChallenges(arg0 context.Context, arg1 challenges.SearchChallengesInput, arg2 pagination.Page) ([]*challenges.Challenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchChallenges", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*challenges.Challenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchChallenges indicates an expected call of SearchChallenges.
+func (mr *MockChallengesRepositoryMockRecorder) SearchChallenges(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchChallenges", reflect.TypeOf((*MockChallengesRepository)(nil).SearchChallenges), arg0, arg1, arg2)
 }
 
-// UpdateSubmissionWithTx mocks base method.
-func (m *MockChallengesRepository) UpdateSubmissionWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 *challenges.ChallengeSubmission) error {
+// UpdateSubmission mocks base method.
+func (m *MockChallengesRepository) UpdateSubmission(arg0 context.Context, arg1 *challenges.ChallengeSubmission) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSubmissionWithTx", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateSubmission", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateSubmissionWithTx indicates an expected call of UpdateSubmissionWithTx.
-func (mr *MockChallengesRepositoryMockRecorder) UpdateSubmissionWithTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+// UpdateSubmission indicates an expected call of UpdateSubmission.
+func (mr *MockChallengesRepositoryMockRecorder) UpdateSubmission(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubmissionWithTx", reflect.TypeOf((*MockChallengesRepository)(nil).UpdateSubmissionWithTx), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubmission", reflect.TypeOf((*MockChallengesRepository)(nil).UpdateSubmission), arg0, arg1)
 }
@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	challenges "github.com/rafaelcoelhox/labbend/internal/challenges"
+	pagination "github.com/rafaelcoelhox/labbend/pkg/pagination"
 )
 
 // MockChallengesService is a mock of Service interface.
@@ -35,6 +36,21 @@ func (m *MockChallengesService) EXPECT() *MockChallengesServiceMockRecorder {
 	return m.recorder
 }
 
+// ApproveSubmission mocks base method.
+func (m *MockChallengesService) ApproveSubmission(arg0 context.Context, arg1 uint, arg2 string) (*challenges.ChallengeSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveSubmission", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*challenges.ChallengeSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveSubmission indicates an expected call of ApproveSubmission.
+func (mr *MockChallengesServiceMockRecorder) ApproveSubmission(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveSubmission", reflect.TypeOf((*MockChallengesService)(nil).ApproveSubmission), arg0, arg1, arg2)
+}
+
 // CreateChallenge mocks base method.
 func (m *MockChallengesService) CreateChallenge(arg0 context.Context, arg1 challenges.CreateChallengeInput) (*challenges.Challenge, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +81,36 @@ func (mr *MockChallengesServiceMockRecorder) GetChallenge(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallenge", reflect.TypeOf((*MockChallengesService)(nil).GetChallenge), arg0, arg1)
 }
 
+// GetSubmission mocks base method.
+func (m *MockChallengesService) GetSubmission(arg0 context.Context, arg1 uint) (*challenges.ChallengeSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmission", arg0, arg1)
+	ret0, _ := ret[0].(*challenges.ChallengeSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmission indicates an expected call of GetSubmission.
+func (mr *MockChallengesServiceMockRecorder) GetSubmission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmission", reflect.TypeOf((*MockChallengesService)(nil).GetSubmission), arg0, arg1)
+}
+
+// GetSubmissionAttempts mocks base method.
+func (m *MockChallengesService) GetSubmissionAttempts(arg0 context.Context, arg1, arg2 uint) ([]*challenges.SubmissionAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmissionAttempts", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*challenges.SubmissionAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmissionAttempts indicates an expected call of GetSubmissionAttempts.
+func (mr *MockChallengesServiceMockRecorder) GetSubmissionAttempts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionAttempts", reflect.TypeOf((*MockChallengesService)(nil).GetSubmissionAttempts), arg0, arg1, arg2)
+}
+
 // GetSubmissionsByChallengeID mocks base method.
 func (m *MockChallengesService) GetSubmissionsByChallengeID(arg0 context.Context, arg1 uint) ([]*challenges.ChallengeSubmission, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +126,21 @@ func (mr *MockChallengesServiceMockRecorder) GetSubmissionsByChallengeID(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionsByChallengeID", reflect.TypeOf((*MockChallengesService)(nil).GetSubmissionsByChallengeID), arg0, arg1)
 }
 
+// GetSubmissionsByChallengeIDs mocks base method.
+func (m *MockChallengesService) GetSubmissionsByChallengeIDs(arg0 context.Context, arg1 []uint) (map[uint][]*challenges.ChallengeSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmissionsByChallengeIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[uint][]*challenges.ChallengeSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmissionsByChallengeIDs indicates an expected call of GetSubmissionsByChallengeIDs.
+func (mr *MockChallengesServiceMockRecorder) GetSubmissionsByChallengeIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionsByChallengeIDs", reflect.TypeOf((*MockChallengesService)(nil).GetSubmissionsByChallengeIDs), arg0, arg1)
+}
+
 // GetVotesBySubmissionID mocks base method.
 func (m *MockChallengesService) GetVotesBySubmissionID(arg0 context.Context, arg1 uint) ([]*challenges.ChallengeVote, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +156,21 @@ func (mr *MockChallengesServiceMockRecorder) GetVotesBySubmissionID(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotesBySubmissionID", reflect.TypeOf((*MockChallengesService)(nil).GetVotesBySubmissionID), arg0, arg1)
 }
 
+// GetVotesBySubmissionIDs mocks base method.
+func (m *MockChallengesService) GetVotesBySubmissionIDs(arg0 context.Context, arg1 []uint) (map[uint][]*challenges.ChallengeVote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVotesBySubmissionIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[uint][]*challenges.ChallengeVote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVotesBySubmissionIDs indicates an expected call of GetVotesBySubmissionIDs.
+func (mr *MockChallengesServiceMockRecorder) GetVotesBySubmissionIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotesBySubmissionIDs", reflect.TypeOf((*MockChallengesService)(nil).GetVotesBySubmissionIDs), arg0, arg1)
+}
+
 // ListChallenges mocks base method.
 func (m *MockChallengesService) ListChallenges(arg0 context.Context, arg1, arg2 int) ([]*challenges.Challenge, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +186,66 @@ func (mr *MockChallengesServiceMockRecorder) ListChallenges(arg0, arg1, arg2 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChallenges", reflect.TypeOf((*MockChallengesService)(nil).ListChallenges), arg0, arg1, arg2)
 }
 
+// ListSubmissionsConnection mocks base method.
+func (m *MockChallengesService) ListSubmissionsConnection(arg0 context.Context, arg1 uint, arg2 pagination.Args) (*pagination.Connection[*challenges.ChallengeSubmission], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubmissionsConnection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*pagination.Connection[*challenges.ChallengeSubmission])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubmissionsConnection indicates an expected call of ListSubmissionsConnection.
+func (mr *MockChallengesServiceMockRecorder) ListSubmissionsConnection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubmissionsConnection", reflect.TypeOf((*MockChallengesService)(nil).ListSubmissionsConnection), arg0, arg1, arg2)
+}
+
+// RejectSubmission mocks base method.
+func (m *MockChallengesService) RejectSubmission(arg0 context.Context, arg1 uint, arg2 string) (*challenges.ChallengeSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectSubmission", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*challenges.ChallengeSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectSubmission indicates an expected call of RejectSubmission.
+func (mr *MockChallengesServiceMockRecorder) RejectSubmission(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectSubmission", reflect.TypeOf((*MockChallengesService)(nil).RejectSubmission), arg0, arg1, arg2)
+}
+
+// RevokeSubmission mocks base method.
+func (m *MockChallengesService) RevokeSubmission(arg0 context.Context, arg1 uint, arg2 challenges.RevokeSubmissionInput) (*challenges.ChallengeSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSubmission", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*challenges.ChallengeSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSubmission indicates an expected call of RevokeSubmission.
+func (mr *MockChallengesServiceMockRecorder) RevokeSubmission(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSubmission", reflect.TypeOf((*MockChallengesService)(nil).RevokeSubmission), arg0, arg1, arg2)
+}
+
+// SearchChallenges mocks base method.
+func (m *MockChallengesService) SearchChallenges(arg0 context.Context, arg1 challenges.SearchChallengesInput, arg2 pagination.Args) (*pagination.Connection[*challenges.Challenge], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchChallenges", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*pagination.Connection[*challenges.Challenge])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchChallenges indicates an expected call of SearchChallenges.
+func (mr *MockChallengesServiceMockRecorder) SearchChallenges(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchChallenges", reflect.TypeOf((*MockChallengesService)(nil).SearchChallenges), arg0, arg1, arg2)
+}
+
 // SubmitChallenge mocks base method.
 func (m *MockChallengesService) SubmitChallenge(arg0 context.Context, arg1 uint, arg2 challenges.SubmitChallengeInput) (*challenges.ChallengeSubmission, error) {
 	m.ctrl.T.Helper()
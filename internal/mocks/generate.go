@@ -10,6 +10,10 @@ package mocks
 //go:generate mockgen -destination=challenges_eventbus_mock.go -package=mocks -mock_names=EventBus=MockChallengesEventBus github.com/rafaelcoelhox/labbend/internal/challenges EventBus
 //go:generate mockgen -destination=eventbus_handler_mock.go -package=mocks -mock_names=EventHandler=MockEventHandler github.com/rafaelcoelhox/labbend/pkg/eventbus EventHandler
 //go:generate mockgen -destination=logger_mock.go -package=mocks -mock_names=Logger=MockLogger github.com/rafaelcoelhox/labbend/pkg/logger Logger
+//go:generate mockgen -destination=moderation_repository_mock.go -package=mocks -mock_names=Repository=MockModerationRepository github.com/rafaelcoelhox/labbend/internal/moderation Repository
+//go:generate mockgen -destination=moderation_service_mock.go -package=mocks -mock_names=Service=MockModerationService github.com/rafaelcoelhox/labbend/internal/moderation Service
+//go:generate mockgen -destination=moderation_eventbus_mock.go -package=mocks -mock_names=EventBus=MockModerationEventBus github.com/rafaelcoelhox/labbend/internal/moderation EventBus
+//go:generate mockgen -destination=moderation_challengeservice_mock.go -package=mocks -mock_names=ChallengeService=MockModerationChallengeService github.com/rafaelcoelhox/labbend/internal/moderation ChallengeService
 //go:generate mockgen -destination=notifications_repository_mock.go -package=mocks -mock_names=Repository=MockNotificationsRepository github.com/rafaelcoelhox/labbend/internal/notifications Repository
 //go:generate mockgen -destination=notifications_service_mock.go -package=mocks -mock_names=Service=MockNotificationsService github.com/rafaelcoelhox/labbend/internal/notifications Service
 //go:generate mockgen -destination=notifications_eventbus_mock.go -package=mocks -mock_names=EventBus=MockNotificationsEventBus github.com/rafaelcoelhox/labbend/internal/notifications EventBus
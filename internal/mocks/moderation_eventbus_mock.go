@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rafaelcoelhox/labbend/internal/moderation (interfaces: EventBus)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	eventbus "github.com/rafaelcoelhox/labbend/pkg/eventbus"
+)
+
+// MockModerationEventBus is a mock of EventBus interface.
+type MockModerationEventBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationEventBusMockRecorder
+}
+
+// MockModerationEventBusMockRecorder is the mock recorder for MockModerationEventBus.
+type MockModerationEventBusMockRecorder struct {
+	mock *MockModerationEventBus
+}
+
+// NewMockModerationEventBus creates a new mock instance.
+func NewMockModerationEventBus(ctrl *gomock.Controller) *MockModerationEventBus {
+	mock := &MockModerationEventBus{ctrl: ctrl}
+	mock.recorder = &MockModerationEventBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerationEventBus) EXPECT() *MockModerationEventBusMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockModerationEventBus) Publish(arg0 eventbus.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", arg0)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockModerationEventBusMockRecorder) Publish(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockModerationEventBus)(nil).Publish), arg0)
+}
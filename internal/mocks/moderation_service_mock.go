@@ -0,0 +1,186 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rafaelcoelhox/labbend/internal/moderation (interfaces: Service)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	challenges "github.com/rafaelcoelhox/labbend/internal/challenges"
+	moderation "github.com/rafaelcoelhox/labbend/internal/moderation"
+)
+
+// MockModerationService is a mock of Service interface.
+type MockModerationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationServiceMockRecorder
+}
+
+// MockModerationServiceMockRecorder is the mock recorder for MockModerationService.
+type MockModerationServiceMockRecorder struct {
+	mock *MockModerationService
+}
+
+// NewMockModerationService creates a new mock instance.
+func NewMockModerationService(ctrl *gomock.Controller) *MockModerationService {
+	mock := &MockModerationService{ctrl: ctrl}
+	mock.recorder = &MockModerationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerationService) EXPECT() *MockModerationServiceMockRecorder {
+	return m.recorder
+}
+
+// AddModerator mocks base method.
+func (m *MockModerationService) AddModerator(arg0 context.Context, arg1 uint) (*moderation.Moderator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddModerator", arg0, arg1)
+	ret0, _ := ret[0].(*moderation.Moderator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddModerator indicates an expected call of AddModerator.
+func (mr *MockModerationServiceMockRecorder) AddModerator(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddModerator", reflect.TypeOf((*MockModerationService)(nil).AddModerator), arg0, arg1)
+}
+
+// AssignAppeal mocks base method.
+func (m *MockModerationService) AssignAppeal(arg0 context.Context, arg1, arg2 uint) (*moderation.Appeal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignAppeal", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*moderation.Appeal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignAppeal indicates an expected call of AssignAppeal.
+func (mr *MockModerationServiceMockRecorder) AssignAppeal(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignAppeal", reflect.TypeOf((*MockModerationService)(nil).AssignAppeal), arg0, arg1, arg2)
+}
+
+// FileAppeal mocks base method.
+func (m *MockModerationService) FileAppeal(arg0 context.Context, arg1 uint, arg2 moderation.FileAppealInput) (*moderation.Appeal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileAppeal", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*moderation.Appeal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FileAppeal indicates an expected call of FileAppeal.
+func (mr *MockModerationServiceMockRecorder) FileAppeal(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileAppeal", reflect.TypeOf((*MockModerationService)(nil).FileAppeal), arg0, arg1, arg2)
+}
+
+// GetAppeal mocks base method.
+func (m *MockModerationService) GetAppeal(arg0 context.Context, arg1 uint) (*moderation.Appeal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppeal", arg0, arg1)
+	ret0, _ := ret[0].(*moderation.Appeal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppeal indicates an expected call of GetAppeal.
+func (mr *MockModerationServiceMockRecorder) GetAppeal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppeal", reflect.TypeOf((*MockModerationService)(nil).GetAppeal), arg0, arg1)
+}
+
+// GetSubmissionHistory mocks base method.
+func (m *MockModerationService) GetSubmissionHistory(arg0 context.Context, arg1 uint) ([]*moderation.ModerationAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmissionHistory", arg0, arg1)
+	ret0, _ := ret[0].([]*moderation.ModerationAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmissionHistory indicates an expected call of GetSubmissionHistory.
+func (mr *MockModerationServiceMockRecorder) GetSubmissionHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionHistory", reflect.TypeOf((*MockModerationService)(nil).GetSubmissionHistory), arg0, arg1)
+}
+
+// IsModerator mocks base method.
+func (m *MockModerationService) IsModerator(arg0 context.Context, arg1 uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsModerator", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsModerator indicates an expected call of IsModerator.
+func (mr *MockModerationServiceMockRecorder) IsModerator(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsModerator", reflect.TypeOf((*MockModerationService)(nil).IsModerator), arg0, arg1)
+}
+
+// ListAppealQueue mocks base method.
+func (m *MockModerationService) ListAppealQueue(arg0 context.Context, arg1 string, arg2 *uint, arg3, arg4 int) ([]*moderation.Appeal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAppealQueue", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*moderation.Appeal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAppealQueue indicates an expected call of ListAppealQueue.
+func (mr *MockModerationServiceMockRecorder) ListAppealQueue(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAppealQueue", reflect.TypeOf((*MockModerationService)(nil).ListAppealQueue), arg0, arg1, arg2, arg3, arg4)
+}
+
+// OverrideSubmission mocks base method.
+func (m *MockModerationService) OverrideSubmission(arg0 context.Context, arg1 uint, arg2 moderation.OverrideSubmissionInput) (*challenges.ChallengeSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OverrideSubmission", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*challenges.ChallengeSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OverrideSubmission indicates an expected call of OverrideSubmission.
+func (mr *MockModerationServiceMockRecorder) OverrideSubmission(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OverrideSubmission", reflect.TypeOf((*MockModerationService)(nil).OverrideSubmission), arg0, arg1, arg2)
+}
+
+// RemoveModerator mocks base method.
+func (m *MockModerationService) RemoveModerator(arg0 context.Context, arg1 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveModerator", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveModerator indicates an expected call of RemoveModerator.
+func (mr *MockModerationServiceMockRecorder) RemoveModerator(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveModerator", reflect.TypeOf((*MockModerationService)(nil).RemoveModerator), arg0, arg1)
+}
+
+// ResolveAppeal mocks base method.
+func (m *MockModerationService) ResolveAppeal(arg0 context.Context, arg1 uint, arg2 moderation.ResolveAppealInput) (*moderation.Appeal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveAppeal", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*moderation.Appeal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveAppeal indicates an expected call of ResolveAppeal.
+func (mr *MockModerationServiceMockRecorder) ResolveAppeal(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveAppeal", reflect.TypeOf((*MockModerationService)(nil).ResolveAppeal), arg0, arg1, arg2)
+}
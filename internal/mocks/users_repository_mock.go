@@ -7,10 +7,11 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	users "github.com/rafaelcoelhox/labbend/internal/users"
-	gorm "gorm.io/gorm"
+	pagination "github.com/rafaelcoelhox/labbend/pkg/pagination"
 )
 
 // MockUsersRepository is a mock of Repository interface.
@@ -64,34 +65,6 @@ func (mr *MockUsersRepositoryMockRecorder) CreateUserXP(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserXP", reflect.TypeOf((*MockUsersRepository)(nil).CreateUserXP), arg0, arg1)
 }
 
-// CreateUserXPWithTx mocks base method.
-func (m *MockUsersRepository) CreateUserXPWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 *users.UserXP) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserXPWithTx", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CreateUserXPWithTx indicates an expected call of CreateUserXPWithTx.
-func (mr *MockUsersRepositoryMockRecorder) CreateUserXPWithTx(arg0, arg1, arg2 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserXPWithTx", reflect.TypeOf((*MockUsersRepository)(nil).CreateUserXPWithTx), arg0, arg1, arg2)
-}
-
-// CreateWithTx mocks base method.
-func (m *MockUsersRepository) CreateWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 *users.User) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateWithTx", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CreateWithTx indicates an expected call of CreateWithTx.
-func (mr *MockUsersRepositoryMockRecorder) CreateWithTx(arg0, arg1, arg2 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithTx", reflect.TypeOf((*MockUsersRepository)(nil).CreateWithTx), arg0, arg1, arg2)
-}
-
 // Delete mocks base method.
 func (m *MockUsersRepository) Delete(arg0 context.Context, arg1 uint) error {
 	m.ctrl.T.Helper()
@@ -106,18 +79,34 @@ func (mr *MockUsersRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUsersRepository)(nil).Delete), arg0, arg1)
 }
 
-// DeleteWithTx mocks base method.
-func (m *MockUsersRepository) DeleteWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 uint) error {
+// ExpireXP mocks base method.
+func (m *MockUsersRepository) ExpireXP(arg0 context.Context, arg1 *users.UserXP, arg2 time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteWithTx", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ExpireXP", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteWithTx indicates an expected call of DeleteWithTx.
-func (mr *MockUsersRepositoryMockRecorder) DeleteWithTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+// ExpireXP indicates an expected call of ExpireXP.
+func (mr *MockUsersRepositoryMockRecorder) ExpireXP(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithTx", reflect.TypeOf((*MockUsersRepository)(nil).DeleteWithTx), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireXP", reflect.TypeOf((*MockUsersRepository)(nil).ExpireXP), arg0, arg1, arg2)
+}
+
+// FindXPBalanceDrift mocks base method.
+func (m *MockUsersRepository) FindXPBalanceDrift(arg0 context.Context) ([]users.XPBalanceDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindXPBalanceDrift", arg0)
+	ret0, _ := ret[0].([]users.XPBalanceDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindXPBalanceDrift indicates an expected call of FindXPBalanceDrift.
+func (mr *MockUsersRepositoryMockRecorder) FindXPBalanceDrift(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindXPBalanceDrift", reflect.TypeOf((*MockUsersRepository)(nil).FindXPBalanceDrift), arg0)
 }
 
 // GetByEmail mocks base method.
@@ -150,19 +139,19 @@ func (mr *MockUsersRepositoryMockRecorder) GetByID(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUsersRepository)(nil).GetByID), arg0, arg1)
 }
 
-// GetByIDWithTx mocks base method.
-func (m *MockUsersRepository) GetByIDWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 uint) (*users.User, error) {
+// GetByIDs mocks base method.
+func (m *MockUsersRepository) GetByIDs(arg0 context.Context, arg1 []uint) ([]*users.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByIDWithTx", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*users.User)
+	ret := m.ctrl.Call(m, "GetByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*users.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByIDWithTx indicates an expected call of GetByIDWithTx.
-func (mr *MockUsersRepositoryMockRecorder) GetByIDWithTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockUsersRepositoryMockRecorder) GetByIDs(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDWithTx", reflect.TypeOf((*MockUsersRepository)(nil).GetByIDWithTx), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockUsersRepository)(nil).GetByIDs), arg0, arg1)
 }
 
 // GetByNickname mocks base method.
@@ -180,34 +169,34 @@ func (mr *MockUsersRepositoryMockRecorder) GetByNickname(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByNickname", reflect.TypeOf((*MockUsersRepository)(nil).GetByNickname), arg0, arg1)
 }
 
-// GetByNicknameWithTx mocks base method.
-func (m *MockUsersRepository) GetByNicknameWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 string) (*users.User, error) {
+// GetMultipleUsersXP mocks base method.
+func (m *MockUsersRepository) GetMultipleUsersXP(arg0 context.Context, arg1 []uint) (map[uint]int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByNicknameWithTx", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*users.User)
+	ret := m.ctrl.Call(m, "GetMultipleUsersXP", arg0, arg1)
+	ret0, _ := ret[0].(map[uint]int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByNicknameWithTx indicates an expected call of GetByNicknameWithTx.
-func (mr *MockUsersRepositoryMockRecorder) GetByNicknameWithTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetMultipleUsersXP indicates an expected call of GetMultipleUsersXP.
+func (mr *MockUsersRepositoryMockRecorder) GetMultipleUsersXP(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByNicknameWithTx", reflect.TypeOf((*MockUsersRepository)(nil).GetByNicknameWithTx), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultipleUsersXP", reflect.TypeOf((*MockUsersRepository)(nil).GetMultipleUsersXP), arg0, arg1)
 }
 
-// GetMultipleUsersXP mocks base method.
-func (m *MockUsersRepository) GetMultipleUsersXP(arg0 context.Context, arg1 []uint) (map[uint]int, error) {
+// GetSourceXPNet mocks base method.
+func (m *MockUsersRepository) GetSourceXPNet(arg0 context.Context, arg1 uint, arg2, arg3 string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMultipleUsersXP", arg0, arg1)
-	ret0, _ := ret[0].(map[uint]int)
+	ret := m.ctrl.Call(m, "GetSourceXPNet", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMultipleUsersXP indicates an expected call of GetMultipleUsersXP.
-func (mr *MockUsersRepositoryMockRecorder) GetMultipleUsersXP(arg0, arg1 interface{}) *gomock.Call {
+// GetSourceXPNet indicates an expected call of GetSourceXPNet.
+func (mr *MockUsersRepositoryMockRecorder) GetSourceXPNet(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultipleUsersXP", reflect.TypeOf((*MockUsersRepository)(nil).GetMultipleUsersXP), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSourceXPNet", reflect.TypeOf((*MockUsersRepository)(nil).GetSourceXPNet), arg0, arg1, arg2, arg3)
 }
 
 // GetUserTotalXP mocks base method.
@@ -240,6 +229,36 @@ func (mr *MockUsersRepositoryMockRecorder) GetUserXPHistory(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserXPHistory", reflect.TypeOf((*MockUsersRepository)(nil).GetUserXPHistory), arg0, arg1)
 }
 
+// GetUserXPHistoryPaginated mocks base method.
+func (m *MockUsersRepository) GetUserXPHistoryPaginated(arg0 context.Context, arg1 uint, arg2 pagination.Page) ([]*users.UserXP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserXPHistoryPaginated", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*users.UserXP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserXPHistoryPaginated indicates an expected call of GetUserXPHistoryPaginated.
+func (mr *MockUsersRepositoryMockRecorder) GetUserXPHistoryPaginated(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserXPHistoryPaginated", reflect.TypeOf((*MockUsersRepository)(nil).GetUserXPHistoryPaginated), arg0, arg1, arg2)
+}
+
+// GetUsersRankedByXP mocks base method.
+func (m *MockUsersRepository) GetUsersRankedByXP(arg0 context.Context, arg1, arg2 int) ([]*users.UserWithXP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersRankedByXP", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*users.UserWithXP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersRankedByXP indicates an expected call of GetUsersRankedByXP.
+func (mr *MockUsersRepositoryMockRecorder) GetUsersRankedByXP(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersRankedByXP", reflect.TypeOf((*MockUsersRepository)(nil).GetUsersRankedByXP), arg0, arg1, arg2)
+}
+
 // GetUsersWithXP mocks base method.
 func (m *MockUsersRepository) GetUsersWithXP(arg0 context.Context, arg1, arg2 int) ([]*users.UserWithXP, error) {
 	m.ctrl.T.Helper()
@@ -255,6 +274,21 @@ func (mr *MockUsersRepositoryMockRecorder) GetUsersWithXP(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersWithXP", reflect.TypeOf((*MockUsersRepository)(nil).GetUsersWithXP), arg0, arg1, arg2)
 }
 
+// GetXPGrantsSince mocks base method.
+func (m *MockUsersRepository) GetXPGrantsSince(arg0 context.Context, arg1 uint, arg2 string, arg3 time.Time) ([]*users.UserXP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXPGrantsSince", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*users.UserXP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetXPGrantsSince indicates an expected call of GetXPGrantsSince.
+func (mr *MockUsersRepositoryMockRecorder) GetXPGrantsSince(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXPGrantsSince", reflect.TypeOf((*MockUsersRepository)(nil).GetXPGrantsSince), arg0, arg1, arg2, arg3)
+}
+
 // List mocks base method.
 func (m *MockUsersRepository) List(arg0 context.Context, arg1, arg2 int) ([]*users.User, error) {
 	m.ctrl.T.Helper()
@@ -270,44 +304,75 @@ func (mr *MockUsersRepositoryMockRecorder) List(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUsersRepository)(nil).List), arg0, arg1, arg2)
 }
 
-// RemoveUserXPWithTx mocks base method.
-func (m *MockUsersRepository) RemoveUserXPWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 uint, arg3, arg4 string, arg5 int) error {
+// ListExpiredXP mocks base method.
+func (m *MockUsersRepository) ListExpiredXP(arg0 context.Context, arg1 time.Time, arg2 int) ([]*users.UserXP, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveUserXPWithTx", arg0, arg1, arg2, arg3, arg4, arg5)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListExpiredXP", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*users.UserXP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RemoveUserXPWithTx indicates an expected call of RemoveUserXPWithTx.
-func (mr *MockUsersRepositoryMockRecorder) RemoveUserXPWithTx(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+// ListExpiredXP indicates an expected call of ListExpiredXP.
+func (mr *MockUsersRepositoryMockRecorder) ListExpiredXP(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserXPWithTx", reflect.TypeOf((*MockUsersRepository)(nil).RemoveUserXPWithTx), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredXP", reflect.TypeOf((*MockUsersRepository)(nil).ListExpiredXP), arg0, arg1, arg2)
 }
 
-// Update mocks base method.
-func (m *MockUsersRepository) Update(arg0 context.Context, arg1 *users.User) error {
+// ListPaginated mocks base method.
+func (m *MockUsersRepository) ListPaginated(arg0 context.Context, arg1 pagination.Page) ([]*users.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret := m.ctrl.Call(m, "ListPaginated", arg0, arg1)
+	ret0, _ := ret[0].([]*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaginated indicates an expected call of ListPaginated.
+func (mr *MockUsersRepositoryMockRecorder) ListPaginated(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaginated", reflect.TypeOf((*MockUsersRepository)(nil).ListPaginated), arg0, arg1)
+}
+
+// LockXPSource mocks base method.
+func (m *MockUsersRepository) LockXPSource(arg0 context.Context, arg1 uint, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockXPSource", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockUsersRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+// LockXPSource indicates an expected call of LockXPSource.
+func (mr *MockUsersRepositoryMockRecorder) LockXPSource(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUsersRepository)(nil).Update), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockXPSource", reflect.TypeOf((*MockUsersRepository)(nil).LockXPSource), arg0, arg1, arg2)
 }
 
-// UpdateWithTx mocks base method.
-func (m *MockUsersRepository) UpdateWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 *users.User) error {
+// RecalculateXPBalance mocks base method.
+func (m *MockUsersRepository) RecalculateXPBalance(arg0 context.Context, arg1 uint) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateWithTx", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "RecalculateXPBalance", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecalculateXPBalance indicates an expected call of RecalculateXPBalance.
+func (mr *MockUsersRepositoryMockRecorder) RecalculateXPBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecalculateXPBalance", reflect.TypeOf((*MockUsersRepository)(nil).RecalculateXPBalance), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockUsersRepository) Update(arg0 context.Context, arg1 *users.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateWithTx indicates an expected call of UpdateWithTx.
-func (mr *MockUsersRepositoryMockRecorder) UpdateWithTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockUsersRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithTx", reflect.TypeOf((*MockUsersRepository)(nil).UpdateWithTx), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUsersRepository)(nil).Update), arg0, arg1)
 }
@@ -10,7 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	users "github.com/rafaelcoelhox/labbend/internal/users"
-	gorm "gorm.io/gorm"
+	pagination "github.com/rafaelcoelhox/labbend/pkg/pagination"
 )
 
 // MockUsersService is a mock of Service interface.
@@ -65,6 +65,21 @@ func (mr *MockUsersServiceMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUsersService)(nil).DeleteUser), arg0, arg1)
 }
 
+// GetMultipleUsersXP mocks base method.
+func (m *MockUsersService) GetMultipleUsersXP(arg0 context.Context, arg1 []uint) (map[uint]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultipleUsersXP", arg0, arg1)
+	ret0, _ := ret[0].(map[uint]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultipleUsersXP indicates an expected call of GetMultipleUsersXP.
+func (mr *MockUsersServiceMockRecorder) GetMultipleUsersXP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultipleUsersXP", reflect.TypeOf((*MockUsersService)(nil).GetMultipleUsersXP), arg0, arg1)
+}
+
 // GetUser mocks base method.
 func (m *MockUsersService) GetUser(arg0 context.Context, arg1 uint) (*users.User, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +140,36 @@ func (mr *MockUsersServiceMockRecorder) GetUserXPHistory(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserXPHistory", reflect.TypeOf((*MockUsersService)(nil).GetUserXPHistory), arg0, arg1)
 }
 
+// GetUserXPHistoryConnection mocks base method.
+func (m *MockUsersService) GetUserXPHistoryConnection(arg0 context.Context, arg1 uint, arg2 pagination.Args) (*pagination.Connection[*users.UserXP], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserXPHistoryConnection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*pagination.Connection[*users.UserXP])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserXPHistoryConnection indicates an expected call of GetUserXPHistoryConnection.
+func (mr *MockUsersServiceMockRecorder) GetUserXPHistoryConnection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserXPHistoryConnection", reflect.TypeOf((*MockUsersService)(nil).GetUserXPHistoryConnection), arg0, arg1, arg2)
+}
+
+// GetUsersByIDs mocks base method.
+func (m *MockUsersService) GetUsersByIDs(arg0 context.Context, arg1 []uint) (map[uint]*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[uint]*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByIDs indicates an expected call of GetUsersByIDs.
+func (mr *MockUsersServiceMockRecorder) GetUsersByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIDs", reflect.TypeOf((*MockUsersService)(nil).GetUsersByIDs), arg0, arg1)
+}
+
 // GiveUserXP mocks base method.
 func (m *MockUsersService) GiveUserXP(arg0 context.Context, arg1 uint, arg2, arg3 string, arg4 int) error {
 	m.ctrl.T.Helper()
@@ -139,18 +184,19 @@ func (mr *MockUsersServiceMockRecorder) GiveUserXP(arg0, arg1, arg2, arg3, arg4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GiveUserXP", reflect.TypeOf((*MockUsersService)(nil).GiveUserXP), arg0, arg1, arg2, arg3, arg4)
 }
 
-// GiveUserXPWithTx mocks base method.
-func (m *MockUsersService) GiveUserXPWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 uint, arg3, arg4 string, arg5 int) error {
+// ListLeaderboard mocks base method.
+func (m *MockUsersService) ListLeaderboard(arg0 context.Context, arg1, arg2 int) ([]*users.UserWithXP, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GiveUserXPWithTx", arg0, arg1, arg2, arg3, arg4, arg5)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListLeaderboard", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*users.UserWithXP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GiveUserXPWithTx indicates an expected call of GiveUserXPWithTx.
-func (mr *MockUsersServiceMockRecorder) GiveUserXPWithTx(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+// ListLeaderboard indicates an expected call of ListLeaderboard.
+func (mr *MockUsersServiceMockRecorder) ListLeaderboard(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GiveUserXPWithTx", reflect.TypeOf((*MockUsersService)(nil).GiveUserXPWithTx), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLeaderboard", reflect.TypeOf((*MockUsersService)(nil).ListLeaderboard), arg0, arg1, arg2)
 }
 
 // ListUsers mocks base method.
@@ -168,6 +214,21 @@ func (mr *MockUsersServiceMockRecorder) ListUsers(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUsersService)(nil).ListUsers), arg0, arg1, arg2)
 }
 
+// ListUsersConnection mocks base method.
+func (m *MockUsersService) ListUsersConnection(arg0 context.Context, arg1 pagination.Args) (*pagination.Connection[*users.User], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersConnection", arg0, arg1)
+	ret0, _ := ret[0].(*pagination.Connection[*users.User])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersConnection indicates an expected call of ListUsersConnection.
+func (mr *MockUsersServiceMockRecorder) ListUsersConnection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersConnection", reflect.TypeOf((*MockUsersService)(nil).ListUsersConnection), arg0, arg1)
+}
+
 // ListUsersWithXP mocks base method.
 func (m *MockUsersService) ListUsersWithXP(arg0 context.Context, arg1, arg2 int) ([]*users.UserWithXP, error) {
 	m.ctrl.T.Helper()
@@ -184,11 +245,12 @@ func (mr *MockUsersServiceMockRecorder) ListUsersWithXP(arg0, arg1, arg2 interfa
 }
 
 // RemoveUserXP mocks base method.
-func (m *MockUsersService) RemoveUserXP(arg0 context.Context, arg1 uint, arg2, arg3 string, arg4 int) error {
+func (m *MockUsersService) RemoveUserXP(arg0 context.Context, arg1 uint, arg2, arg3 string, arg4 int) (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RemoveUserXP", arg0, arg1, arg2, arg3, arg4)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // RemoveUserXP indicates an expected call of RemoveUserXP.
@@ -197,18 +259,18 @@ func (mr *MockUsersServiceMockRecorder) RemoveUserXP(arg0, arg1, arg2, arg3, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserXP", reflect.TypeOf((*MockUsersService)(nil).RemoveUserXP), arg0, arg1, arg2, arg3, arg4)
 }
 
-// RemoveUserXPWithTx mocks base method.
-func (m *MockUsersService) RemoveUserXPWithTx(arg0 context.Context, arg1 *gorm.DB, arg2 uint, arg3, arg4 string, arg5 int) error {
+// RestoreUserXP mocks base method.
+func (m *MockUsersService) RestoreUserXP(arg0 context.Context, arg1 uint, arg2, arg3 string, arg4 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveUserXPWithTx", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret := m.ctrl.Call(m, "RestoreUserXP", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RemoveUserXPWithTx indicates an expected call of RemoveUserXPWithTx.
-func (mr *MockUsersServiceMockRecorder) RemoveUserXPWithTx(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+// RestoreUserXP indicates an expected call of RestoreUserXP.
+func (mr *MockUsersServiceMockRecorder) RestoreUserXP(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserXPWithTx", reflect.TypeOf((*MockUsersService)(nil).RemoveUserXPWithTx), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUserXP", reflect.TypeOf((*MockUsersService)(nil).RestoreUserXP), arg0, arg1, arg2, arg3, arg4)
 }
 
 // UpdateUser mocks base method.
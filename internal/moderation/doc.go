@@ -0,0 +1,62 @@
+// Package moderation implementa a moderação de submissions e o sistema de
+// apelações da plataforma LabEnd.
+//
+// A votação comunitária do pacote challenges decide a maioria dos casos, mas
+// moderadores podem sobrescrever o resultado e usuários podem apelar de uma
+// rejeição.
+//
+// # Funcionalidades
+//
+//   - Cadastro de moderadores (tabela moderators), restrito a admins
+//   - Override de decisões: aprovar ou rejeitar uma submission com motivo;
+//     rejeitar uma aprovada usa ChallengeService.RevokeSubmission
+//   - Uma apelação por submission rejeitada, com justificativa
+//   - Fila de apelações com atribuição e resolução
+//   - Trilha de auditoria em moderation_actions
+//
+// # Ciclo de Vida da Apelação
+//
+//	pending -> assigned -> upheld      (rejeição mantida)
+//	                    -> overturned  (rejeição revertida)
+//
+// Uma apelação pendente pode ser resolvida diretamente; nesse caso ela é
+// atribuída ao moderador que a resolveu. Apelações atribuídas só podem ser
+// resolvidas pelo moderador responsável.
+//
+// As mutations GraphQL identificam o usuário ou moderador pelo auth.Viewer
+// do contexto e retornam Unauthorized para chamadas anônimas.
+//
+// # Transações
+//
+// Reverter uma rejeição aprova a submission através de
+// ChallengeService.ApproveSubmission, que concede o XP via
+// UserService.GiveUserXP. Os dois participam da transação aberta com
+// TxManager.InTransaction: a atualização da apelação, a aprovação, o XP e o
+// registro de auditoria são gravados juntos.
+//
+// # Eventos
+//
+// O pacote publica os seguintes eventos:
+//   - AppealFiled: Quando um usuário registra uma apelação
+//   - AppealAssigned: Quando uma apelação é atribuída a um moderador
+//   - AppealResolved: Quando uma apelação é resolvida (depois do commit)
+//
+// # Exemplo de Uso
+//
+//	moderationRepo := moderation.NewRepository(db)
+//	moderationService := moderation.NewService(moderationRepo, challengeService, logger, eventBus, txManager)
+//
+//	// Usuário apela da rejeição
+//	appeal, err := moderationService.FileAppeal(ctx, userID, moderation.FileAppealInput{
+//		SubmissionID:  "42",
+//		Justification: "O link aponta para o commit final do projeto",
+//	})
+//
+//	// Moderador assume e reverte a rejeição
+//	_, err = moderationService.AssignAppeal(ctx, moderatorID, appeal.ID)
+//	_, err = moderationService.ResolveAppeal(ctx, moderatorID, moderation.ResolveAppealInput{
+//		AppealID: "1",
+//		Overturn: true,
+//		Note:     "Prova válida",
+//	})
+package moderation
@@ -0,0 +1,422 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/rafaelcoelhox/labbend/internal/challenges"
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+// ===== GRAPHQL TYPES =====
+
+var AppealType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Appeal",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+		},
+		"submissionID": &graphql.Field{
+			Type: graphql.String,
+		},
+		"userID": &graphql.Field{
+			Type: graphql.String,
+		},
+		"justification": &graphql.Field{
+			Type: graphql.String,
+		},
+		"status": &graphql.Field{
+			Type: graphql.String,
+		},
+		"assignedTo": &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if appeal, ok := p.Source.(*Appeal); ok {
+					return optionalID(appeal.AssignedTo), nil
+				}
+				return nil, nil
+			},
+		},
+		"assignedAt": &graphql.Field{
+			Type: graphql.String,
+		},
+		"resolvedBy": &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if appeal, ok := p.Source.(*Appeal); ok {
+					return optionalID(appeal.ResolvedBy), nil
+				}
+				return nil, nil
+			},
+		},
+		"resolutionNote": &graphql.Field{
+			Type: graphql.String,
+		},
+		"resolvedAt": &graphql.Field{
+			Type: graphql.String,
+		},
+		"createdAt": &graphql.Field{
+			Type: graphql.String,
+		},
+	},
+})
+
+var ModerationActionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ModerationAction",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+		},
+		"submissionID": &graphql.Field{
+			Type: graphql.String,
+		},
+		"appealID": &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if action, ok := p.Source.(*ModerationAction); ok {
+					return optionalID(action.AppealID), nil
+				}
+				return nil, nil
+			},
+		},
+		"moderatorID": &graphql.Field{
+			Type: graphql.String,
+		},
+		"action": &graphql.Field{
+			Type: graphql.String,
+		},
+		"reason": &graphql.Field{
+			Type: graphql.String,
+		},
+		"createdAt": &graphql.Field{
+			Type: graphql.String,
+		},
+	},
+})
+
+var ModeratorType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Moderator",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+		},
+		"userID": &graphql.Field{
+			Type: graphql.String,
+		},
+		"createdAt": &graphql.Field{
+			Type: graphql.String,
+		},
+	},
+})
+
+var AppealStatusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "AppealStatus",
+	Values: graphql.EnumValueConfigMap{
+		"PENDING":    &graphql.EnumValueConfig{Value: AppealStatusPending},
+		"ASSIGNED":   &graphql.EnumValueConfig{Value: AppealStatusAssigned},
+		"UPHELD":     &graphql.EnumValueConfig{Value: AppealStatusUpheld},
+		"OVERTURNED": &graphql.EnumValueConfig{Value: AppealStatusOverturned},
+	},
+})
+
+// optionalID - converte IDs opcionais (*uint) para string ou nil
+func optionalID(id *uint) interface{} {
+	if id == nil {
+		return nil
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+// ===== RESOLVER FUNCTIONS =====
+
+func appealResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		id := p.Args["id"].(string)
+		appealID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+
+		logger.Info("Buscando apelação")
+		return service.GetAppeal(p.Context, uint(appealID))
+	}
+}
+
+func appealQueueResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		limit := 10
+		offset := 0
+		if l, ok := p.Args["limit"].(int); ok {
+			limit = l
+		}
+		if o, ok := p.Args["offset"].(int); ok {
+			offset = o
+		}
+
+		status, _ := p.Args["status"].(string)
+
+		var assignedTo *uint
+		if a, ok := p.Args["assignedTo"].(string); ok {
+			moderatorID, err := strconv.ParseUint(a, 10, 32)
+			if err != nil {
+				return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+			}
+			id := uint(moderatorID)
+			assignedTo = &id
+		}
+
+		logger.Info("Listando fila de apelações")
+		return service.ListAppealQueue(p.Context, status, assignedTo, limit, offset)
+	}
+}
+
+func submissionModerationHistoryResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		id := p.Args["submissionID"].(string)
+		submissionID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+
+		logger.Info("Buscando histórico de moderação")
+		return service.GetSubmissionHistory(p.Context, uint(submissionID))
+	}
+}
+
+func fileAppealResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		input := FileAppealInput{
+			SubmissionID:  p.Args["submissionID"].(string),
+			Justification: p.Args["justification"].(string),
+		}
+
+		userID, err := viewerID(p.Context)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Registrando apelação")
+		return service.FileAppeal(p.Context, userID, input)
+	}
+}
+
+func assignAppealResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		id := p.Args["appealID"].(string)
+		appealID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+
+		moderatorID, err := viewerID(p.Context)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Atribuindo apelação")
+		return service.AssignAppeal(p.Context, moderatorID, uint(appealID))
+	}
+}
+
+func resolveAppealResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		input := ResolveAppealInput{
+			AppealID: p.Args["appealID"].(string),
+			Overturn: p.Args["overturn"].(bool),
+			Note:     p.Args["note"].(string),
+		}
+
+		moderatorID, err := viewerID(p.Context)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Resolvendo apelação")
+		return service.ResolveAppeal(p.Context, moderatorID, input)
+	}
+}
+
+func overrideSubmissionResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		input := OverrideSubmissionInput{
+			SubmissionID: p.Args["submissionID"].(string),
+			Approve:      p.Args["approve"].(bool),
+			Reason:       p.Args["reason"].(string),
+		}
+
+		moderatorID, err := viewerID(p.Context)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Sobrescrevendo decisão de submission")
+		return service.OverrideSubmission(p.Context, moderatorID, input)
+	}
+}
+
+func addModeratorResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		id := p.Args["userID"].(string)
+		userID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+
+		logger.Info("Adicionando moderador")
+		return service.AddModerator(p.Context, uint(userID))
+	}
+}
+
+func removeModeratorResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		id := p.Args["userID"].(string)
+		userID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+
+		logger.Info("Removendo moderador")
+		if err := service.RemoveModerator(p.Context, uint(userID)); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// viewerID - usuário autenticado da request; chamadas anônimas são recusadas
+func viewerID(ctx context.Context) (uint, error) {
+	viewer := auth.ViewerFromContext(ctx)
+	if viewer.IsAnonymous() {
+		return 0, errors.Unauthorized("authentication required")
+	}
+	return viewer.UserID, nil
+}
+
+// ===== SCHEMA CONFIGURATION =====
+
+func Queries(moderationService Service, logger logger.Logger) *graphql.Fields {
+	return &graphql.Fields{
+		"appeal": &graphql.Field{
+			Type:        AppealType,
+			Description: "Retorna uma apelação específica por ID",
+			Args: graphql.FieldConfigArgument{
+				"id": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: appealResolver(moderationService, logger),
+		},
+		"appealQueue": &graphql.Field{
+			Type:        graphql.NewList(AppealType),
+			Description: "Retorna a fila de apelações (mais antigas primeiro)",
+			Args: graphql.FieldConfigArgument{
+				"status": &graphql.ArgumentConfig{
+					Type: AppealStatusEnum,
+				},
+				"assignedTo": &graphql.ArgumentConfig{
+					Type: graphql.String,
+				},
+				"limit": &graphql.ArgumentConfig{
+					Type:         graphql.Int,
+					DefaultValue: 10,
+				},
+				"offset": &graphql.ArgumentConfig{
+					Type:         graphql.Int,
+					DefaultValue: 0,
+				},
+			},
+			Resolve: appealQueueResolver(moderationService, logger),
+		},
+		"submissionModerationHistory": &graphql.Field{
+			Type:        graphql.NewList(ModerationActionType),
+			Description: "Retorna as ações de moderação aplicadas a uma submission",
+			Args: graphql.FieldConfigArgument{
+				"submissionID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: submissionModerationHistoryResolver(moderationService, logger),
+		},
+	}
+}
+
+func Mutations(moderationService Service, logger logger.Logger) *graphql.Fields {
+	return &graphql.Fields{
+		"fileAppeal": &graphql.Field{
+			Type:        AppealType,
+			Description: "Registra uma apelação contra a rejeição de uma submission",
+			Args: graphql.FieldConfigArgument{
+				"submissionID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+				"justification": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: fileAppealResolver(moderationService, logger),
+		},
+		"assignAppeal": &graphql.Field{
+			Type:        AppealType,
+			Description: "Atribui uma apelação ao moderador atual",
+			Args: graphql.FieldConfigArgument{
+				"appealID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: assignAppealResolver(moderationService, logger),
+		},
+		"resolveAppeal": &graphql.Field{
+			Type:        AppealType,
+			Description: "Resolve uma apelação mantendo ou revertendo a rejeição",
+			Args: graphql.FieldConfigArgument{
+				"appealID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+				"overturn": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.Boolean),
+				},
+				"note": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: resolveAppealResolver(moderationService, logger),
+		},
+		"overrideSubmission": &graphql.Field{
+			Type:        challenges.ChallengeSubmissionType,
+			Description: "Sobrescreve o resultado de uma submission (apenas moderadores)",
+			Args: graphql.FieldConfigArgument{
+				"submissionID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+				"approve": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.Boolean),
+				},
+				"reason": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: overrideSubmissionResolver(moderationService, logger),
+		},
+		"addModerator": &graphql.Field{
+			Type:        ModeratorType,
+			Description: "Concede o papel de moderador a um usuário",
+			Args: graphql.FieldConfigArgument{
+				"userID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: addModeratorResolver(moderationService, logger),
+		},
+		"removeModerator": &graphql.Field{
+			Type:        graphql.Boolean,
+			Description: "Remove o papel de moderador de um usuário",
+			Args: graphql.FieldConfigArgument{
+				"userID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: removeModeratorResolver(moderationService, logger),
+		},
+	}
+}
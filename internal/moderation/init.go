@@ -0,0 +1,14 @@
+package moderation
+
+import "github.com/rafaelcoelhox/labbend/pkg/module"
+
+// init - registra automaticamente o módulo moderation
+func init() {
+	module.Register(module.Definition{
+		Name:       ModuleName,
+		DependsOn:  []string{"challenges"},
+		New:        NewModule,
+		Migrations: migrations,
+		Models:     models,
+	})
+}
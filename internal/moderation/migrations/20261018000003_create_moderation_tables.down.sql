@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "moderation_actions";
+DROP TABLE IF EXISTS "moderation_appeals";
+DROP TABLE IF EXISTS "moderators";
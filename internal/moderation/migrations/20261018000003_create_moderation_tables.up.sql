@@ -0,0 +1,44 @@
+-- Schema inicial do módulo moderation (equivalente ao AutoMigrate anterior)
+CREATE TABLE IF NOT EXISTS "moderators" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_moderators_user_id" ON "moderators" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "moderation_appeals" (
+    "id" bigserial,
+    "submission_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "justification" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'pending',
+    "assigned_to" bigint,
+    "assigned_at" timestamptz,
+    "resolved_by" bigint,
+    "resolution_note" text,
+    "resolved_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_moderation_appeals_created_at" ON "moderation_appeals" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_moderation_appeals_assigned_to" ON "moderation_appeals" ("assigned_to");
+CREATE INDEX IF NOT EXISTS "idx_moderation_appeals_status" ON "moderation_appeals" ("status");
+CREATE INDEX IF NOT EXISTS "idx_moderation_appeals_user_id" ON "moderation_appeals" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_moderation_appeals_submission_id" ON "moderation_appeals" ("submission_id");
+
+CREATE TABLE IF NOT EXISTS "moderation_actions" (
+    "id" bigserial,
+    "submission_id" bigint NOT NULL,
+    "appeal_id" bigint,
+    "moderator_id" bigint NOT NULL,
+    "action" text NOT NULL,
+    "reason" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_moderation_actions_submission_id" ON "moderation_actions" ("submission_id");
+CREATE INDEX IF NOT EXISTS "idx_moderation_actions_created_at" ON "moderation_actions" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_moderation_actions_moderator_id" ON "moderation_actions" ("moderator_id");
+CREATE INDEX IF NOT EXISTS "idx_moderation_actions_appeal_id" ON "moderation_actions" ("appeal_id");
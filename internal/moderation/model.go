@@ -0,0 +1,139 @@
+package moderation
+
+import (
+	"errors"
+	"time"
+)
+
+// Moderator - usuário com permissão para moderar submissions e apelações
+type Moderator struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Appeal - apelação de um usuário contra a rejeição de sua submission
+type Appeal struct {
+	ID             uint       `json:"id" gorm:"primarykey"`
+	SubmissionID   uint       `json:"submission_id" gorm:"not null;uniqueIndex"`
+	UserID         uint       `json:"user_id" gorm:"not null;index"`
+	Justification  string     `json:"justification" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"not null;default:'pending';index"`
+	AssignedTo     *uint      `json:"assigned_to" gorm:"index"`
+	AssignedAt     *time.Time `json:"assigned_at"`
+	ResolvedBy     *uint      `json:"resolved_by"`
+	ResolutionNote string     `json:"resolution_note" gorm:"type:text"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"index"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ModerationAction - trilha de auditoria das decisões de moderação
+type ModerationAction struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	SubmissionID uint      `json:"submission_id" gorm:"not null;index"`
+	AppealID     *uint     `json:"appeal_id" gorm:"index"`
+	ModeratorID  uint      `json:"moderator_id" gorm:"not null;index"`
+	Action       string    `json:"action" gorm:"not null"`
+	Reason       string    `json:"reason" gorm:"type:text"`
+	CreatedAt    time.Time `json:"created_at" gorm:"index"`
+}
+
+const (
+	AppealStatusPending    = "pending"
+	AppealStatusAssigned   = "assigned"
+	AppealStatusUpheld     = "upheld"
+	AppealStatusOverturned = "overturned"
+
+	ActionOverrideApprove  = "override_approve"
+	ActionOverrideReject   = "override_reject"
+	ActionOverrideRevoke   = "override_revoke"
+	ActionAppealUpheld     = "appeal_upheld"
+	ActionAppealOverturned = "appeal_overturned"
+)
+
+type FileAppealInput struct {
+	SubmissionID  string `json:"submissionID" validate:"required,numeric"`
+	Justification string `json:"justification" validate:"required,notblank,min=20"`
+}
+
+type ResolveAppealInput struct {
+	AppealID string `json:"appealID" validate:"required,numeric"`
+	Overturn bool   `json:"overturn"`
+	Note     string `json:"note" validate:"required,notblank"`
+}
+
+type OverrideSubmissionInput struct {
+	SubmissionID string `json:"submissionID" validate:"required,numeric"`
+	Approve      bool   `json:"approve"`
+	Reason       string `json:"reason" validate:"required,notblank"`
+}
+
+func (Moderator) TableName() string {
+	return "moderators"
+}
+
+func (Appeal) TableName() string {
+	return "moderation_appeals"
+}
+
+func (ModerationAction) TableName() string {
+	return "moderation_actions"
+}
+
+func (a *Appeal) IsOpen() bool {
+	return a.Status == AppealStatusPending || a.Status == AppealStatusAssigned
+}
+
+func (a *Appeal) IsResolved() bool {
+	return a.Status == AppealStatusUpheld || a.Status == AppealStatusOverturned
+}
+
+// Assign - atribui a apelação a um moderador
+func (a *Appeal) Assign(moderatorID uint) error {
+	if !a.IsOpen() {
+		return ErrAppealResolved
+	}
+	now := time.Now()
+	a.AssignedTo = &moderatorID
+	a.AssignedAt = &now
+	a.Status = AppealStatusAssigned
+	return nil
+}
+
+// Resolve - encerra a apelação mantendo (upheld) ou revertendo (overturned) a rejeição
+func (a *Appeal) Resolve(moderatorID uint, overturn bool, note string) error {
+	if !a.IsOpen() {
+		return ErrAppealResolved
+	}
+	if a.AssignedTo != nil && *a.AssignedTo != moderatorID {
+		return ErrNotAssignee
+	}
+	now := time.Now()
+	if a.AssignedTo == nil {
+		a.AssignedTo = &moderatorID
+		a.AssignedAt = &now
+	}
+	a.ResolvedBy = &moderatorID
+	a.ResolvedAt = &now
+	a.ResolutionNote = note
+	a.Status = AppealStatusUpheld
+	if overturn {
+		a.Status = AppealStatusOverturned
+	}
+	return nil
+}
+
+func IsValidAppealStatus(status string) bool {
+	switch status {
+	case AppealStatusPending, AppealStatusAssigned, AppealStatusUpheld, AppealStatusOverturned:
+		return true
+	}
+	return false
+}
+
+var (
+	ErrAppealResolved       = errors.New("appeal is already resolved")
+	ErrNotAssignee          = errors.New("appeal is assigned to another moderator")
+	ErrInvalidJustification = errors.New("justification must have at least 20 characters")
+)
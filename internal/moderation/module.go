@@ -0,0 +1,53 @@
+package moderation
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/module"
+)
+
+// ModuleName - nome do módulo no registry (usado em DependsOn)
+const ModuleName = "moderation"
+
+// Module - registro do módulo moderation na aplicação
+type Module struct {
+	module.Base
+	service Service
+	logger  logger.Logger
+}
+
+// NewModule - factory registrada no init.go; depende do módulo challenges
+func NewModule(deps *module.Deps) (module.Module, error) {
+	challengeService, ok := deps.Service("challenges").(ChallengeService)
+	if !ok {
+		return nil, fmt.Errorf("challenges service does not implement moderation.ChallengeService")
+	}
+
+	repo := NewRepository(deps.DB)
+	return &Module{
+		service: NewService(repo, challengeService, deps.Logger, deps.EventBus, deps.TxManager),
+		logger:  deps.Logger,
+	}, nil
+}
+
+func (m *Module) Name() string         { return ModuleName }
+func (m *Module) Service() interface{} { return m.service }
+
+func (m *Module) Queries() graphql.Fields {
+	return *Queries(m.service, m.logger)
+}
+
+func (m *Module) Mutations() graphql.Fields {
+	return *Mutations(m.service, m.logger)
+}
+
+// models - modelos GORM do módulo; registrados em database.RegisterModel
+// para o AutoMigrate do SQLite (desenvolvimento local e testes)
+var models = []interface{}{&Moderator{}, &Appeal{}, &ModerationAction{}}
+
+func (m *Module) Models() []interface{} {
+	return models
+}
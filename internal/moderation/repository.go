@@ -0,0 +1,185 @@
+package moderation
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+)
+
+type Repository interface {
+	AddModerator(ctx context.Context, moderator *Moderator) error
+	RemoveModerator(ctx context.Context, userID uint) error
+	IsModerator(ctx context.Context, userID uint) (bool, error)
+
+	CreateAppeal(ctx context.Context, appeal *Appeal) error
+	GetAppealByID(ctx context.Context, id uint) (*Appeal, error)
+	GetAppealBySubmissionID(ctx context.Context, submissionID uint) (*Appeal, error)
+	UpdateAppeal(ctx context.Context, appeal *Appeal) error
+	ListAppeals(ctx context.Context, status string, assignedTo *uint, limit, offset int) ([]*Appeal, error)
+
+	CreateAction(ctx context.Context, action *ModerationAction) error
+	ListActionsBySubmissionID(ctx context.Context, submissionID uint) ([]*ModerationAction, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// === MODERATOR OPERATIONS ===
+
+func (r *repository) AddModerator(ctx context.Context, moderator *Moderator) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := database.FromContext(ctx, r.db).Create(moderator).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.AlreadyExists("moderator", "user", moderator.UserID)
+		}
+		return errors.Internal(err)
+	}
+	return nil
+}
+
+func (r *repository) RemoveModerator(ctx context.Context, userID uint) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := database.FromContext(ctx, r.db).Where("user_id = ?", userID).Delete(&Moderator{})
+	if result.Error != nil {
+		return errors.Internal(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NotFound("moderator", userID)
+	}
+	return nil
+}
+
+func (r *repository) IsModerator(ctx context.Context, userID uint) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var count int64
+	err := database.FromContext(ctx, r.db).
+		Model(&Moderator{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error
+	if err != nil {
+		return false, errors.Internal(err)
+	}
+	return count > 0, nil
+}
+
+// === APPEAL OPERATIONS ===
+
+func (r *repository) CreateAppeal(ctx context.Context, appeal *Appeal) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := database.FromContext(ctx, r.db).Create(appeal).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.AlreadyExists("appeal", "submission", appeal.SubmissionID)
+		}
+		return errors.Internal(err)
+	}
+	return nil
+}
+
+func (r *repository) GetAppealByID(ctx context.Context, id uint) (*Appeal, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var appeal Appeal
+	err := database.FromContext(ctx, r.db).First(&appeal, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NotFound("appeal", id)
+		}
+		return nil, errors.Internal(err)
+	}
+	return &appeal, nil
+}
+
+func (r *repository) GetAppealBySubmissionID(ctx context.Context, submissionID uint) (*Appeal, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var appeal Appeal
+	err := database.FromContext(ctx, r.db).Where("submission_id = ?", submissionID).First(&appeal).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NotFound("appeal", submissionID)
+		}
+		return nil, errors.Internal(err)
+	}
+	return &appeal, nil
+}
+
+func (r *repository) UpdateAppeal(ctx context.Context, appeal *Appeal) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := database.FromContext(ctx, r.db).Save(appeal).Error; err != nil {
+		return errors.Internal(err)
+	}
+	return nil
+}
+
+// ListAppeals - fila de apelações em ordem de chegada (mais antigas primeiro)
+func (r *repository) ListAppeals(ctx context.Context, status string, assignedTo *uint, limit, offset int) ([]*Appeal, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := database.FromContext(ctx, r.db)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if assignedTo != nil {
+		query = query.Where("assigned_to = ?", *assignedTo)
+	}
+
+	var appeals []*Appeal
+	err := query.
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&appeals).Error
+	if err != nil {
+		return nil, errors.Internal(err)
+	}
+	return appeals, nil
+}
+
+// === ACTION OPERATIONS ===
+
+func (r *repository) CreateAction(ctx context.Context, action *ModerationAction) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := database.FromContext(ctx, r.db).Create(action).Error; err != nil {
+		return errors.Internal(err)
+	}
+	return nil
+}
+
+func (r *repository) ListActionsBySubmissionID(ctx context.Context, submissionID uint) ([]*ModerationAction, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var actions []*ModerationAction
+	err := database.FromContext(ctx, r.db).
+		Where("submission_id = ?", submissionID).
+		Order("created_at DESC").
+		Find(&actions).Error
+	if err != nil {
+		return nil, errors.Internal(err)
+	}
+	return actions, nil
+}
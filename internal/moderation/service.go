@@ -0,0 +1,415 @@
+package moderation
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/rafaelcoelhox/labbend/internal/challenges"
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/validation"
+)
+
+// EventBus - interface para comunicação entre módulos
+type EventBus interface {
+	Publish(event eventbus.Event)
+}
+
+// ChallengeService - interface para comunicação com módulo de challenges
+type ChallengeService interface {
+	GetSubmission(ctx context.Context, id uint) (*challenges.ChallengeSubmission, error)
+	GetSubmissionAttempts(ctx context.Context, userID, challengeID uint) ([]*challenges.SubmissionAttempt, error)
+	ApproveSubmission(ctx context.Context, submissionID uint, reason string) (*challenges.ChallengeSubmission, error)
+	RejectSubmission(ctx context.Context, submissionID uint, reason string) (*challenges.ChallengeSubmission, error)
+	RevokeSubmission(ctx context.Context, adminID uint, input challenges.RevokeSubmissionInput) (*challenges.ChallengeSubmission, error)
+}
+
+// Service - interface de negócio
+type Service interface {
+	// Moderator management
+	AddModerator(ctx context.Context, userID uint) (*Moderator, error)
+	RemoveModerator(ctx context.Context, userID uint) error
+	IsModerator(ctx context.Context, userID uint) (bool, error)
+
+	// Appeals
+	FileAppeal(ctx context.Context, userID uint, input FileAppealInput) (*Appeal, error)
+	GetAppeal(ctx context.Context, id uint) (*Appeal, error)
+	ListAppealQueue(ctx context.Context, status string, assignedTo *uint, limit, offset int) ([]*Appeal, error)
+	AssignAppeal(ctx context.Context, moderatorID, appealID uint) (*Appeal, error)
+	ResolveAppeal(ctx context.Context, moderatorID uint, input ResolveAppealInput) (*Appeal, error)
+
+	// Overrides
+	OverrideSubmission(ctx context.Context, moderatorID uint, input OverrideSubmissionInput) (*challenges.ChallengeSubmission, error)
+	GetSubmissionHistory(ctx context.Context, submissionID uint) ([]*ModerationAction, error)
+}
+
+type service struct {
+	repo             Repository
+	challengeService ChallengeService
+	logger           logger.Logger
+	eventBus         EventBus
+	txManager        *database.TxManager
+}
+
+func NewService(repo Repository, challengeService ChallengeService, logger logger.Logger, eventBus EventBus, txManager *database.TxManager) Service {
+	return &service{
+		repo:             repo,
+		challengeService: challengeService,
+		logger:           logger,
+		eventBus:         eventBus,
+		txManager:        txManager,
+	}
+}
+
+// === MODERATOR MANAGEMENT ===
+
+func (s *service) AddModerator(ctx context.Context, userID uint) (*Moderator, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	moderator := &Moderator{UserID: userID}
+	if err := s.repo.AddModerator(ctx, moderator); err != nil {
+		s.logger.Error("failed to add moderator", zap.Error(err), zap.Uint("user_id", userID))
+		return nil, err
+	}
+
+	s.logger.Info("moderator added", zap.Uint("user_id", userID))
+	return moderator, nil
+}
+
+func (s *service) RemoveModerator(ctx context.Context, userID uint) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveModerator(ctx, userID); err != nil {
+		return err
+	}
+
+	s.logger.Info("moderator removed", zap.Uint("user_id", userID))
+	return nil
+}
+
+func (s *service) IsModerator(ctx context.Context, userID uint) (bool, error) {
+	return s.repo.IsModerator(ctx, userID)
+}
+
+// === APPEALS ===
+
+func (s *service) FileAppeal(ctx context.Context, userID uint, input FileAppealInput) (*Appeal, error) {
+	if err := validation.Struct(input); err != nil {
+		return nil, err
+	}
+	submissionID, err := strconv.ParseUint(input.SubmissionID, 10, 32)
+	if err != nil {
+		return nil, errors.InvalidInput("invalid submission ID")
+	}
+
+	justification := strings.TrimSpace(input.Justification)
+	if len(justification) < 20 {
+		return nil, errors.InvalidInput(ErrInvalidJustification.Error())
+	}
+
+	s.logger.Info("filing appeal",
+		zap.Uint("user_id", userID),
+		zap.Uint("submission_id", uint(submissionID)))
+
+	submission, err := s.challengeService.GetSubmission(ctx, uint(submissionID))
+	if err != nil {
+		return nil, err
+	}
+	if submission.UserID != userID {
+		return nil, errors.InvalidInput("only the submission owner can appeal")
+	}
+	if !submission.IsRejected() {
+		return nil, errors.InvalidInput("only rejected submissions can be appealed")
+	}
+
+	// Uma tentativa substituída por outra mais nova não pode mais ser apelada
+	attempts, err := s.challengeService.GetSubmissionAttempts(ctx, userID, submission.ChallengeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, attempt := range attempts {
+		if attempt.Submission.AttemptNumber > submission.AttemptNumber {
+			return nil, errors.InvalidInput("submission was replaced by a newer attempt")
+		}
+	}
+
+	// Apenas uma apelação por submission rejeitada
+	_, err = s.repo.GetAppealBySubmissionID(ctx, submission.ID)
+	if err == nil {
+		return nil, errors.AlreadyExists("appeal", "submission", submission.ID)
+	}
+	if !errors.Is(err, errors.ErrNotFound) {
+		return nil, err
+	}
+
+	appeal := &Appeal{
+		SubmissionID:  submission.ID,
+		UserID:        userID,
+		Justification: justification,
+		Status:        AppealStatusPending,
+	}
+
+	if err := s.repo.CreateAppeal(ctx, appeal); err != nil {
+		s.logger.Error("failed to create appeal", zap.Error(err))
+		return nil, err
+	}
+
+	s.txManager.OnCommit(ctx, func(context.Context) {
+		s.eventBus.Publish(eventbus.Event{
+			Type:   "AppealFiled",
+			Source: "moderation",
+			Data: map[string]interface{}{
+				"appealID":     appeal.ID,
+				"submissionID": appeal.SubmissionID,
+				"userID":       userID,
+			},
+		})
+	})
+
+	s.logger.Info("appeal filed successfully", zap.Uint("appeal_id", appeal.ID))
+	return appeal, nil
+}
+
+func (s *service) GetAppeal(ctx context.Context, id uint) (*Appeal, error) {
+	return s.repo.GetAppealByID(ctx, id)
+}
+
+func (s *service) ListAppealQueue(ctx context.Context, status string, assignedTo *uint, limit, offset int) ([]*Appeal, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if status != "" && !IsValidAppealStatus(status) {
+		return nil, errors.InvalidInput("invalid appeal status")
+	}
+
+	return s.repo.ListAppeals(ctx, status, assignedTo, limit, offset)
+}
+
+func (s *service) AssignAppeal(ctx context.Context, moderatorID, appealID uint) (*Appeal, error) {
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+
+	appeal, err := s.repo.GetAppealByID(ctx, appealID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := appeal.Assign(moderatorID); err != nil {
+		return nil, errors.InvalidInput(err.Error())
+	}
+
+	if err := s.repo.UpdateAppeal(ctx, appeal); err != nil {
+		s.logger.Error("failed to assign appeal", zap.Error(err))
+		return nil, err
+	}
+
+	s.txManager.OnCommit(ctx, func(context.Context) {
+		s.eventBus.Publish(eventbus.Event{
+			Type:   "AppealAssigned",
+			Source: "moderation",
+			Data: map[string]interface{}{
+				"appealID":    appeal.ID,
+				"moderatorID": moderatorID,
+			},
+		})
+	})
+
+	s.logger.Info("appeal assigned",
+		zap.Uint("appeal_id", appeal.ID),
+		zap.Uint("moderator_id", moderatorID))
+	return appeal, nil
+}
+
+func (s *service) ResolveAppeal(ctx context.Context, moderatorID uint, input ResolveAppealInput) (*Appeal, error) {
+	if err := validation.Struct(input); err != nil {
+		return nil, err
+	}
+	appealID, err := strconv.ParseUint(input.AppealID, 10, 32)
+	if err != nil {
+		return nil, errors.InvalidInput("invalid appeal ID")
+	}
+	note := strings.TrimSpace(input.Note)
+
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("resolving appeal",
+		zap.Uint("appeal_id", uint(appealID)),
+		zap.Uint("moderator_id", moderatorID),
+		zap.Bool("overturn", input.Overturn))
+
+	var appeal *Appeal
+	err = s.txManager.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		appeal, err = s.repo.GetAppealByID(ctx, uint(appealID))
+		if err != nil {
+			return err
+		}
+
+		if err := appeal.Resolve(moderatorID, input.Overturn, note); err != nil {
+			return errors.InvalidInput(err.Error())
+		}
+		if err := s.repo.UpdateAppeal(ctx, appeal); err != nil {
+			return err
+		}
+
+		action := ActionAppealUpheld
+		if input.Overturn {
+			// Reverter a rejeição concede o XP pelo mesmo caminho da aprovação por votos
+			action = ActionAppealOverturned
+			if _, err := s.challengeService.ApproveSubmission(ctx, appeal.SubmissionID, note); err != nil {
+				return err
+			}
+		}
+
+		if err := s.repo.CreateAction(ctx, &ModerationAction{
+			SubmissionID: appeal.SubmissionID,
+			AppealID:     &appeal.ID,
+			ModeratorID:  moderatorID,
+			Action:       action,
+			Reason:       note,
+		}); err != nil {
+			return err
+		}
+
+		resolved := *appeal
+		s.txManager.OnCommit(ctx, func(context.Context) {
+			s.eventBus.Publish(eventbus.Event{
+				Type:   "AppealResolved",
+				Source: "moderation",
+				Data: map[string]interface{}{
+					"appealID":     resolved.ID,
+					"submissionID": resolved.SubmissionID,
+					"userID":       resolved.UserID,
+					"moderatorID":  moderatorID,
+					"status":       resolved.Status,
+				},
+			})
+		})
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to resolve appeal", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("appeal resolved",
+		zap.Uint("appeal_id", appeal.ID),
+		zap.String("status", appeal.Status))
+	return appeal, nil
+}
+
+// === OVERRIDES ===
+
+func (s *service) OverrideSubmission(ctx context.Context, moderatorID uint, input OverrideSubmissionInput) (*challenges.ChallengeSubmission, error) {
+	if err := validation.Struct(input); err != nil {
+		return nil, err
+	}
+	submissionID, err := strconv.ParseUint(input.SubmissionID, 10, 32)
+	if err != nil {
+		return nil, errors.InvalidInput("invalid submission ID")
+	}
+	reason := strings.TrimSpace(input.Reason)
+
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("overriding submission outcome",
+		zap.Uint("submission_id", uint(submissionID)),
+		zap.Uint("moderator_id", moderatorID),
+		zap.Bool("approve", input.Approve))
+
+	var submission *challenges.ChallengeSubmission
+	err = s.txManager.InTransaction(ctx, func(ctx context.Context) error {
+		action, overridden, err := s.applyOverride(ctx, moderatorID, uint(submissionID), input.Approve, reason)
+		if err != nil {
+			return err
+		}
+		submission = overridden
+
+		return s.repo.CreateAction(ctx, &ModerationAction{
+			SubmissionID: submission.ID,
+			ModeratorID:  moderatorID,
+			Action:       action,
+			Reason:       reason,
+		})
+	})
+	if err != nil {
+		s.logger.Error("failed to override submission", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("submission outcome overridden",
+		zap.Uint("submission_id", submission.ID),
+		zap.String("status", submission.Status))
+	return submission, nil
+}
+
+func (s *service) GetSubmissionHistory(ctx context.Context, submissionID uint) ([]*ModerationAction, error) {
+	return s.repo.ListActionsBySubmissionID(ctx, submissionID)
+}
+
+// === PRIVATE HELPERS ===
+
+// applyOverride - aprova ou rejeita a submission. Rejeitar uma submission já
+// aprovada passa pela saga de revogação do challenges, que remove o XP
+// concedido; a submission fica com status revoked.
+func (s *service) applyOverride(ctx context.Context, moderatorID, submissionID uint, approve bool, reason string) (string, *challenges.ChallengeSubmission, error) {
+	if approve {
+		submission, err := s.challengeService.ApproveSubmission(ctx, submissionID, reason)
+		return ActionOverrideApprove, submission, err
+	}
+
+	current, err := s.challengeService.GetSubmission(ctx, submissionID)
+	if err != nil {
+		return "", nil, err
+	}
+	if current.IsApproved() {
+		submission, err := s.challengeService.RevokeSubmission(ctx, moderatorID, challenges.RevokeSubmissionInput{
+			SubmissionID: strconv.FormatUint(uint64(submissionID), 10),
+			Reason:       reason,
+		})
+		return ActionOverrideRevoke, submission, err
+	}
+
+	submission, err := s.challengeService.RejectSubmission(ctx, submissionID, reason)
+	return ActionOverrideReject, submission, err
+}
+
+// requireAdmin - gestão de moderadores é restrita a admins
+func requireAdmin(ctx context.Context) error {
+	if !auth.ViewerFromContext(ctx).Admin {
+		return errors.Unauthorized("admin role required")
+	}
+	return nil
+}
+
+func (s *service) requireModerator(ctx context.Context, userID uint) error {
+	isModerator, err := s.repo.IsModerator(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !isModerator {
+		return errors.Unauthorized("moderator role required")
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package moderation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/graphql-go/graphql"
+	"github.com/rafaelcoelhox/labbend/internal/challenges"
+	"github.com/rafaelcoelhox/labbend/internal/mocks"
+	"github.com/rafaelcoelhox/labbend/internal/moderation"
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/database/databasetest"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	"github.com/stretchr/testify/assert"
+)
+
+const justification = "O vídeo mostra claramente o projeto funcionando"
+
+func TestModerationService_FileAppeal_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockModerationRepository(ctrl)
+	mockChallengeService := mocks.NewMockModerationChallengeService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockModerationEventBus(ctrl)
+
+	service := moderation.NewService(mockRepo, mockChallengeService, mockLogger, mockEventBus, database.NewTxManager(nil))
+
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockChallengeService.EXPECT().
+		GetSubmission(gomock.Any(), uint(7)).
+		Return(&challenges.ChallengeSubmission{ID: 7, ChallengeID: 4, UserID: 1, AttemptNumber: 1, Status: challenges.SubmissionStatusRejected}, nil).
+		Times(1)
+
+	mockChallengeService.EXPECT().
+		GetSubmissionAttempts(gomock.Any(), uint(1), uint(4)).
+		Return([]*challenges.SubmissionAttempt{
+			{Submission: &challenges.ChallengeSubmission{ID: 7, ChallengeID: 4, UserID: 1, AttemptNumber: 1}},
+		}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetAppealBySubmissionID(gomock.Any(), uint(7)).
+		Return(nil, errors.NotFound("appeal", 7)).
+		Times(1)
+
+	mockRepo.EXPECT().
+		CreateAppeal(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, appeal *moderation.Appeal) error {
+			appeal.ID = 1
+			return nil
+		}).
+		Times(1)
+
+	mockEventBus.EXPECT().Publish(gomock.Any()).Times(1)
+
+	appeal, err := service.FileAppeal(context.Background(), 1, moderation.FileAppealInput{
+		SubmissionID:  "7",
+		Justification: justification,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), appeal.SubmissionID)
+	assert.Equal(t, moderation.AppealStatusPending, appeal.Status)
+}
+
+func TestModerationService_FileAppeal_Rules_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockModerationRepository(ctrl)
+	mockChallengeService := mocks.NewMockModerationChallengeService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockModerationEventBus(ctrl)
+
+	service := moderation.NewService(mockRepo, mockChallengeService, mockLogger, mockEventBus, database.NewTxManager(nil))
+
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	// Submission ainda pendente não pode receber apelação
+	mockChallengeService.EXPECT().
+		GetSubmission(gomock.Any(), uint(8)).
+		Return(&challenges.ChallengeSubmission{ID: 8, UserID: 1, Status: challenges.SubmissionStatusPending}, nil)
+
+	_, err := service.FileAppeal(context.Background(), 1, moderation.FileAppealInput{SubmissionID: "8", Justification: justification})
+	assert.True(t, errors.Is(err, errors.ErrInvalidInput))
+
+	// Apenas uma apelação por submission
+	mockChallengeService.EXPECT().
+		GetSubmission(gomock.Any(), uint(9)).
+		Return(&challenges.ChallengeSubmission{ID: 9, ChallengeID: 4, UserID: 1, AttemptNumber: 1, Status: challenges.SubmissionStatusRejected}, nil)
+	mockChallengeService.EXPECT().
+		GetSubmissionAttempts(gomock.Any(), uint(1), uint(4)).
+		Return(nil, nil)
+	mockRepo.EXPECT().
+		GetAppealBySubmissionID(gomock.Any(), uint(9)).
+		Return(&moderation.Appeal{ID: 3, SubmissionID: 9}, nil)
+
+	_, err = service.FileAppeal(context.Background(), 1, moderation.FileAppealInput{SubmissionID: "9", Justification: justification})
+	assert.True(t, errors.Is(err, errors.ErrAlreadyExists))
+
+	// Tentativa substituída por outra mais nova não pode ser apelada
+	mockChallengeService.EXPECT().
+		GetSubmission(gomock.Any(), uint(10)).
+		Return(&challenges.ChallengeSubmission{ID: 10, ChallengeID: 4, UserID: 1, AttemptNumber: 1, Status: challenges.SubmissionStatusRejected}, nil)
+	mockChallengeService.EXPECT().
+		GetSubmissionAttempts(gomock.Any(), uint(1), uint(4)).
+		Return([]*challenges.SubmissionAttempt{
+			{Submission: &challenges.ChallengeSubmission{ID: 10, ChallengeID: 4, UserID: 1, AttemptNumber: 1}},
+			{Submission: &challenges.ChallengeSubmission{ID: 11, ChallengeID: 4, UserID: 1, AttemptNumber: 2}},
+		}, nil)
+
+	_, err = service.FileAppeal(context.Background(), 1, moderation.FileAppealInput{SubmissionID: "10", Justification: justification})
+	assert.True(t, errors.Is(err, errors.ErrInvalidInput))
+
+	// Justificativa curta é rejeitada antes de consultar o banco
+	_, err = service.FileAppeal(context.Background(), 1, moderation.FileAppealInput{SubmissionID: "9", Justification: "injusto"})
+	assert.True(t, errors.Is(err, errors.ErrInvalidInput))
+}
+
+func TestModerationService_AssignAppeal_RequiresModerator_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockModerationRepository(ctrl)
+	mockChallengeService := mocks.NewMockModerationChallengeService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockModerationEventBus(ctrl)
+
+	service := moderation.NewService(mockRepo, mockChallengeService, mockLogger, mockEventBus, database.NewTxManager(nil))
+
+	mockRepo.EXPECT().IsModerator(gomock.Any(), uint(2)).Return(false, nil)
+
+	_, err := service.AssignAppeal(context.Background(), 2, 1)
+	assert.True(t, errors.Is(err, errors.ErrUnauthorized))
+}
+
+func TestModerationService_ManageModerators_RequiresAdmin_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockModerationRepository(ctrl)
+	mockChallengeService := mocks.NewMockModerationChallengeService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockModerationEventBus(ctrl)
+
+	service := moderation.NewService(mockRepo, mockChallengeService, mockLogger, mockEventBus, database.NewTxManager(nil))
+
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	t.Run("should reject non-admin callers", func(t *testing.T) {
+		for _, ctx := range []context.Context{
+			context.Background(),
+			auth.WithViewer(context.Background(), auth.Viewer{UserID: 2}),
+		} {
+			_, err := service.AddModerator(ctx, 3)
+			assert.True(t, errors.Is(err, errors.ErrUnauthorized))
+
+			err = service.RemoveModerator(ctx, 3)
+			assert.True(t, errors.Is(err, errors.ErrUnauthorized))
+		}
+	})
+
+	t.Run("should allow admins", func(t *testing.T) {
+		ctx := auth.WithViewer(context.Background(), auth.Viewer{UserID: 1, Admin: true})
+
+		mockRepo.EXPECT().AddModerator(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockRepo.EXPECT().RemoveModerator(gomock.Any(), uint(3)).Return(nil).Times(1)
+
+		moderator, err := service.AddModerator(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(3), moderator.UserID)
+		assert.NoError(t, service.RemoveModerator(ctx, 3))
+	})
+}
+
+func TestModerationMutations_RequireAuthentication_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockModerationService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	fields := *moderation.Mutations(mockService, mockLogger)
+	args := map[string]map[string]interface{}{
+		"fileAppeal":         {"submissionID": "7", "justification": justification},
+		"assignAppeal":       {"appealID": "1"},
+		"resolveAppeal":      {"appealID": "1", "overturn": true, "note": "ok"},
+		"overrideSubmission": {"submissionID": "7", "approve": true, "reason": "ok"},
+	}
+
+	t.Run("should reject anonymous calls", func(t *testing.T) {
+		for name, fieldArgs := range args {
+			_, err := fields[name].Resolve(graphql.ResolveParams{Context: context.Background(), Args: fieldArgs})
+			assert.True(t, errors.Is(err, errors.ErrUnauthorized), name)
+		}
+	})
+
+	t.Run("should use the authenticated user as moderator", func(t *testing.T) {
+		ctx := auth.WithViewer(context.Background(), auth.Viewer{UserID: 5})
+
+		mockService.EXPECT().AssignAppeal(gomock.Any(), uint(5), uint(1)).Return(&moderation.Appeal{ID: 1}, nil).Times(1)
+
+		_, err := fields["assignAppeal"].Resolve(graphql.ResolveParams{Context: ctx, Args: args["assignAppeal"]})
+		assert.NoError(t, err)
+	})
+}
+
+func TestModerationService_OverrideSubmission_Reject_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockModerationRepository(ctrl)
+	mockChallengeService := mocks.NewMockModerationChallengeService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockModerationEventBus(ctrl)
+
+	service := moderation.NewService(mockRepo, mockChallengeService, mockLogger, mockEventBus, database.NewTxManager(databasetest.New(t)))
+
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockRepo.EXPECT().IsModerator(gomock.Any(), uint(2)).Return(true, nil).AnyTimes()
+
+	input := moderation.OverrideSubmissionInput{SubmissionID: "7", Approve: false, Reason: "vídeo de outro projeto"}
+
+	t.Run("should revoke an approved submission", func(t *testing.T) {
+		mockChallengeService.EXPECT().
+			GetSubmission(gomock.Any(), uint(7)).
+			Return(&challenges.ChallengeSubmission{ID: 7, Status: challenges.SubmissionStatusApproved}, nil)
+		mockChallengeService.EXPECT().
+			RevokeSubmission(gomock.Any(), uint(2), challenges.RevokeSubmissionInput{SubmissionID: "7", Reason: input.Reason}).
+			Return(&challenges.ChallengeSubmission{ID: 7, Status: challenges.SubmissionStatusRevoked}, nil)
+		mockRepo.EXPECT().
+			CreateAction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, action *moderation.ModerationAction) error {
+				assert.Equal(t, moderation.ActionOverrideRevoke, action.Action)
+				return nil
+			})
+
+		submission, err := service.OverrideSubmission(context.Background(), 2, input)
+		assert.NoError(t, err)
+		assert.Equal(t, challenges.SubmissionStatusRevoked, submission.Status)
+	})
+
+	t.Run("should reject a pending submission", func(t *testing.T) {
+		mockChallengeService.EXPECT().
+			GetSubmission(gomock.Any(), uint(7)).
+			Return(&challenges.ChallengeSubmission{ID: 7, Status: challenges.SubmissionStatusPending}, nil)
+		mockChallengeService.EXPECT().
+			RejectSubmission(gomock.Any(), uint(7), input.Reason).
+			Return(&challenges.ChallengeSubmission{ID: 7, Status: challenges.SubmissionStatusRejected}, nil)
+		mockRepo.EXPECT().
+			CreateAction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, action *moderation.ModerationAction) error {
+				assert.Equal(t, moderation.ActionOverrideReject, action.Action)
+				return nil
+			})
+
+		submission, err := service.OverrideSubmission(context.Background(), 2, input)
+		assert.NoError(t, err)
+		assert.Equal(t, challenges.SubmissionStatusRejected, submission.Status)
+	})
+}
+
+func TestAppeal_Lifecycle(t *testing.T) {
+	appeal := &moderation.Appeal{Status: moderation.AppealStatusPending}
+
+	assert.NoError(t, appeal.Assign(5))
+	assert.Equal(t, moderation.AppealStatusAssigned, appeal.Status)
+
+	// Outro moderador não pode resolver apelação atribuída
+	assert.ErrorIs(t, appeal.Resolve(6, true, "ok"), moderation.ErrNotAssignee)
+
+	assert.NoError(t, appeal.Resolve(5, true, "prova válida"))
+	assert.Equal(t, moderation.AppealStatusOverturned, appeal.Status)
+	assert.True(t, appeal.IsResolved())
+
+	assert.ErrorIs(t, appeal.Assign(5), moderation.ErrAppealResolved)
+}
+
+func TestModerationService_ResolveAppeal_PublishesAfterCommit_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockModerationRepository(ctrl)
+	mockChallengeService := mocks.NewMockModerationChallengeService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockModerationEventBus(ctrl)
+
+	service := moderation.NewService(mockRepo, mockChallengeService, mockLogger, mockEventBus,
+		database.NewTxManager(databasetest.New(t)))
+
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockRepo.EXPECT().IsModerator(gomock.Any(), uint(2)).Return(true, nil).Times(2)
+	mockRepo.EXPECT().GetAppealByID(gomock.Any(), uint(1)).DoAndReturn(
+		func(context.Context, uint) (*moderation.Appeal, error) {
+			return &moderation.Appeal{ID: 1, SubmissionID: 7, UserID: 5, Status: moderation.AppealStatusPending}, nil
+		}).Times(2)
+	mockRepo.EXPECT().UpdateAppeal(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockChallengeService.EXPECT().ApproveSubmission(gomock.Any(), uint(7), "Prova válida").
+		Return(&challenges.ChallengeSubmission{ID: 7}, nil).Times(2)
+
+	var published []string
+	mockEventBus.EXPECT().
+		Publish(gomock.Any()).
+		Do(func(event eventbus.Event) { published = append(published, event.Type) }).
+		AnyTimes()
+
+	input := moderation.ResolveAppealInput{AppealID: "1", Overturn: true, Note: "Prova válida"}
+
+	t.Run("should not publish when the transaction rolls back", func(t *testing.T) {
+		mockRepo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Return(errors.Internal(assert.AnError))
+
+		_, err := service.ResolveAppeal(context.Background(), 2, input)
+		assert.Error(t, err)
+		assert.Empty(t, published)
+	})
+
+	t.Run("should publish AppealResolved after commit", func(t *testing.T) {
+		mockRepo.EXPECT().CreateAction(gomock.Any(), gomock.Any()).Return(nil)
+
+		appeal, err := service.ResolveAppeal(context.Background(), 2, input)
+		assert.NoError(t, err)
+		assert.Equal(t, moderation.AppealStatusOverturned, appeal.Status)
+		assert.Equal(t, []string{"AppealResolved"}, published)
+	})
+}
@@ -0,0 +1,53 @@
+package users
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/rafaelcoelhox/labbend/pkg/cache"
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	"github.com/rafaelcoelhox/labbend/pkg/graphqlws"
+)
+
+// cacheInvalidationEvents - eventos que tornam o usuário ou o total de XP em cache obsoletos
+var cacheInvalidationEvents = []string{"UserUpdated", "UserDeleted", "UserXPGranted", "UserXPRemoved", "UserXPRestored", "UserXPReconciled", "UserXPExpired"}
+
+func userCacheKey(id uint) string {
+	return cache.Key("user", strconv.FormatUint(uint64(id), 10))
+}
+
+func xpTotalCacheKey(userID uint) string {
+	return cache.Key("xp_total", strconv.FormatUint(uint64(userID), 10))
+}
+
+// readCache - o cache só vale fora de transação: dentro dela a leitura precisa
+// ver o que a própria transação já escreveu
+func (s *service) readCache(ctx context.Context) cache.Cache {
+	if _, inTx := database.TxFromContext(ctx); inTx {
+		return nil
+	}
+	return s.cache
+}
+
+// cacheInvalidator - remove o usuário e o total de XP afetados por um evento.
+// Os eventos saem por TxManager.OnCommit: quando GiveUserXP ou RemoveUserXP
+// rodam dentro da transação do chamador (aprovação, moderação), a remoção só
+// acontece depois do commit, e uma leitura concorrente não recoloca o total
+// antigo no cache. Com Redis a remoção vale para todas as instâncias
+type cacheInvalidator struct {
+	cache cache.Cache
+}
+
+func (h *cacheInvalidator) HandleEvent(ctx context.Context, event eventbus.Event) error {
+	userID := graphqlws.EventUint(event.Data, "userID")
+	if userID == 0 {
+		return nil
+	}
+
+	keys := []string{xpTotalCacheKey(userID)}
+	if event.Type == "UserUpdated" || event.Type == "UserDeleted" {
+		keys = append(keys, userCacheKey(userID))
+	}
+	return h.cache.Delete(ctx, keys...)
+}
@@ -0,0 +1,148 @@
+package users
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/rafaelcoelhox/labbend/internal/challenges"
+	"github.com/rafaelcoelhox/labbend/pkg/cache"
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/database/databasetest"
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	applogger "github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/saga"
+)
+
+// invalidatingBus - entrega os eventos direto ao invalidador, como o event bus
+// faria depois do commit
+type invalidatingBus struct {
+	mu          sync.Mutex
+	invalidator *cacheInvalidator
+}
+
+func (b *invalidatingBus) Publish(event eventbus.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, eventType := range cacheInvalidationEvents {
+		if event.Type == eventType {
+			_ = b.invalidator.HandleEvent(context.Background(), event)
+		}
+	}
+}
+
+func setupCachedService(t *testing.T) (*service, *cache.Memory, *gorm.DB) {
+	t.Helper()
+
+	db := databasetest.New(t)
+	log, err := applogger.New()
+	require.NoError(t, err)
+
+	store := cache.NewMemory(0, 0)
+	bus := &invalidatingBus{invalidator: &cacheInvalidator{cache: store}}
+	svc := NewService(NewRepository(db), log, bus, database.NewTxManager(db), store, XPRules{}).(*service)
+	return svc, store, db
+}
+
+func TestService_Cache_UserInvalidatedOnUpdate(t *testing.T) {
+	svc, store, db := setupCachedService(t)
+	ctx := context.Background()
+
+	user, err := svc.CreateUser(ctx, CreateUserInput{Name: "Alice", Email: "alice@example.com", Nickname: "alice"})
+	require.NoError(t, err)
+
+	_, err = svc.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	_, cached, _ := store.Get(ctx, userCacheKey(user.ID))
+	require.True(t, cached)
+
+	// Escrita fora do service: o cache continua servindo o valor antigo
+	require.NoError(t, db.Model(&User{}).Where("id = ?", user.ID).Update("name", "Direct").Error)
+	found, err := svc.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", found.Name)
+
+	name, nickname := "Alice Updated", "alice2"
+	_, err = svc.UpdateUser(ctx, user.ID, UpdateUserInput{Name: &name, Nickname: &nickname})
+	require.NoError(t, err)
+
+	found, err = svc.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, name, found.Name)
+}
+
+func TestService_Cache_XPTotalInvalidatedOnGrant(t *testing.T) {
+	svc, _, _ := setupCachedService(t)
+	ctx := context.Background()
+
+	user, err := svc.CreateUser(ctx, CreateUserInput{Name: "Bob", Email: "bob@example.com", Nickname: "bob"})
+	require.NoError(t, err)
+
+	total, err := svc.GetUserTotalXP(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	require.NoError(t, svc.GiveUserXP(ctx, user.ID, "challenge", "1", 50))
+
+	withXP, err := svc.GetUserWithXP(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 50, withXP.TotalXP)
+}
+
+func TestService_Cache_BypassedInsideTransaction(t *testing.T) {
+	svc, store, _ := setupCachedService(t)
+	ctx := context.Background()
+
+	user, err := svc.CreateUser(ctx, CreateUserInput{Name: "Carol", Email: "carol@example.com", Nickname: "carol"})
+	require.NoError(t, err)
+
+	err = svc.txManager.InTransaction(ctx, func(ctx context.Context) error {
+		_, err := svc.GetUser(ctx, user.ID)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, store.Len())
+}
+
+func TestService_Cache_XPTotalAfterApprovalCommit(t *testing.T) {
+	svc, store, db := setupCachedService(t)
+	ctx := context.Background()
+
+	user, err := svc.CreateUser(ctx, CreateUserInput{Name: "Dave", Email: "dave@example.com", Nickname: "dave"})
+	require.NoError(t, err)
+
+	challengeRepo := challenges.NewRepository(db)
+	challenge := &challenges.Challenge{Title: "Deploy", XPReward: 120}
+	require.NoError(t, challengeRepo.CreateChallenge(ctx, challenge))
+	submission := &challenges.ChallengeSubmission{ChallengeID: challenge.ID, UserID: user.ID, ProofURL: "https://github.com/dave/deploy"}
+	require.NoError(t, challengeRepo.CreateSubmission(ctx, submission))
+
+	log, err := applogger.New()
+	require.NoError(t, err)
+	challengeService := challenges.NewService(challengeRepo, svc, log, svc.eventBus, svc.txManager,
+		saga.NewSagaManager(log), challenges.DefaultSubmissionPolicy(), nil, nil)
+
+	total, err := svc.GetUserTotalXP(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	err = svc.txManager.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := challengeService.ApproveSubmission(ctx, submission.ID, challenges.DecisionReasonCommunityApproved); err != nil {
+			return err
+		}
+		// Antes do commit o total em cache continua lá: uma leitura concorrente
+		// não tem como recolocar o valor antigo depois da invalidação
+		_, cached, _ := store.Get(ctx, xpTotalCacheKey(user.ID))
+		assert.True(t, cached, "invalidation must wait for the commit")
+		return nil
+	})
+	require.NoError(t, err)
+
+	total, err = svc.GetUserTotalXP(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 120, total)
+}
@@ -2,7 +2,9 @@
 // e sistema de XP (experiência) na plataforma LabEnd.
 //
 // Este pacote implementa um sistema de gamificação onde usuários podem:
-//   - Criar e gerenciar perfis de usuário
+//   - Criar e gerenciar perfis de usuário (bio, avatar, links, idioma e fuso),
+//     com visibilidade por campo (PrivacySettings) aplicada nas APIs conforme o
+//     solicitante (pkg/auth)
 //   - Acumular XP (pontos de experiência) através de diferentes atividades
 //   - Visualizar histórico de XP e rankings
 //
@@ -17,10 +19,13 @@
 // # Performance
 //
 // O pacote implementa otimizações críticas:
+//   - Saldo de XP materializado (user_xp_balance), mantido na transação de cada
+//     lançamento e reconciliado com o ledger user_xp pelo Reconciler
 //   - Query JOIN otimizada para usuarios+XP (elimina N+1)
 //   - Índices es tratégicos no banco de dados
 //   - Connection pooling com timeouts
 //   - Processamento assíncrono de eventos
+//   - Loaders por request (pkg/dataloader) para user e totalXP no GraphQL
 //
 // # Eventos
 //
@@ -29,12 +34,14 @@
 //   - UserUpdated: Quando dados do usuário são atualizados
 //   - UserDeleted: Quando um usuário é removido
 //   - UserXPGranted: Quando XP é concedido ao usuário
+//   - UserXPReconciled: Quando a reconciliação corrige o saldo de XP
+//   - UserXPExpired: Quando XP concedido expira (XPRules com expires_after)
 //
 // # Exemplo de Uso
 //
 //	// Criar service
 //	userRepo := users.NewRepository(db)
-//	userService := users.NewService(userRepo, logger, eventBus)
+//	userService := users.NewService(userRepo, logger, eventBus, txManager, cache.NewMemory(0, 0), users.XPRules{})
 //
 //	// Criar usuário
 //	user, err := userService.CreateUser(ctx, users.CreateUserInput{
@@ -1,11 +1,18 @@
 package users
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
 	"github.com/graphql-go/graphql"
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	"github.com/rafaelcoelhox/labbend/pkg/complexity"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	"github.com/rafaelcoelhox/labbend/pkg/graphqlws"
 	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
 	"go.uber.org/zap"
 )
 
@@ -24,7 +31,8 @@ var UserType = graphql.NewObject(graphql.ObjectConfig{
 			Type: graphql.String,
 		},
 		"totalXP": &graphql.Field{
-			Type: graphql.Int,
+			Type:    graphql.Int,
+			Resolve: totalXPResolver,
 		},
 		"createdAt": &graphql.Field{
 			Type: graphql.String,
@@ -35,6 +43,46 @@ var UserType = graphql.NewObject(graphql.ObjectConfig{
 		"nickname": &graphql.Field{
 			Type: graphql.String,
 		},
+		"bio": &graphql.Field{
+			Type: graphql.String,
+		},
+		"avatarURL": &graphql.Field{
+			Type: graphql.String,
+		},
+		"links": &graphql.Field{
+			Type: graphql.NewList(graphql.String),
+		},
+		"locale": &graphql.Field{
+			Type: graphql.String,
+		},
+		"timezone": &graphql.Field{
+			Type: graphql.String,
+		},
+		"privacy": &graphql.Field{
+			Type:        graphql.NewList(PrivacySettingType),
+			Description: "Visibilidade de cada campo do perfil (apenas para o dono e admins)",
+		},
+	},
+})
+
+var ProfileVisibilityEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "ProfileVisibility",
+	Values: graphql.EnumValueConfigMap{
+		"PUBLIC":  &graphql.EnumValueConfig{Value: string(VisibilityPublic)},
+		"FRIENDS": &graphql.EnumValueConfig{Value: string(VisibilityFriends), Description: "Por enquanto equivale a PRIVATE"},
+		"PRIVATE": &graphql.EnumValueConfig{Value: string(VisibilityPrivate)},
+	},
+})
+
+var PrivacySettingType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "PrivacySetting",
+	Fields: graphql.Fields{
+		"field": &graphql.Field{
+			Type: graphql.String,
+		},
+		"visibility": &graphql.Field{
+			Type: ProfileVisibilityEnum,
+		},
 	},
 })
 
@@ -59,22 +107,78 @@ var UserXPType = graphql.NewObject(graphql.ObjectConfig{
 		"createdAt": &graphql.Field{
 			Type: graphql.String,
 		},
+		"expiresAt": &graphql.Field{
+			Type:    graphql.String,
+			Resolve: xpExpiresAtResolver,
+		},
+		"breakdown": &graphql.Field{
+			Type:        XPBreakdownType,
+			Description: "Regras da economia de XP aplicadas no lançamento",
+			Resolve:     xpBreakdownResolver,
+		},
 	},
 })
 
+var XPBreakdownType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "XPBreakdown",
+	Fields: graphql.Fields{
+		"baseAmount":  &graphql.Field{Type: graphql.Int},
+		"multiplier":  &graphql.Field{Type: graphql.Float},
+		"events":      &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"streakDays":  &graphql.Field{Type: graphql.Int},
+		"streakBonus": &graphql.Field{Type: graphql.Float},
+		"cappedBy":    &graphql.Field{Type: graphql.Int},
+		"finalAmount": &graphql.Field{Type: graphql.Int},
+		"expiryOf":    &graphql.Field{Type: graphql.ID},
+	},
+})
+
+var UserConnectionType = pagination.NewConnectionType("User", UserType)
+
+var UserXPConnectionType = pagination.NewConnectionType("UserXP", UserXPType)
+
 // ===== RESOLVER FUNCTIONS =====
 
+func xpExpiresAtResolver(p graphql.ResolveParams) (interface{}, error) {
+	userXP, ok := p.Source.(*UserXP)
+	if !ok || userXP.ExpiresAt == nil {
+		return nil, nil
+	}
+	return userXP.ExpiresAt.String(), nil
+}
+
+func xpBreakdownResolver(p graphql.ResolveParams) (interface{}, error) {
+	userXP, ok := p.Source.(*UserXP)
+	if !ok {
+		return nil, nil
+	}
+	breakdown, err := userXP.ParseBreakdown()
+	if err != nil || breakdown == nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"baseAmount":  breakdown.BaseAmount,
+		"multiplier":  breakdown.Multiplier,
+		"events":      breakdown.Events,
+		"streakDays":  breakdown.StreakDays,
+		"streakBonus": breakdown.StreakBonus,
+		"cappedBy":    breakdown.CappedBy,
+		"finalAmount": breakdown.FinalAmount,
+		"expiryOf":    breakdown.ExpiryOf,
+	}, nil
+}
+
 func userResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
 	return func(p graphql.ResolveParams) (interface{}, error) {
 		id := p.Args["id"].(string)
 		userID, err := strconv.ParseUint(id, 10, 32)
 		if err != nil {
-			return nil, fmt.Errorf("ID inválido: %v", err)
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
 		}
 
 		logger.Info("Buscando usuário", zap.String("id", id))
 
-		user, err := service.GetUser(p.Context, uint(userID))
+		user, err := loadUser(p.Context, service, uint(userID))
 		if err != nil {
 			logger.Error("Erro ao buscar usuário", zap.Error(err))
 			return nil, err
@@ -82,74 +186,153 @@ func userResolver(service Service, logger logger.Logger) graphql.FieldResolveFn
 
 		logger.Info("Usuário encontrado", zap.String("name", user.Name))
 
-		// Usar exatamente o mesmo formato que createUser
-		return map[string]interface{}{
-			"id":        fmt.Sprintf("%d", user.ID),
-			"name":      user.Name,
-			"email":     user.Email,
-			"nickname":  user.Nickname,
-			"totalXP":   0,
-			"createdAt": user.CreatedAt.String(),
-			"updatedAt": user.UpdatedAt.String(),
-		}, nil
+		return userToMap(user, auth.ViewerFromContext(p.Context)), nil
 	}
 }
 
-func usersResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
-	return func(p graphql.ResolveParams) (interface{}, error) {
-		limit := 10
-		offset := 0
-		if l, ok := p.Args["limit"].(int); ok {
-			limit = l
-		}
-		if o, ok := p.Args["offset"].(int); ok {
-			offset = o
+// loadUser - usa o loader da request quando disponível (agrupa aliases de user(id))
+func loadUser(ctx context.Context, service Service, id uint) (*User, error) {
+	loaders := LoadersFromContext(ctx)
+	if loaders == nil {
+		return service.GetUser(ctx, id)
+	}
+
+	user, err := loaders.UserByID.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.NotFound("user", id)
+	}
+	return user, nil
+}
+
+// totalXPResolver - XP total via loader, uma query para todos os usuários da resposta
+func totalXPResolver(p graphql.ResolveParams) (interface{}, error) {
+	var userID uint
+	switch source := p.Source.(type) {
+	case map[string]interface{}:
+		id, _ := source["id"].(string)
+		parsed, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, nil
 		}
+		userID = uint(parsed)
+	case *User:
+		userID = source.ID
+	default:
+		return nil, nil
+	}
+
+	loaders := LoadersFromContext(p.Context)
+	if loaders == nil {
+		return nil, nil
+	}
+
+	thunk := loaders.TotalXP.LoadThunk(p.Context, userID)
+	return func() (interface{}, error) {
+		return thunk()
+	}, nil
+}
 
+func usersResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
 		logger.Info("Listando usuários")
 
-		users, err := service.ListUsers(p.Context, limit, offset)
+		conn, err := service.ListUsersConnection(p.Context, pagination.ArgsFromMap(p.Args))
 		if err != nil {
 			logger.Error("Erro ao listar usuários", zap.Error(err))
 			return nil, err
 		}
 
-		logger.Info("Usuários encontrados", zap.Int("count", len(users)))
+		logger.Info("Usuários encontrados", zap.Int("count", len(conn.Edges)))
 
-		// Criar resultado usando exatamente o mesmo formato que createUser
-		var result []map[string]interface{}
-		for _, user := range users {
-			if user == nil {
-				continue
-			}
+		viewer := auth.ViewerFromContext(p.Context)
+		return pagination.Map(conn, func(user *User) map[string]interface{} {
+			return userToMap(user, viewer)
+		}), nil
+	}
+}
 
-			userMap := map[string]interface{}{
-				"id":        fmt.Sprintf("%d", user.ID),
-				"name":      user.Name,
-				"email":     user.Email,
-				"nickname":  user.Nickname,
-				"totalXP":   0,
-				"createdAt": user.CreatedAt.String(),
-				"updatedAt": user.UpdatedAt.String(),
-			}
-			result = append(result, userMap)
+// leaderboardResolver - o saldo já vem na listagem e é gravado no loader, então
+// User.totalXP não faz outra consulta
+func leaderboardResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		limit, _ := p.Args["limit"].(int)
+		offset, _ := p.Args["offset"].(int)
+
+		ranking, err := service.ListLeaderboard(p.Context, limit, offset)
+		if err != nil {
+			logger.Error("Erro ao listar ranking", zap.Error(err))
+			return nil, err
 		}
 
-		logger.Info("Retornando resultado", zap.Int("total", len(result)))
+		loaders := LoadersFromContext(p.Context)
+		viewer := auth.ViewerFromContext(p.Context)
+		result := make([]map[string]interface{}, len(ranking))
+		for i, entry := range ranking {
+			if loaders != nil {
+				loaders.TotalXP.Prime(entry.User.ID, entry.TotalXP)
+			}
+			result[i] = userToMap(entry.User, viewer)
+		}
 		return result, nil
 	}
 }
 
+// userToMap - formato de User usado pelos resolvers GraphQL; campos que
+// viewer não pode ver (User.CanView) ficam nulos
+func userToMap(user *User, viewer auth.Viewer) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":        fmt.Sprintf("%d", user.ID),
+		"name":      user.Name,
+		"nickname":  user.Nickname,
+		"createdAt": user.CreatedAt.String(),
+		"updatedAt": user.UpdatedAt.String(),
+	}
+
+	profile := map[string]interface{}{
+		ProfileFieldEmail:    user.Email,
+		ProfileFieldBio:      user.Bio,
+		ProfileFieldAvatar:   user.AvatarURL,
+		ProfileFieldLinks:    []string(user.Links),
+		ProfileFieldLocale:   user.Locale,
+		ProfileFieldTimezone: user.Timezone,
+	}
+	keys := map[string]string{ProfileFieldAvatar: "avatarURL"}
+	for field, value := range profile {
+		key := field
+		if k, ok := keys[field]; ok {
+			key = k
+		}
+		if user.CanView(field, viewer) {
+			result[key] = value
+		} else {
+			result[key] = nil
+		}
+	}
+
+	if viewer.CanManage(user.ID) {
+		settings := user.PrivacySettings()
+		privacy := make([]map[string]interface{}, 0, len(settings))
+		for _, field := range settings.Fields() {
+			privacy = append(privacy, map[string]interface{}{"field": field, "visibility": string(settings[field])})
+		}
+		result["privacy"] = privacy
+	}
+	return result
+}
+
 func userXPHistoryResolver(service Service, logger logger.Logger) graphql.FieldResolveFn {
 	return func(p graphql.ResolveParams) (interface{}, error) {
 		userID := p.Args["userID"].(string)
 		uid, err := strconv.ParseUint(userID, 10, 32)
 		if err != nil {
-			return nil, fmt.Errorf("ID inválido: %v", err)
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
 		}
 
 		logger.Info("Buscando histórico XP")
-		return service.GetUserXPHistory(p.Context, uint(uid))
+		return service.GetUserXPHistoryConnection(p.Context, uint(uid), pagination.ArgsFromMap(p.Args))
 	}
 }
 
@@ -167,15 +350,8 @@ func createUserResolver(service Service, logger logger.Logger) graphql.FieldReso
 			return nil, err
 		}
 
-		return map[string]interface{}{
-			"id":        fmt.Sprintf("%d", user.ID),
-			"name":      user.Name,
-			"email":     user.Email,
-			"nickname":  user.Nickname,
-			"totalXP":   0,
-			"createdAt": user.CreatedAt.String(),
-			"updatedAt": user.UpdatedAt.String(),
-		}, nil
+		// Quem se cadastra vê o próprio perfil completo
+		return userToMap(user, auth.Viewer{UserID: user.ID}), nil
 	}
 }
 
@@ -184,7 +360,11 @@ func updateUserResolver(service Service, logger logger.Logger) graphql.FieldReso
 		id := p.Args["id"].(string)
 		userID, err := strconv.ParseUint(id, 10, 32)
 		if err != nil {
-			return nil, fmt.Errorf("ID inválido: %v", err)
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+		// Só o próprio usuário (ou admins) altera o perfil
+		if !auth.ViewerFromContext(p.Context).CanManage(uint(userID)) {
+			return nil, errors.Unauthorized("cannot update another user")
 		}
 
 		updateInput := UpdateUserInput{}
@@ -200,6 +380,32 @@ func updateUserResolver(service Service, logger logger.Logger) graphql.FieldReso
 			nicknameStr := nickname.(string)
 			updateInput.Nickname = &nicknameStr
 		}
+		if bio, ok := p.Args["bio"].(string); ok {
+			updateInput.Bio = &bio
+		}
+		if avatarURL, ok := p.Args["avatarURL"].(string); ok {
+			updateInput.AvatarURL = &avatarURL
+		}
+		if locale, ok := p.Args["locale"].(string); ok {
+			updateInput.Locale = &locale
+		}
+		if timezone, ok := p.Args["timezone"].(string); ok {
+			updateInput.Timezone = &timezone
+		}
+		if links, ok := p.Args["links"].([]interface{}); ok {
+			linkList := make([]string, 0, len(links))
+			for _, link := range links {
+				linkList = append(linkList, link.(string))
+			}
+			updateInput.Links = &linkList
+		}
+		if settings, ok := p.Args["privacy"].([]interface{}); ok {
+			updateInput.Privacy = make(PrivacySettings, len(settings))
+			for _, s := range settings {
+				setting := s.(map[string]interface{})
+				updateInput.Privacy[setting["field"].(string)] = Visibility(setting["visibility"].(string))
+			}
+		}
 
 		logger.Info("Atualizando usuário")
 		user, err := service.UpdateUser(p.Context, uint(userID), updateInput)
@@ -207,15 +413,7 @@ func updateUserResolver(service Service, logger logger.Logger) graphql.FieldReso
 			return nil, err
 		}
 
-		return map[string]interface{}{
-			"id":        fmt.Sprintf("%d", user.ID),
-			"name":      user.Name,
-			"email":     user.Email,
-			"nickname":  user.Nickname,
-			"totalXP":   0,
-			"createdAt": user.CreatedAt.String(),
-			"updatedAt": user.UpdatedAt.String(),
-		}, nil
+		return userToMap(user, auth.ViewerFromContext(p.Context)), nil
 	}
 }
 
@@ -224,7 +422,7 @@ func deleteUserResolver(service Service, logger logger.Logger) graphql.FieldReso
 		id := p.Args["id"].(string)
 		userID, err := strconv.ParseUint(id, 10, 32)
 		if err != nil {
-			return nil, fmt.Errorf("ID inválido: %v", err)
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
 		}
 
 		logger.Info("Deletando usuário")
@@ -238,6 +436,24 @@ func deleteUserResolver(service Service, logger logger.Logger) graphql.FieldReso
 
 // ===== SCHEMA CONFIGURATION =====
 
+var PrivacySettingInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "PrivacySettingInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"field": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.String),
+			Description: "email, bio, avatar, links, locale ou timezone",
+		},
+		"visibility": &graphql.InputObjectFieldConfig{
+			Type: graphql.NewNonNull(ProfileVisibilityEnum),
+		},
+	},
+})
+
+// FieldCosts - custos para a análise de complexidade (campos com consulta própria)
+var FieldCosts = complexity.Costs{
+	"User.totalXP": 1,
+}
+
 func Queries(userService Service, logger logger.Logger) *graphql.Fields {
 	return &graphql.Fields{
 		"user": &graphql.Field{
@@ -251,8 +467,14 @@ func Queries(userService Service, logger logger.Logger) *graphql.Fields {
 			Resolve: userResolver(userService, logger),
 		},
 		"users": &graphql.Field{
-			Type:        graphql.NewList(UserType),
-			Description: "Retorna lista de usuários",
+			Type:        graphql.NewNonNull(UserConnectionType),
+			Description: "Retorna usuários paginados por cursor (mais recentes primeiro)",
+			Args:        pagination.ConnectionArgs(nil),
+			Resolve:     usersResolver(userService, logger),
+		},
+		"leaderboard": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(UserType))),
+			Description: "Retorna o ranking de usuários pelo saldo de XP",
 			Args: graphql.FieldConfigArgument{
 				"limit": &graphql.ArgumentConfig{
 					Type:         graphql.Int,
@@ -263,16 +485,16 @@ func Queries(userService Service, logger logger.Logger) *graphql.Fields {
 					DefaultValue: 0,
 				},
 			},
-			Resolve: usersResolver(userService, logger),
+			Resolve: leaderboardResolver(userService, logger),
 		},
 		"userXPHistory": &graphql.Field{
-			Type:        graphql.NewList(UserXPType),
-			Description: "Retorna o histórico de XP de um usuário",
-			Args: graphql.FieldConfigArgument{
+			Type:        graphql.NewNonNull(UserXPConnectionType),
+			Description: "Retorna o histórico de XP de um usuário paginado por cursor",
+			Args: pagination.ConnectionArgs(graphql.FieldConfigArgument{
 				"userID": &graphql.ArgumentConfig{
 					Type: graphql.NewNonNull(graphql.String),
 				},
-			},
+			}),
 			Resolve: userXPHistoryResolver(userService, logger),
 		},
 	}
@@ -312,6 +534,28 @@ func Mutations(userService Service, logger logger.Logger) *graphql.Fields {
 				"nickname": &graphql.ArgumentConfig{
 					Type: graphql.String,
 				},
+				"bio": &graphql.ArgumentConfig{
+					Type: graphql.String,
+				},
+				"avatarURL": &graphql.ArgumentConfig{
+					Type: graphql.String,
+				},
+				"links": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+					Description: "Substitui a lista de links (até 5, http ou https)",
+				},
+				"locale": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Tag BCP 47 (ex.: pt-BR)",
+				},
+				"timezone": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Fuso IANA (ex.: America/Sao_Paulo)",
+				},
+				"privacy": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(PrivacySettingInputType)),
+					Description: "Altera a visibilidade dos campos informados",
+				},
 			},
 			Resolve: updateUserResolver(userService, logger),
 		},
@@ -327,3 +571,50 @@ func Mutations(userService Service, logger logger.Logger) *graphql.Fields {
 		},
 	}
 }
+
+func Subscriptions(bus graphqlws.EventSubscriber, logger logger.Logger) *graphql.Fields {
+	return &graphql.Fields{
+		"xpGained": &graphql.Field{
+			Type:        UserXPType,
+			Description: "Notifica cada XP concedido ao usuário (apenas o próprio usuário ou admins)",
+			Args: graphql.FieldConfigArgument{
+				"userID": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			},
+			Subscribe: xpGainedSubscriber(bus, logger),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source, nil
+			},
+		},
+	}
+}
+
+func xpGainedSubscriber(bus graphqlws.EventSubscriber, logger logger.Logger) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		userID, err := strconv.ParseUint(p.Args["userID"].(string), 10, 32)
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+		}
+		// O XP de um usuário só é transmitido para ele mesmo (ou admins)
+		if !auth.ViewerFromContext(p.Context).CanManage(uint(userID)) {
+			return nil, errors.Unauthorized("cannot subscribe to another user's XP")
+		}
+
+		logger.Info("Inscrevendo em xpGained", zap.Uint64("user_id", userID))
+		return graphqlws.EventStream(p.Context, bus, []string{"UserXPGranted"}, func(event eventbus.Event) (interface{}, bool) {
+			if graphqlws.EventUint(event.Data, "userID") != uint(userID) {
+				return nil, false
+			}
+			amount, _ := event.Data["amount"].(int)
+			sourceType, _ := event.Data["sourceType"].(string)
+			sourceID, _ := event.Data["sourceID"].(string)
+			return &UserXP{
+				UserID:     uint(userID),
+				SourceType: sourceType,
+				SourceID:   sourceID,
+				Amount:     amount,
+			}, true
+		}), nil
+	}
+}
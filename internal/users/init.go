@@ -1,9 +1,13 @@
 package users
 
-import "github.com/rafaelcoelhox/labbend/pkg/database"
+import "github.com/rafaelcoelhox/labbend/pkg/module"
 
-// init - registra automaticamente os modelos do módulo users
+// init - registra automaticamente o módulo users
 func init() {
-	database.RegisterModel(&User{})
-	database.RegisterModel(&UserXP{})
+	module.Register(module.Definition{
+		Name:       ModuleName,
+		New:        NewModule,
+		Migrations: migrations,
+		Models:     models,
+	})
 }
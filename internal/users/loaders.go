@@ -0,0 +1,34 @@
+package users
+
+import (
+	"context"
+
+	"github.com/rafaelcoelhox/labbend/pkg/dataloader"
+)
+
+// Loaders - dataloaders do módulo users, criados uma vez por request
+type Loaders struct {
+	UserByID *dataloader.Loader[uint, *User]
+	TotalXP  *dataloader.Loader[uint, int]
+}
+
+// NewLoaders - cria os loaders com batching sobre o Service
+func NewLoaders(service Service, config dataloader.Config) *Loaders {
+	return &Loaders{
+		UserByID: dataloader.New(service.GetUsersByIDs, config),
+		TotalXP:  dataloader.New(service.GetMultipleUsersXP, config),
+	}
+}
+
+type loadersKey struct{}
+
+// WithLoaders - anexa loaders novos ao contexto da request
+func WithLoaders(ctx context.Context, service Service, config dataloader.Config) context.Context {
+	return context.WithValue(ctx, loadersKey{}, NewLoaders(service, config))
+}
+
+// LoadersFromContext - loaders da request atual (nil se o handler não os anexou)
+func LoadersFromContext(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(loadersKey{}).(*Loaders)
+	return loaders
+}
@@ -0,0 +1,98 @@
+package users_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/internal/mocks"
+	"github.com/rafaelcoelhox/labbend/internal/users"
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/dataloader"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+func newUsersSchema(t *testing.T, service users.Service) graphql.Schema {
+	t.Helper()
+
+	log, err := logger.New()
+	require.NoError(t, err)
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: *users.Queries(service, log),
+		}),
+	})
+	require.NoError(t, err)
+	return schema
+}
+
+func TestUserLoaders_TotalXPIsBatched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepository(ctrl)
+	mockEventBus := mocks.NewMockUsersEventBus(ctrl)
+	log, _ := logger.New()
+	service := users.NewService(mockRepo, log, mockEventBus, database.NewTxManager(nil), nil, users.XPRules{})
+
+	mockRepo.EXPECT().
+		ListPaginated(gomock.Any(), gomock.Any()).
+		Return([]*users.User{{ID: 1, Name: "Ana"}, {ID: 2, Name: "Bia"}, {ID: 3, Name: "Caio"}}, nil).
+		Times(1)
+
+	// Uma única query de XP para todos os usuários da resposta
+	mockRepo.EXPECT().
+		GetMultipleUsersXP(gomock.Any(), gomock.InAnyOrder([]uint{1, 2, 3})).
+		Return(map[uint]int{1: 100, 2: 0, 3: 250}, nil).
+		Times(1)
+	mockRepo.EXPECT().GetUserTotalXP(gomock.Any(), gomock.Any()).Times(0)
+
+	ctx := users.WithLoaders(context.Background(), service, dataloader.DefaultConfig())
+	result := graphql.Do(graphql.Params{
+		Schema:        newUsersSchema(t, service),
+		RequestString: `{ users { edges { node { id totalXP } } } }`,
+		Context:       ctx,
+	})
+
+	require.Empty(t, result.Errors)
+	edges := result.Data.(map[string]interface{})["users"].(map[string]interface{})["edges"].([]interface{})
+	require.Len(t, edges, 3)
+	assert.Equal(t, 100, edges[0].(map[string]interface{})["node"].(map[string]interface{})["totalXP"])
+	assert.Equal(t, 250, edges[2].(map[string]interface{})["node"].(map[string]interface{})["totalXP"])
+}
+
+func TestUserLoaders_UserByIDIsBatchedAcrossAliases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepository(ctrl)
+	mockEventBus := mocks.NewMockUsersEventBus(ctrl)
+	log, _ := logger.New()
+	service := users.NewService(mockRepo, log, mockEventBus, database.NewTxManager(nil), nil, users.XPRules{})
+
+	// Campos raiz são resolvidos em sequência: cada alias busca pelo loader e o
+	// cache da request evita repetir a mesma chave
+	mockRepo.EXPECT().
+		GetByIDs(gomock.Any(), []uint{1}).
+		Return([]*users.User{{ID: 1, Name: "Ana"}}, nil).
+		Times(1)
+	mockRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Times(0)
+
+	ctx := users.WithLoaders(context.Background(), service, dataloader.DefaultConfig())
+	result := graphql.Do(graphql.Params{
+		Schema:        newUsersSchema(t, service),
+		RequestString: `{ a: user(id: "1") { name } b: user(id: "1") { name } }`,
+		Context:       ctx,
+	})
+
+	require.Empty(t, result.Errors)
+	data := result.Data.(map[string]interface{})
+	assert.Equal(t, "Ana", data["a"].(map[string]interface{})["name"])
+	assert.Equal(t, "Ana", data["b"].(map[string]interface{})["name"])
+}
@@ -0,0 +1,28 @@
+-- Schema inicial do módulo users (equivalente ao AutoMigrate anterior)
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "email" text NOT NULL,
+    "nickname" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_name" ON "users" ("name");
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_users_created_at" ON "users" ("created_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email");
+
+CREATE TABLE IF NOT EXISTS "user_xp" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "source_type" text NOT NULL,
+    "source_id" text NOT NULL,
+    "amount" bigint NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_user_xp_created_at" ON "user_xp" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_user_xp_source" ON "user_xp" ("source_type","source_id");
+CREATE INDEX IF NOT EXISTS "idx_user_xp_user_id" ON "user_xp" ("user_id");
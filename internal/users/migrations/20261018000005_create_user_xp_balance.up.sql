@@ -0,0 +1,16 @@
+-- Saldo de XP materializado: mantido na mesma transação de cada lançamento em
+-- user_xp e conferido pelo job de reconciliação
+CREATE TABLE IF NOT EXISTS "user_xp_balance" (
+    "user_id" bigint NOT NULL,
+    "balance" bigint NOT NULL DEFAULT 0,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("user_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_user_xp_balance_balance" ON "user_xp_balance" ("balance");
+
+-- Saldos iniciais a partir do ledger existente
+INSERT INTO "user_xp_balance" ("user_id", "balance", "updated_at")
+SELECT "user_id", SUM("amount"), NOW()
+FROM "user_xp"
+GROUP BY "user_id"
+ON CONFLICT ("user_id") DO UPDATE SET "balance" = EXCLUDED."balance", "updated_at" = EXCLUDED."updated_at";
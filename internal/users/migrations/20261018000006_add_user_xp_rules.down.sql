@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_user_xp_expires_at";
+ALTER TABLE "user_xp" DROP COLUMN IF EXISTS "expired_at";
+ALTER TABLE "user_xp" DROP COLUMN IF EXISTS "expires_at";
+ALTER TABLE "user_xp" DROP COLUMN IF EXISTS "breakdown";
@@ -0,0 +1,7 @@
+-- Regras da economia de XP: cálculo aplicado em cada lançamento e validade do XP
+ALTER TABLE "user_xp" ADD COLUMN IF NOT EXISTS "breakdown" jsonb;
+ALTER TABLE "user_xp" ADD COLUMN IF NOT EXISTS "expires_at" timestamptz;
+ALTER TABLE "user_xp" ADD COLUMN IF NOT EXISTS "expired_at" timestamptz;
+
+-- Job de expiração: apenas os lançamentos ainda não expirados
+CREATE INDEX IF NOT EXISTS "idx_user_xp_expires_at" ON "user_xp" ("expires_at") WHERE "expired_at" IS NULL;
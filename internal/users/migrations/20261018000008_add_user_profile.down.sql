@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "idx_users_nickname";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "privacy";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "timezone";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "locale";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "links";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "avatar_url";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "bio";
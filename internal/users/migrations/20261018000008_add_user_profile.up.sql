@@ -0,0 +1,18 @@
+-- Perfil do usuário e configurações de privacidade por campo
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "bio" text;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "avatar_url" text;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "links" jsonb;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "locale" text;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "timezone" text;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "privacy" jsonb;
+
+-- Nicknames repetidos (CreateUser não verificava): o usuário mais antigo
+-- mantém o nickname, os demais recebem o sufixo _<id>
+UPDATE "users" AS u
+SET "nickname" = u."nickname" || '_' || u."id"
+WHERE EXISTS (
+    SELECT 1 FROM "users" AS older
+    WHERE older."nickname" = u."nickname" AND older."id" < u."id"
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_nickname" ON "users" ("nickname");
@@ -1,17 +1,27 @@
 package users
 
 import (
+	"encoding/json"
 	"errors"
 	"time"
 
 	"gorm.io/gorm"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
 )
 
 type User struct {
-	ID        uint           `json:"id" gorm:"primarykey"`
-	Name      string         `json:"name" gorm:"not null;index"`
-	Email     string         `json:"email" gorm:"uniqueIndex;not null"`
-	Nickname  string         `json:"nickname" gorm:"not null"`
+	ID        uint                `json:"id" gorm:"primarykey"`
+	Name      string              `json:"name" gorm:"not null;index"`
+	Email     string              `json:"email,omitempty" gorm:"uniqueIndex;not null"`
+	Nickname  string              `json:"nickname" gorm:"not null;uniqueIndex"`
+	Bio       string              `json:"bio,omitempty"`
+	AvatarURL string              `json:"avatar_url,omitempty"`
+	Links     database.StringList `json:"links,omitempty"`
+	Locale    string              `json:"locale,omitempty"`
+	Timezone  string              `json:"timezone,omitempty"`
+	// Privacy - PrivacySettings em JSON; campos ausentes usam DefaultPrivacy
+	Privacy   database.JSON  `json:"privacy,omitempty"`
 	CreatedAt time.Time      `json:"created_at" gorm:"index"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
@@ -24,6 +34,19 @@ type UserXP struct {
 	SourceID   string    `json:"source_id" gorm:"not null;index:idx_user_xp_source"`
 	Amount     int       `json:"amount" gorm:"not null"`
 	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+	// Breakdown - XPBreakdown com as regras aplicadas (ver ParseBreakdown)
+	Breakdown database.JSON `json:"breakdown,omitempty"`
+	// ExpiresAt - validade do XP concedido; ExpiredAt marca a expiração já lançada
+	ExpiresAt *time.Time `json:"expires_at,omitempty" gorm:"index:idx_user_xp_expires_at"`
+	ExpiredAt *time.Time `json:"expired_at,omitempty"`
+}
+
+// UserXPBalance - saldo materializado do ledger user_xp; atualizado na mesma
+// transação de cada lançamento e conferido pelo Reconciler
+type UserXPBalance struct {
+	UserID    uint      `json:"user_id" gorm:"primarykey;autoIncrement:false"`
+	Balance   int       `json:"balance" gorm:"not null;default:0;index:idx_user_xp_balance_balance"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 const (
@@ -35,13 +58,20 @@ const (
 type CreateUserInput struct {
 	Name     string `json:"name" validate:"required,min=2"`
 	Email    string `json:"email" validate:"required,email"`
-	Nickname string `json:"nickname" validate:"required,min=2"`
+	Nickname string `json:"nickname" validate:"required,nickname"`
 }
 
 type UpdateUserInput struct {
-	Name     *string `json:"name,omitempty"`
-	Email    *string `json:"email,omitempty"`
-	Nickname *string `json:"nickname,omitempty"`
+	Name      *string   `json:"name,omitempty" validate:"omitempty,min=2"`
+	Email     *string   `json:"email,omitempty" validate:"omitempty,email"`
+	Nickname  *string   `json:"nickname,omitempty" validate:"omitempty,nickname"`
+	Bio       *string   `json:"bio,omitempty" validate:"omitempty,max=500"`
+	AvatarURL *string   `json:"avatar_url,omitempty" validate:"omitempty,url,urlscheme=https http"`
+	Links     *[]string `json:"links,omitempty" validate:"omitempty,max=5,dive,url,urlscheme=https http"`
+	Locale    *string   `json:"locale,omitempty" validate:"omitempty,bcp47_language_tag"`
+	Timezone  *string   `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	// Privacy - visibilidade por campo; apenas os campos informados mudam
+	Privacy PrivacySettings `json:"privacy,omitempty"`
 }
 
 func (User) TableName() string {
@@ -52,6 +82,22 @@ func (UserXP) TableName() string {
 	return "user_xp"
 }
 
+// ParseBreakdown - regras aplicadas no lançamento (nil em lançamentos sem registro)
+func (x *UserXP) ParseBreakdown() (*XPBreakdown, error) {
+	if len(x.Breakdown) == 0 {
+		return nil, nil
+	}
+	var breakdown XPBreakdown
+	if err := json.Unmarshal(x.Breakdown, &breakdown); err != nil {
+		return nil, err
+	}
+	return &breakdown, nil
+}
+
+func (UserXPBalance) TableName() string {
+	return "user_xp_balance"
+}
+
 func (u *User) Validate() error {
 	if u.Name == "" {
 		return ErrInvalidName
@@ -0,0 +1,153 @@
+package users
+
+import (
+	"context"
+	"time"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/rafaelcoelhox/labbend/pkg/cache"
+	"github.com/rafaelcoelhox/labbend/pkg/complexity"
+	"github.com/rafaelcoelhox/labbend/pkg/dataloader"
+	"github.com/rafaelcoelhox/labbend/pkg/graphqlws"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/module"
+	"github.com/rafaelcoelhox/labbend/pkg/rest"
+)
+
+// ModuleName - nome do módulo no registry (usado em DependsOn)
+const ModuleName = "users"
+
+// Settings - configuração lida de Deps.Config; sem ela valem os defaults
+type Settings interface {
+	// UsersXPReconcileInterval - intervalo da reconciliação do saldo de XP (0 desativa)
+	UsersXPReconcileInterval() time.Duration
+	// UsersXPReconcileFix - corrige as divergências encontradas (false só reporta)
+	UsersXPReconcileFix() bool
+	// UsersXPRules - regras da economia de XP (XPRules{} concede o valor informado)
+	UsersXPRules() (XPRules, error)
+	// UsersXPExpiryInterval - intervalo do job de expiração de XP
+	UsersXPExpiryInterval() time.Duration
+}
+
+// Module - registro do módulo users na aplicação
+type Module struct {
+	module.Base
+	service      Service
+	logger       logger.Logger
+	loaderConfig dataloader.Config
+	cache        cache.Cache
+
+	reconciler        *Reconciler
+	reconcileInterval time.Duration
+	reconcileFix      bool
+	expirer           *Expirer
+	expiryInterval    time.Duration
+}
+
+// NewModule - factory registrada no init.go
+func NewModule(deps *module.Deps) (module.Module, error) {
+	reconcileInterval, reconcileFix := DefaultReconcileInterval, true
+	expiryInterval := DefaultExpiryInterval
+	var rules XPRules
+	if settings, ok := deps.Config.(Settings); ok {
+		reconcileInterval = settings.UsersXPReconcileInterval()
+		reconcileFix = settings.UsersXPReconcileFix()
+		expiryInterval = settings.UsersXPExpiryInterval()
+
+		var err error
+		if rules, err = settings.UsersXPRules(); err != nil {
+			return nil, err
+		}
+	}
+	// Sem validade configurada não há o que expirar
+	if !rules.Expires() {
+		expiryInterval = 0
+	}
+
+	repo := NewRepository(deps.DB)
+	usersCache := deps.Cache(ModuleName)
+	return &Module{
+		service:           NewService(repo, deps.Logger, deps.EventBus, deps.TxManager, usersCache, rules),
+		logger:            deps.Logger,
+		loaderConfig:      deps.LoaderConfig,
+		cache:             usersCache,
+		reconciler:        NewReconciler(repo, deps.Logger, deps.EventBus),
+		reconcileInterval: reconcileInterval,
+		reconcileFix:      reconcileFix,
+		expirer:           NewExpirer(repo, deps.Logger, deps.EventBus),
+		expiryInterval:    expiryInterval,
+	}, nil
+}
+
+func (m *Module) Name() string         { return ModuleName }
+func (m *Module) Service() interface{} { return m.service }
+
+func (m *Module) Queries() graphql.Fields {
+	return *Queries(m.service, m.logger)
+}
+
+func (m *Module) Mutations() graphql.Fields {
+	return *Mutations(m.service, m.logger)
+}
+
+func (m *Module) Subscriptions(bus graphqlws.EventSubscriber) graphql.Fields {
+	return *Subscriptions(bus, m.logger)
+}
+
+func (m *Module) Costs() complexity.Costs {
+	return FieldCosts
+}
+
+// RESTRoutes - endpoints REST em /api/v1
+func (m *Module) RESTRoutes() []rest.Route {
+	return RESTRoutes(m.service)
+}
+
+// EventSubscriptions - invalidação do cache pelos eventos de usuário e XP
+func (m *Module) EventSubscriptions() []module.EventSubscription {
+	if m.cache == nil {
+		return nil
+	}
+	invalidator := &cacheInvalidator{cache: m.cache}
+	subscriptions := make([]module.EventSubscription, 0, len(cacheInvalidationEvents))
+	for _, eventType := range cacheInvalidationEvents {
+		subscriptions = append(subscriptions, module.EventSubscription{EventType: eventType, Handler: invalidator})
+	}
+	return subscriptions
+}
+
+// Jobs - reconciliação de user_xp_balance com o ledger user_xp e expiração de XP
+func (m *Module) Jobs() []module.Job {
+	return []module.Job{
+		{
+			Name:     "xp_balance_reconcile",
+			Interval: m.reconcileInterval,
+			Run: func(ctx context.Context) error {
+				_, err := m.reconciler.Reconcile(ctx, m.reconcileFix)
+				return err
+			},
+		},
+		{
+			Name:     "xp_expiry",
+			Interval: m.expiryInterval,
+			Run: func(ctx context.Context) error {
+				_, err := m.expirer.ExpireDue(ctx)
+				return err
+			},
+		},
+	}
+}
+
+// models - modelos GORM do módulo; registrados em database.RegisterModel
+// para o AutoMigrate do SQLite (desenvolvimento local e testes)
+var models = []interface{}{&User{}, &UserXP{}, &UserXPBalance{}}
+
+func (m *Module) Models() []interface{} {
+	return models
+}
+
+// WithRequestContext - dataloaders por request
+func (m *Module) WithRequestContext(ctx context.Context) context.Context {
+	return WithLoaders(ctx, m.service, m.loaderConfig)
+}
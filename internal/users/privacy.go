@@ -0,0 +1,169 @@
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+)
+
+// Visibility - quem vê um campo do perfil
+type Visibility string
+
+const (
+	VisibilityPublic Visibility = "public"
+	// VisibilityFriends - ainda não há relação de amizade: equivale a private
+	VisibilityFriends Visibility = "friends"
+	VisibilityPrivate Visibility = "private"
+)
+
+// Campos do perfil com visibilidade configurável; id, name e nickname são
+// sempre públicos
+const (
+	ProfileFieldEmail    = "email"
+	ProfileFieldBio      = "bio"
+	ProfileFieldAvatar   = "avatar"
+	ProfileFieldLinks    = "links"
+	ProfileFieldLocale   = "locale"
+	ProfileFieldTimezone = "timezone"
+)
+
+// ProfileFields - campos aceitos em PrivacySettings
+var ProfileFields = []string{
+	ProfileFieldEmail,
+	ProfileFieldBio,
+	ProfileFieldAvatar,
+	ProfileFieldLinks,
+	ProfileFieldLocale,
+	ProfileFieldTimezone,
+}
+
+// PrivacySettings - visibilidade por campo do perfil
+type PrivacySettings map[string]Visibility
+
+// DefaultPrivacy - email privado, demais campos públicos
+func DefaultPrivacy() PrivacySettings {
+	settings := make(PrivacySettings, len(ProfileFields))
+	for _, field := range ProfileFields {
+		settings[field] = VisibilityPublic
+	}
+	settings[ProfileFieldEmail] = VisibilityPrivate
+	return settings
+}
+
+// Validate - apenas campos de ProfileFields e visibilidades conhecidas
+func (p PrivacySettings) Validate() error {
+	for field, visibility := range p {
+		if !isProfileField(field) {
+			return errors.InvalidInput(fmt.Sprintf("privacy: unknown field %q (expected one of %s)",
+				field, strings.Join(ProfileFields, ", ")))
+		}
+		switch visibility {
+		case VisibilityPublic, VisibilityFriends, VisibilityPrivate:
+		default:
+			return errors.InvalidInput(fmt.Sprintf("privacy: invalid visibility %q for %s (expected public, friends or private)",
+				visibility, field))
+		}
+	}
+	return nil
+}
+
+// Fields - campos em ordem alfabética (saída estável para as APIs)
+func (p PrivacySettings) Fields() []string {
+	fields := make([]string, 0, len(p))
+	for field := range p {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func isProfileField(field string) bool {
+	for _, f := range ProfileFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// PrivacySettings - configurações gravadas sobre DefaultPrivacy
+func (u *User) PrivacySettings() PrivacySettings {
+	settings := DefaultPrivacy()
+	if len(u.Privacy) == 0 {
+		return settings
+	}
+
+	var stored PrivacySettings
+	// JSON inválido não deve expor campos: fica com o padrão
+	if err := json.Unmarshal(u.Privacy, &stored); err != nil {
+		return settings
+	}
+	for field, visibility := range stored {
+		if isProfileField(field) {
+			settings[field] = visibility
+		}
+	}
+	return settings
+}
+
+// SetPrivacy - aplica changes sobre as configurações atuais
+func (u *User) SetPrivacy(changes PrivacySettings) error {
+	if err := changes.Validate(); err != nil {
+		return err
+	}
+
+	settings := u.PrivacySettings()
+	for field, visibility := range changes {
+		settings[field] = visibility
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return errors.Internal(err)
+	}
+	u.Privacy = data
+	return nil
+}
+
+// CanView - o dono do perfil e admins veem todos os campos; os demais só os
+// públicos
+func (u *User) CanView(field string, viewer auth.Viewer) bool {
+	if viewer.CanManage(u.ID) {
+		return true
+	}
+	return u.PrivacySettings()[field] == VisibilityPublic
+}
+
+// Redacted - cópia do usuário sem os campos que viewer não pode ver
+func (u *User) Redacted(viewer auth.Viewer) *User {
+	if viewer.CanManage(u.ID) {
+		return u
+	}
+
+	redacted := *u
+	settings := u.PrivacySettings()
+	redacted.Privacy = nil
+	hidden := func(field string) bool { return settings[field] != VisibilityPublic }
+	if hidden(ProfileFieldEmail) {
+		redacted.Email = ""
+	}
+	if hidden(ProfileFieldBio) {
+		redacted.Bio = ""
+	}
+	if hidden(ProfileFieldAvatar) {
+		redacted.AvatarURL = ""
+	}
+	if hidden(ProfileFieldLinks) {
+		redacted.Links = nil
+	}
+	if hidden(ProfileFieldLocale) {
+		redacted.Locale = ""
+	}
+	if hidden(ProfileFieldTimezone) {
+		redacted.Timezone = ""
+	}
+	return &redacted
+}
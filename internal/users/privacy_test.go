@@ -0,0 +1,194 @@
+package users
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	applogger "github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+func TestUser_CanView(t *testing.T) {
+	user := &User{ID: 7, Email: "alice@example.com", Bio: "Olá"}
+	require.NoError(t, user.SetPrivacy(PrivacySettings{ProfileFieldBio: VisibilityFriends}))
+
+	anonymous := auth.Viewer{}
+	stranger := auth.Viewer{UserID: 8}
+
+	// Email é privado por padrão; friends ainda equivale a private
+	assert.False(t, user.CanView(ProfileFieldEmail, anonymous))
+	assert.False(t, user.CanView(ProfileFieldBio, stranger))
+	assert.True(t, user.CanView(ProfileFieldLocale, stranger))
+
+	for _, viewer := range []auth.Viewer{{UserID: 7}, {UserID: 8, Admin: true}} {
+		assert.True(t, user.CanView(ProfileFieldEmail, viewer))
+		assert.True(t, user.CanView(ProfileFieldBio, viewer))
+	}
+
+	redacted := user.Redacted(stranger)
+	assert.Empty(t, redacted.Email)
+	assert.Empty(t, redacted.Bio)
+	assert.Nil(t, redacted.Privacy)
+	assert.Equal(t, "alice@example.com", user.Email, "Redacted must not change the original")
+	assert.Same(t, user, user.Redacted(auth.Viewer{UserID: 7}))
+}
+
+func TestPrivacySettings_Validate(t *testing.T) {
+	user := &User{ID: 7}
+	assert.ErrorIs(t, user.SetPrivacy(PrivacySettings{"password": VisibilityPublic}), errors.ErrInvalidInput)
+	assert.ErrorIs(t, user.SetPrivacy(PrivacySettings{ProfileFieldEmail: "everyone"}), errors.ErrInvalidInput)
+
+	require.NoError(t, user.SetPrivacy(PrivacySettings{ProfileFieldEmail: VisibilityPublic}))
+	require.NoError(t, user.SetPrivacy(PrivacySettings{ProfileFieldLinks: VisibilityPrivate}))
+	settings := user.PrivacySettings()
+	assert.Equal(t, VisibilityPublic, settings[ProfileFieldEmail])
+	assert.Equal(t, VisibilityPrivate, settings[ProfileFieldLinks])
+}
+
+func TestUserToMap_Privacy(t *testing.T) {
+	user := &User{ID: 7, Name: "Alice", Email: "alice@example.com", Nickname: "alice", AvatarURL: "https://cdn.example.com/a.png"}
+
+	public := userToMap(user, auth.Viewer{UserID: 8})
+	assert.Nil(t, public["email"])
+	assert.Equal(t, "https://cdn.example.com/a.png", public["avatarURL"])
+	assert.Nil(t, public["privacy"])
+
+	owner := userToMap(user, auth.Viewer{UserID: 7})
+	assert.Equal(t, "alice@example.com", owner["email"])
+	assert.Len(t, owner["privacy"], len(ProfileFields))
+}
+
+func TestService_UpdateUser_Profile(t *testing.T) {
+	svc, _, _ := setupCachedService(t)
+	ctx := context.Background()
+
+	user, err := svc.CreateUser(ctx, CreateUserInput{Name: "Alice", Email: "alice@example.com", Nickname: "alice"})
+	require.NoError(t, err)
+
+	bio := "Estudante de Go"
+	links := []string{"https://github.com/alice"}
+	timezone := "America/Sao_Paulo"
+	_, err = svc.UpdateUser(ctx, user.ID, UpdateUserInput{
+		Bio:      &bio,
+		Links:    &links,
+		Timezone: &timezone,
+		Privacy:  PrivacySettings{ProfileFieldTimezone: VisibilityPrivate},
+	})
+	require.NoError(t, err)
+
+	// Passa pelo cache: as configurações de privacidade sobrevivem à serialização
+	_, err = svc.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	found, err := svc.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, bio, found.Bio)
+	assert.Equal(t, links, []string(found.Links))
+	assert.False(t, found.CanView(ProfileFieldTimezone, auth.Viewer{}))
+
+	t.Run("should reject invalid profile fields", func(t *testing.T) {
+		invalid := []UpdateUserInput{
+			{Timezone: strPtr("Mars/Olympus")},
+			{Locale: strPtr("not a locale")},
+			{AvatarURL: strPtr("ftp://example.com/a.png")},
+			{Links: &[]string{"javascript:alert(1)"}},
+		}
+		for _, input := range invalid {
+			_, err := svc.UpdateUser(ctx, user.ID, input)
+			assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		}
+	})
+}
+
+func TestService_NicknameUniqueness(t *testing.T) {
+	svc, _, _ := setupCachedService(t)
+	ctx := context.Background()
+
+	alice, err := svc.CreateUser(ctx, CreateUserInput{Name: "Alice", Email: "alice@example.com", Nickname: "alice"})
+	require.NoError(t, err)
+	bob, err := svc.CreateUser(ctx, CreateUserInput{Name: "Bob", Email: "bob@example.com", Nickname: "bob"})
+	require.NoError(t, err)
+
+	t.Run("should reject a taken nickname on create", func(t *testing.T) {
+		_, err := svc.CreateUser(ctx, CreateUserInput{Name: "Outra Alice", Email: "alice2@example.com", Nickname: "alice"})
+		assert.ErrorIs(t, err, errors.ErrAlreadyExists)
+	})
+
+	t.Run("should keep the user's own nickname on update", func(t *testing.T) {
+		name := "Alice Silva"
+		updated, err := svc.UpdateUser(ctx, alice.ID, UpdateUserInput{Name: &name, Nickname: strPtr("alice")})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", updated.Nickname)
+	})
+
+	t.Run("should reject a taken nickname on update", func(t *testing.T) {
+		_, err := svc.UpdateUser(ctx, bob.ID, UpdateUserInput{Nickname: strPtr("alice")})
+		assert.ErrorIs(t, err, errors.ErrAlreadyExists)
+	})
+
+	t.Run("unique index should reject writes that skip the service", func(t *testing.T) {
+		err := svc.repo.Create(ctx, &User{Name: "Carol", Email: "carol@example.com", Nickname: "bob"})
+		assert.ErrorIs(t, err, errors.ErrAlreadyExists)
+		assert.Contains(t, err.Error(), "nickname")
+
+		err = svc.repo.Create(ctx, &User{Name: "Carol", Email: "bob@example.com", Nickname: "carol"})
+		assert.ErrorIs(t, err, errors.ErrAlreadyExists)
+		assert.Contains(t, err.Error(), "email")
+	})
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+type noopSubscriber struct{}
+
+func (noopSubscriber) Subscribe(string, eventbus.EventHandler)   {}
+func (noopSubscriber) Unsubscribe(string, eventbus.EventHandler) {}
+
+func TestXPGainedSubscriber_OnlyOwnUser(t *testing.T) {
+	log, err := applogger.New()
+	require.NoError(t, err)
+	subscribe := xpGainedSubscriber(noopSubscriber{}, log)
+	args := map[string]interface{}{"userID": "7"}
+
+	for _, viewer := range []auth.Viewer{{}, {UserID: 8}} {
+		_, err := subscribe(graphql.ResolveParams{Context: auth.WithViewer(context.Background(), viewer), Args: args})
+		assert.ErrorIs(t, err, errors.ErrUnauthorized)
+	}
+
+	for _, viewer := range []auth.Viewer{{UserID: 7}, {UserID: 8, Admin: true}} {
+		ctx, cancel := context.WithCancel(auth.WithViewer(context.Background(), viewer))
+		_, err := subscribe(graphql.ResolveParams{Context: ctx, Args: args})
+		assert.NoError(t, err)
+		cancel()
+	}
+}
+
+func TestUpdateUserResolver_OnlyOwnUser(t *testing.T) {
+	svc, _, _ := setupCachedService(t)
+	update := updateUserResolver(svc, svc.logger)
+
+	user, err := svc.CreateUser(context.Background(), CreateUserInput{Name: "Alice", Email: "alice@example.com", Nickname: "alice"})
+	require.NoError(t, err)
+	args := map[string]interface{}{"id": strconv.FormatUint(uint64(user.ID), 10), "bio": "Alterado"}
+
+	for _, viewer := range []auth.Viewer{{}, {UserID: user.ID + 1}} {
+		_, err := update(graphql.ResolveParams{Context: auth.WithViewer(context.Background(), viewer), Args: args})
+		assert.ErrorIs(t, err, errors.ErrUnauthorized)
+	}
+	found, err := svc.GetUser(context.Background(), user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, found.Bio)
+
+	for _, viewer := range []auth.Viewer{{UserID: user.ID}, {UserID: user.ID + 1, Admin: true}} {
+		_, err := update(graphql.ResolveParams{Context: auth.WithViewer(context.Background(), viewer), Args: args})
+		assert.NoError(t, err)
+	}
+}
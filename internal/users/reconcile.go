@@ -0,0 +1,86 @@
+package users
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+// DefaultReconcileInterval - intervalo padrão do job de reconciliação
+const DefaultReconcileInterval = time.Hour
+
+// XPBalanceDrift - divergência entre o saldo materializado e o ledger
+type XPBalanceDrift struct {
+	UserID      uint `json:"user_id" gorm:"column:user_id"`
+	Balance     int  `json:"balance" gorm:"column:balance"`
+	LedgerTotal int  `json:"ledger_total" gorm:"column:ledger_total"`
+}
+
+// ReconcileReport - resultado de uma reconciliação
+type ReconcileReport struct {
+	Drifts []XPBalanceDrift `json:"drifts"`
+	Fixed  int              `json:"fixed"`
+}
+
+// Reconciler - compara user_xp_balance com a soma de user_xp e, com fix,
+// regrava os saldos divergentes a partir do ledger (a fonte da verdade)
+type Reconciler struct {
+	repo     Repository
+	logger   logger.Logger
+	eventBus EventBus
+}
+
+// NewReconciler - eventBus nil não publica UserXPReconciled (ex.: CLI)
+func NewReconciler(repo Repository, logger logger.Logger, eventBus EventBus) *Reconciler {
+	return &Reconciler{repo: repo, logger: logger, eventBus: eventBus}
+}
+
+// Reconcile - lista as divergências; com fix corrige cada uma e publica
+// UserXPReconciled (invalida o total de XP em cache)
+func (r *Reconciler) Reconcile(ctx context.Context, fix bool) (*ReconcileReport, error) {
+	drifts, err := r.repo.FindXPBalanceDrift(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReconcileReport{Drifts: drifts}
+	for _, drift := range drifts {
+		r.logger.Warn("XP balance drift detected",
+			zap.Uint("user_id", drift.UserID),
+			zap.Int("balance", drift.Balance),
+			zap.Int("ledger_total", drift.LedgerTotal))
+
+		if !fix {
+			continue
+		}
+
+		total, err := r.repo.RecalculateXPBalance(ctx, drift.UserID)
+		if err != nil {
+			return report, err
+		}
+		report.Fixed++
+
+		if r.eventBus != nil {
+			r.eventBus.Publish(eventbus.Event{
+				Type:   "UserXPReconciled",
+				Source: "users",
+				Data: map[string]interface{}{
+					"userID":          drift.UserID,
+					"previousBalance": drift.Balance,
+					"balance":         total,
+				},
+			})
+		}
+	}
+
+	if len(drifts) > 0 {
+		r.logger.Info("XP balance reconciliation finished",
+			zap.Int("drifts", len(drifts)),
+			zap.Int("fixed", report.Fixed))
+	}
+	return report, nil
+}
@@ -0,0 +1,74 @@
+package users
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database/databasetest"
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	applogger "github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+type recordingBus struct {
+	events []eventbus.Event
+}
+
+func (b *recordingBus) Publish(event eventbus.Event) {
+	b.events = append(b.events, event)
+}
+
+func TestReconciler_Reconcile(t *testing.T) {
+	db := databasetest.New(t)
+	ctx := context.Background()
+	repo := NewRepository(db)
+	log, err := applogger.New()
+	require.NoError(t, err)
+
+	alice := &User{Name: "Alice", Email: "alice@example.com", Nickname: "alice"}
+	bob := &User{Name: "Bob", Email: "bob@example.com", Nickname: "bob"}
+	carol := &User{Name: "Carol", Email: "carol@example.com", Nickname: "carol"}
+	for _, user := range []*User{alice, bob, carol} {
+		require.NoError(t, repo.Create(ctx, user))
+	}
+	require.NoError(t, repo.CreateUserXP(ctx, NewUserXP(alice.ID, XPSourceChallenge, "1", 100)))
+	require.NoError(t, repo.CreateUserXP(ctx, NewUserXP(bob.ID, XPSourceChallenge, "1", 40)))
+
+	// Divergências: saldo alterado, lançamento sem saldo e saldo sem lançamentos
+	require.NoError(t, db.Model(&UserXPBalance{}).Where("user_id = ?", alice.ID).Update("balance", 70).Error)
+	require.NoError(t, db.Delete(&UserXPBalance{}, "user_id = ?", bob.ID).Error)
+	require.NoError(t, db.Create(&UserXPBalance{UserID: carol.ID, Balance: 25}).Error)
+
+	bus := &recordingBus{}
+	reconciler := NewReconciler(repo, log, bus)
+
+	t.Run("should only report without fix", func(t *testing.T) {
+		report, err := reconciler.Reconcile(ctx, false)
+		require.NoError(t, err)
+		assert.Equal(t, []XPBalanceDrift{
+			{UserID: alice.ID, Balance: 70, LedgerTotal: 100},
+			{UserID: bob.ID, Balance: 0, LedgerTotal: 40},
+			{UserID: carol.ID, Balance: 25, LedgerTotal: 0},
+		}, report.Drifts)
+		assert.Zero(t, report.Fixed)
+		assert.Empty(t, bus.events)
+	})
+
+	t.Run("should fix balances from the ledger", func(t *testing.T) {
+		report, err := reconciler.Reconcile(ctx, true)
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Fixed)
+		assert.Len(t, bus.events, 3)
+		assert.Equal(t, "UserXPReconciled", bus.events[0].Type)
+
+		totals, err := repo.GetMultipleUsersXP(ctx, []uint{alice.ID, bob.ID, carol.ID})
+		require.NoError(t, err)
+		assert.Equal(t, map[uint]int{alice.ID: 100, bob.ID: 40, carol.ID: 0}, totals)
+
+		report, err = reconciler.Reconcile(ctx, true)
+		require.NoError(t, err)
+		assert.Empty(t, report.Drifts)
+	})
+}
@@ -2,36 +2,65 @@ package users
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"time"
 
+	"github.com/rafaelcoelhox/labbend/pkg/database"
 	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
 	Create(ctx context.Context, user *User) error
 	GetByID(ctx context.Context, id uint) (*User, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]*User, error)
 	GetByNickname(ctx context.Context, nickname string) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	Update(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id uint) error
 	List(ctx context.Context, limit, offset int) ([]*User, error)
+	ListPaginated(ctx context.Context, page pagination.Page) ([]*User, error)
 	GetUsersWithXP(ctx context.Context, limit, offset int) ([]*UserWithXP, error)
+	GetUsersRankedByXP(ctx context.Context, limit, offset int) ([]*UserWithXP, error)
 
 	CreateUserXP(ctx context.Context, userXP *UserXP) error
 	GetUserTotalXP(ctx context.Context, userID uint) (int, error)
 	GetUserXPHistory(ctx context.Context, userID uint) ([]*UserXP, error)
+	GetUserXPHistoryPaginated(ctx context.Context, userID uint, page pagination.Page) ([]*UserXP, error)
 	GetMultipleUsersXP(ctx context.Context, userIDs []uint) (map[uint]int, error)
 
-	// Métodos transacionais
-	CreateWithTx(ctx context.Context, tx *gorm.DB, user *User) error
-	CreateUserXPWithTx(ctx context.Context, tx *gorm.DB, userXP *UserXP) error
-	GetByIDWithTx(ctx context.Context, tx *gorm.DB, id uint) (*User, error)
-	GetByNicknameWithTx(ctx context.Context, tx *gorm.DB, nickname string) (*User, error)
-	UpdateWithTx(ctx context.Context, tx *gorm.DB, user *User) error
-	DeleteWithTx(ctx context.Context, tx *gorm.DB, id uint) error
-	RemoveUserXPWithTx(ctx context.Context, tx *gorm.DB, userID uint, sourceType, sourceID string, amount int) error
+	// Regras da economia de XP
+	GetXPGrantsSince(ctx context.Context, userID uint, sourceType string, since time.Time) ([]*UserXP, error)
+	GetSourceXPNet(ctx context.Context, userID uint, sourceType, sourceID string) (int, error)
+	LockXPSource(ctx context.Context, userID uint, sourceType string) error
+	ListExpiredXP(ctx context.Context, now time.Time, limit int) ([]*UserXP, error)
+	ExpireXP(ctx context.Context, grant *UserXP, now time.Time) (int, error)
+
+	// Reconciliação do saldo materializado
+	FindXPBalanceDrift(ctx context.Context) ([]XPBalanceDrift, error)
+	RecalculateXPBalance(ctx context.Context, userID uint) (int, error)
+}
+
+// userKeyset - ordenação das listagens de usuários por cursor (mais recentes primeiro)
+var userKeyset = pagination.Keyset{
+	Name:       "users.created_at",
+	Column:     "users.created_at",
+	IDColumn:   "users.id",
+	Kind:       pagination.KindTime,
+	Descending: true,
+}
+
+// userXPKeyset - ordenação do histórico de XP por cursor (mais recentes primeiro)
+var userXPKeyset = pagination.Keyset{
+	Name:       "user_xp.created_at",
+	Column:     "user_xp.created_at",
+	IDColumn:   "user_xp.id",
+	Kind:       pagination.KindTime,
+	Descending: true,
 }
 
 type repository struct {
@@ -44,15 +73,25 @@ func NewRepository(db *gorm.DB) Repository {
 
 // === USER OPERATIONS ===
 
+// userWriteError - violações dos índices únicos de nickname e email viram
+// AlreadyExists; o índice de nickname fecha a corrida entre a verificação do
+// service e o INSERT/UPDATE
+func userWriteError(err error, user *User) error {
+	if database.IsUniqueViolation(err, "idx_users_nickname", "users.nickname") {
+		return errors.AlreadyExists("user", "nickname", user.Nickname)
+	}
+	if database.IsUniqueViolation(err) {
+		return errors.AlreadyExists("user", "email", user.Email)
+	}
+	return errors.Internal(err)
+}
+
 func (r *repository) Create(ctx context.Context, user *User) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
-		if errors.Is(err, gorm.ErrDuplicatedKey) {
-			return errors.AlreadyExists("user", "email", user.Email)
-		}
-		return errors.Internal(err)
+	if err := database.FromContext(ctx, r.db).Create(user).Error; err != nil {
+		return userWriteError(err, user)
 	}
 	return nil
 }
@@ -62,7 +101,7 @@ func (r *repository) GetByID(ctx context.Context, id uint) (*User, error) {
 	defer cancel()
 
 	var user User
-	err := r.db.WithContext(ctx).First(&user, id).Error
+	err := database.FromContext(ctx, r.db).Scopes(database.ReadReplica).First(&user, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.NotFound("user", id)
@@ -72,12 +111,28 @@ func (r *repository) GetByID(ctx context.Context, id uint) (*User, error) {
 	return &user, nil
 }
 
+// GetByIDs - busca vários usuários em uma única query (IDs inexistentes são ignorados)
+func (r *repository) GetByIDs(ctx context.Context, ids []uint) ([]*User, error) {
+	if len(ids) == 0 {
+		return []*User{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var users []*User
+	if err := database.FromContext(ctx, r.db).Scopes(database.ReadReplica).Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, errors.Internal(err)
+	}
+	return users, nil
+}
+
 func (r *repository) GetByNickname(ctx context.Context, nickname string) (*User, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var user User
-	err := r.db.WithContext(ctx).Where("nickname = ?", nickname).First(&user).Error
+	err := database.FromContext(ctx, r.db).Where("nickname = ?", nickname).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.NotFound("user", nickname)
@@ -92,7 +147,7 @@ func (r *repository) GetByEmail(ctx context.Context, email string) (*User, error
 	defer cancel()
 
 	var user User
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	err := database.FromContext(ctx, r.db).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.NotFound("user", email)
@@ -106,9 +161,8 @@ func (r *repository) Update(ctx context.Context, user *User) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	err := r.db.WithContext(ctx).Save(user).Error
-	if err != nil {
-		return errors.Internal(err)
+	if err := database.FromContext(ctx, r.db).Save(user).Error; err != nil {
+		return userWriteError(err, user)
 	}
 	return nil
 }
@@ -117,7 +171,7 @@ func (r *repository) Delete(ctx context.Context, id uint) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	err := r.db.WithContext(ctx).Delete(&User{}, id).Error
+	err := database.FromContext(ctx, r.db).Delete(&User{}, id).Error
 	if err != nil {
 		return errors.Internal(err)
 	}
@@ -129,7 +183,8 @@ func (r *repository) List(ctx context.Context, limit, offset int) ([]*User, erro
 	defer cancel()
 
 	var users []*User
-	err := r.db.WithContext(ctx).
+	err := database.FromContext(ctx, r.db).
+		Scopes(database.ReadReplica).
 		Limit(limit).
 		Offset(offset).
 		Order("created_at DESC").
@@ -140,23 +195,49 @@ func (r *repository) List(ctx context.Context, limit, offset int) ([]*User, erro
 	return users, nil
 }
 
-// GetUsersWithXP - otimizada para evitar N+1 queries
+// ListPaginated - listagem por keyset; retorna até page.Limit+1 usuários
+func (r *repository) ListPaginated(ctx context.Context, page pagination.Page) ([]*User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query, err := userKeyset.Apply(database.FromContext(ctx, r.db).Scopes(database.ReadReplica).Model(&User{}), page)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*User
+	if err := query.Find(&users).Error; err != nil {
+		return nil, errors.Internal(err)
+	}
+	return users, nil
+}
+
+// GetUsersWithXP - usuários mais recentes com o saldo materializado (um único JOIN)
 func (r *repository) GetUsersWithXP(ctx context.Context, limit, offset int) ([]*UserWithXP, error) {
+	return r.listUsersWithXP(ctx, "users.created_at DESC", limit, offset)
+}
+
+// GetUsersRankedByXP - ranking pelo saldo (desempate pelo usuário mais antigo)
+func (r *repository) GetUsersRankedByXP(ctx context.Context, limit, offset int) ([]*UserWithXP, error) {
+	return r.listUsersWithXP(ctx, "total_xp DESC, users.id ASC", limit, offset)
+}
+
+func (r *repository) listUsersWithXP(ctx context.Context, order string, limit, offset int) ([]*UserWithXP, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	// Query otimizada com LEFT JOIN para buscar usuários e XP de uma vez
 	var results []struct {
 		User
 		TotalXP int `gorm:"column:total_xp"`
 	}
 
-	err := r.db.WithContext(ctx).
+	err := database.FromContext(ctx, r.db).
+		Scopes(database.ReadReplica).
 		Table("users").
-		Select("users.*, COALESCE(SUM(user_xp.amount), 0) as total_xp").
-		Joins("LEFT JOIN user_xp ON users.id = user_xp.user_id").
-		Group("users.id").
-		Order("users.created_at DESC").
+		Select("users.*, COALESCE(user_xp_balance.balance, 0) as total_xp").
+		Joins("LEFT JOIN user_xp_balance ON users.id = user_xp_balance.user_id").
+		Where("users.deleted_at IS NULL").
+		Order(order).
 		Limit(limit).
 		Offset(offset).
 		Scan(&results).Error
@@ -182,7 +263,25 @@ func (r *repository) CreateUserXP(ctx context.Context, userXP *UserXP) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	if err := r.db.WithContext(ctx).Create(userXP).Error; err != nil {
+	return insertXP(database.FromContext(ctx, r.db), userXP)
+}
+
+// insertXP - grava o lançamento e soma o valor ao saldo na mesma transação
+// (savepoint quando db já está em uma)
+func insertXP(db *gorm.DB, userXP *UserXP) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(userXP).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"balance":    gorm.Expr("user_xp_balance.balance + excluded.balance"),
+				"updated_at": gorm.Expr("excluded.updated_at"),
+			}),
+		}).Create(&UserXPBalance{UserID: userXP.UserID, Balance: userXP.Amount}).Error
+	})
+	if err != nil {
 		return errors.Internal(err)
 	}
 	return nil
@@ -192,11 +291,12 @@ func (r *repository) GetUserTotalXP(ctx context.Context, userID uint) (int, erro
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	// Sem linha no saldo o usuário ainda não recebeu XP
 	var total int64
-	err := r.db.WithContext(ctx).
-		Model(&UserXP{}).
+	err := database.FromContext(ctx, r.db).
+		Model(&UserXPBalance{}).
 		Where("user_id = ?", userID).
-		Select("COALESCE(SUM(amount), 0)").
+		Select("balance").
 		Scan(&total).Error
 
 	if err != nil {
@@ -210,7 +310,7 @@ func (r *repository) GetUserXPHistory(ctx context.Context, userID uint) ([]*User
 	defer cancel()
 
 	var xpHistory []*UserXP
-	err := r.db.WithContext(ctx).
+	err := database.FromContext(ctx, r.db).
 		Where("user_id = ?", userID).
 		Order("created_at DESC").
 		Find(&xpHistory).Error
@@ -221,7 +321,24 @@ func (r *repository) GetUserXPHistory(ctx context.Context, userID uint) ([]*User
 	return xpHistory, nil
 }
 
-// GetMultipleUsersXP - otimizada para buscar XP de múltiplos usuários de uma vez
+// GetUserXPHistoryPaginated - histórico de XP por keyset; retorna até page.Limit+1 registros
+func (r *repository) GetUserXPHistoryPaginated(ctx context.Context, userID uint, page pagination.Page) ([]*UserXP, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query, err := userXPKeyset.Apply(database.FromContext(ctx, r.db).Model(&UserXP{}).Where("user_id = ?", userID), page)
+	if err != nil {
+		return nil, err
+	}
+
+	var xpHistory []*UserXP
+	if err := query.Find(&xpHistory).Error; err != nil {
+		return nil, errors.Internal(err)
+	}
+	return xpHistory, nil
+}
+
+// GetMultipleUsersXP - saldos de vários usuários em uma única query
 func (r *repository) GetMultipleUsersXP(ctx context.Context, userIDs []uint) (map[uint]int, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -235,11 +352,10 @@ func (r *repository) GetMultipleUsersXP(ctx context.Context, userIDs []uint) (ma
 		TotalXP int  `gorm:"column:total_xp"`
 	}
 
-	err := r.db.WithContext(ctx).
-		Model(&UserXP{}).
-		Select("user_id, COALESCE(SUM(amount), 0) as total_xp").
+	err := database.FromContext(ctx, r.db).
+		Model(&UserXPBalance{}).
+		Select("user_id, balance as total_xp").
 		Where("user_id IN ?", userIDs).
-		Group("user_id").
 		Scan(&results).Error
 
 	if err != nil {
@@ -261,96 +377,199 @@ func (r *repository) GetMultipleUsersXP(ctx context.Context, userIDs []uint) (ma
 	return xpMap, nil
 }
 
-// Métodos transacionais
-func (r *repository) CreateWithTx(ctx context.Context, tx *gorm.DB, user *User) error {
+// GetXPGrantsSince - lançamentos positivos da fonte a partir de since (limite
+// diário e sequência das regras de XP)
+func (r *repository) GetXPGrantsSince(ctx context.Context, userID uint, sourceType string, since time.Time) ([]*UserXP, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	if err := tx.WithContext(ctx).Create(user).Error; err != nil {
-		if errors.Is(err, gorm.ErrDuplicatedKey) {
-			return errors.AlreadyExists("user", "email", user.Email)
-		}
-		return errors.Internal(err)
+	return getXPGrantsSince(database.FromContext(ctx, r.db), userID, sourceType, since)
+}
+
+func getXPGrantsSince(db *gorm.DB, userID uint, sourceType string, since time.Time) ([]*UserXP, error) {
+	var grants []*UserXP
+	err := db.
+		Select("id", "amount", "created_at").
+		Where("user_id = ? AND source_type = ? AND amount > 0 AND created_at >= ?", userID, sourceType, since).
+		Order("created_at").
+		Find(&grants).Error
+	if err != nil {
+		return nil, errors.Internal(err)
 	}
-	return nil
+	return grants, nil
 }
 
-func (r *repository) CreateUserXPWithTx(ctx context.Context, tx *gorm.DB, userXP *UserXP) error {
+// LockXPSource - lock por (usuário, fonte) até o fim da transação em ctx.
+// Serializa as concessões que leem o histórico antes de gravar (limite
+// diário, sequência, líquido da fonte). No SQLite a escrita já é serializada
+// pelo próprio banco
+func (r *repository) LockXPSource(ctx context.Context, userID uint, sourceType string) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	if err := tx.WithContext(ctx).Create(userXP).Error; err != nil {
+	if err := lockXPSource(database.FromContext(ctx, r.db), userID, sourceType); err != nil {
 		return errors.Internal(err)
 	}
 	return nil
 }
 
-func (r *repository) GetByIDWithTx(ctx context.Context, tx *gorm.DB, id uint) (*User, error) {
+func lockXPSource(db *gorm.DB, userID uint, sourceType string) error {
+	if database.IsSQLite(db) {
+		return nil
+	}
+	key := fmt.Sprintf("user_xp:%d:%s", userID, sourceType)
+	return db.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", key).Error
+}
+
+// GetSourceXPNet - XP líquido do usuário para uma fonte (concessões menos
+// remoções e expirações)
+func (r *repository) GetSourceXPNet(ctx context.Context, userID uint, sourceType, sourceID string) (int, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	var user User
-	err := tx.WithContext(ctx).First(&user, id).Error
+	return getSourceXPNet(database.FromContext(ctx, r.db), userID, sourceType, sourceID)
+}
+
+func getSourceXPNet(db *gorm.DB, userID uint, sourceType, sourceID string) (int, error) {
+	var net int64
+	err := db.
+		Model(&UserXP{}).
+		Where("user_id = ? AND source_type = ? AND source_id = ?", userID, sourceType, sourceID).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&net).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.NotFound("user", id)
-		}
-		return nil, errors.Internal(err)
+		return 0, errors.Internal(err)
 	}
-	return &user, nil
+	return int(net), nil
 }
 
-func (r *repository) GetByNicknameWithTx(ctx context.Context, tx *gorm.DB, nickname string) (*User, error) {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+// ListExpiredXP - concessões vencidas em now que ainda não foram expiradas
+func (r *repository) ListExpiredXP(ctx context.Context, now time.Time, limit int) ([]*UserXP, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	var user User
-	err := tx.WithContext(ctx).Where("nickname = ?", nickname).First(&user).Error
+	var grants []*UserXP
+	err := database.FromContext(ctx, r.db).
+		Where("expires_at <= ? AND expired_at IS NULL AND amount > 0", now).
+		Order("expires_at, id").
+		Limit(limit).
+		Find(&grants).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.NotFound("user", nickname)
-		}
 		return nil, errors.Internal(err)
 	}
-	return &user, nil
+	return grants, nil
 }
 
-func (r *repository) UpdateWithTx(ctx context.Context, tx *gorm.DB, user *User) error {
+// ExpireXP - marca grant como expirado e lança o valor negativo na mesma fonte,
+// limitado ao XP líquido ainda existente (remoções já feitas não são
+// descontadas duas vezes). Retorna o XP expirado; 0 se outra execução já o fez.
+// A fonte é travada (LockXPSource) antes da leitura do líquido, então uma
+// remoção concorrente entra antes ou depois da expiração, nunca no meio
+func (r *repository) ExpireXP(ctx context.Context, grant *UserXP, now time.Time) (int, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	if err := tx.WithContext(ctx).Save(user).Error; err != nil {
-		return errors.Internal(err)
+	expired := 0
+	err := database.FromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := lockXPSource(tx, grant.UserID, grant.SourceType); err != nil {
+			return err
+		}
+
+		result := tx.Model(&UserXP{}).
+			Where("id = ? AND expired_at IS NULL", grant.ID).
+			Update("expired_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		net, err := getSourceXPNet(tx, grant.UserID, grant.SourceType, grant.SourceID)
+		if err != nil {
+			return err
+		}
+		expired = max(min(grant.Amount, net), 0)
+		if expired == 0 {
+			return nil
+		}
+
+		breakdown, err := json.Marshal(XPBreakdown{BaseAmount: -expired, FinalAmount: -expired, ExpiryOf: grant.ID})
+		if err != nil {
+			return err
+		}
+		return insertXP(tx, &UserXP{
+			UserID:     grant.UserID,
+			SourceType: grant.SourceType,
+			SourceID:   grant.SourceID,
+			Amount:     -expired,
+			CreatedAt:  now,
+			Breakdown:  breakdown,
+		})
+	})
+	if err != nil {
+		return 0, errors.Internal(err)
 	}
-	return nil
+	return expired, nil
 }
 
-func (r *repository) DeleteWithTx(ctx context.Context, tx *gorm.DB, id uint) error {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+// === RECONCILIAÇÃO ===
+
+// FindXPBalanceDrift - usuários cujo saldo difere da soma do ledger, incluindo
+// lançamentos sem saldo e saldos sem lançamentos
+func (r *repository) FindXPBalanceDrift(ctx context.Context) ([]XPBalanceDrift, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
 
-	if err := tx.WithContext(ctx).Delete(&User{}, id).Error; err != nil {
-		return errors.Internal(err)
+	var drifts []XPBalanceDrift
+	err := database.FromContext(ctx, r.db).Raw(`
+		SELECT ledger.user_id, COALESCE(b.balance, 0) AS balance, ledger.total AS ledger_total
+		FROM (SELECT user_id, SUM(amount) AS total FROM user_xp GROUP BY user_id) ledger
+		LEFT JOIN user_xp_balance b ON b.user_id = ledger.user_id
+		WHERE COALESCE(b.balance, 0) <> ledger.total
+		UNION ALL
+		SELECT b.user_id, b.balance, 0
+		FROM user_xp_balance b
+		WHERE b.balance <> 0 AND NOT EXISTS (SELECT 1 FROM user_xp x WHERE x.user_id = b.user_id)
+		ORDER BY user_id`).
+		Scan(&drifts).Error
+	if err != nil {
+		return nil, errors.Internal(err)
 	}
-	return nil
+	return drifts, nil
 }
 
-func (r *repository) RemoveUserXPWithTx(ctx context.Context, tx *gorm.DB, userID uint, sourceType, sourceID string, amount int) error {
+// RecalculateXPBalance - regrava o saldo com a soma do ledger. A linha do saldo
+// é travada antes da soma, então lançamentos concorrentes entram depois dela
+func (r *repository) RecalculateXPBalance(ctx context.Context, userID uint) (int, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	// Criar registro de XP negativo para compensação
-	userXP := &UserXP{
-		UserID:     userID,
-		SourceType: sourceType,
-		SourceID:   sourceID,
-		Amount:     -amount, // Negativo para compensação
-		CreatedAt:  time.Now(),
-	}
+	var total int64
+	err := database.FromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		locking := tx
+		if !database.IsSQLite(tx) {
+			locking = tx.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+		var current []UserXPBalance
+		if err := locking.Where("user_id = ?", userID).Find(&current).Error; err != nil {
+			return err
+		}
 
-	if err := tx.WithContext(ctx).Create(userXP).Error; err != nil {
-		return errors.Internal(err)
-	}
+		if err := tx.Model(&UserXP{}).
+			Where("user_id = ?", userID).
+			Select("COALESCE(SUM(amount), 0)").
+			Scan(&total).Error; err != nil {
+			return err
+		}
 
-	return nil
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"balance", "updated_at"}),
+		}).Create(&UserXPBalance{UserID: userID, Balance: int(total)}).Error
+	})
+	if err != nil {
+		return 0, errors.Internal(err)
+	}
+	return int(total), nil
 }
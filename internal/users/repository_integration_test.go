@@ -3,6 +3,7 @@ package users
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -15,11 +16,22 @@ import (
 	"gorm.io/gorm/logger"
 
 	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/database/databasetest"
 	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	applogger "github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
 )
 
-// setupTestDB cria um container PostgreSQL para testes
+// setupTestDB cria um banco SQLite em memória; com TEST_DATABASE=postgres os
+// testes rodam em um container PostgreSQL
 func setupTestDB(t *testing.T) (*gorm.DB, func()) {
+	if os.Getenv("TEST_DATABASE") != database.DriverPostgres {
+		return databasetest.New(t), func() {}
+	}
+	if testing.Short() {
+		t.Skip("Skipping PostgreSQL integration test in short mode")
+	}
+
 	ctx := context.Background()
 
 	// Criar container PostgreSQL
@@ -75,10 +87,6 @@ func setupTestDB(t *testing.T) (*gorm.DB, func()) {
 }
 
 func TestUserRepository_Integration_Create(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test in short mode")
-	}
-
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
 
@@ -97,10 +105,6 @@ func TestUserRepository_Integration_Create(t *testing.T) {
 }
 
 func TestUserRepository_Integration_GetByID(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test in short mode")
-	}
-
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
 
@@ -122,10 +126,6 @@ func TestUserRepository_Integration_GetByID(t *testing.T) {
 }
 
 func TestUserRepository_Integration_GetByEmail(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test in short mode")
-	}
-
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
 
@@ -147,10 +147,6 @@ func TestUserRepository_Integration_GetByEmail(t *testing.T) {
 }
 
 func TestUserRepository_Integration_Update(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test in short mode")
-	}
-
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
 
@@ -176,10 +172,6 @@ func TestUserRepository_Integration_Update(t *testing.T) {
 }
 
 func TestUserRepository_Integration_Delete(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test in short mode")
-	}
-
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
 
@@ -204,10 +196,6 @@ func TestUserRepository_Integration_Delete(t *testing.T) {
 }
 
 func TestUserRepository_Integration_List(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test in short mode")
-	}
-
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
 
@@ -215,9 +203,9 @@ func TestUserRepository_Integration_List(t *testing.T) {
 
 	// Criar múltiplos usuários
 	users := []*User{
-		{Name: "User 1", Email: "user1@example.com"},
-		{Name: "User 2", Email: "user2@example.com"},
-		{Name: "User 3", Email: "user3@example.com"},
+		{Name: "User 1", Email: "user1@example.com", Nickname: "user1"},
+		{Name: "User 2", Email: "user2@example.com", Nickname: "user2"},
+		{Name: "User 3", Email: "user3@example.com", Nickname: "user3"},
 	}
 
 	for _, user := range users {
@@ -236,11 +224,45 @@ func TestUserRepository_Integration_List(t *testing.T) {
 	assert.Len(t, result, 1)
 }
 
-func TestUserRepository_Integration_UserXP(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test in short mode")
+func TestUserRepository_Integration_ListPaginated(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	log, err := applogger.New()
+	require.NoError(t, err)
+	service := NewService(repo, log, nil, database.NewTxManager(db), nil, XPRules{})
+
+	for i := 1; i <= 5; i++ {
+		err = repo.Create(context.Background(), &User{
+			Name:     fmt.Sprintf("User %d", i),
+			Email:    fmt.Sprintf("user%d@example.com", i),
+			Nickname: fmt.Sprintf("user%d", i),
+		})
+		require.NoError(t, err)
 	}
 
+	first := 2
+	page1, err := service.ListUsersConnection(context.Background(), pagination.Args{First: &first})
+	require.NoError(t, err)
+	require.Len(t, page1.Edges, 2)
+	assert.True(t, page1.PageInfo.HasNextPage)
+
+	// Inserção concorrente não desloca a próxima página
+	require.NoError(t, repo.Create(context.Background(), &User{Name: "Novo", Email: "novo@example.com", Nickname: "novo"}))
+
+	page2, err := service.ListUsersConnection(context.Background(), pagination.Args{First: &first, After: *page1.PageInfo.EndCursor})
+	require.NoError(t, err)
+	require.Len(t, page2.Edges, 2)
+	assert.Equal(t, page1.Edges[1].Node.ID-1, page2.Edges[0].Node.ID)
+
+	// Voltando a partir da segunda página retorna a primeira
+	back, err := service.ListUsersConnection(context.Background(), pagination.Args{Last: &first, Before: *page2.PageInfo.StartCursor})
+	require.NoError(t, err)
+	assert.Equal(t, page1.Nodes(), back.Nodes())
+}
+
+func TestUserRepository_Integration_UserXP(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
 
@@ -298,8 +320,9 @@ func TestUserRepository_Integration_UserXP(t *testing.T) {
 	t.Run("should get multiple users XP", func(t *testing.T) {
 		// Criar segundo usuário
 		user2 := &User{
-			Name:  "Gamer User 2",
-			Email: "gamer2@example.com",
+			Name:     "Gamer User 2",
+			Email:    "gamer2@example.com",
+			Nickname: "gamer2",
 		}
 		err = repo.Create(context.Background(), user2)
 		require.NoError(t, err)
@@ -325,3 +348,149 @@ func TestUserRepository_Integration_UserXP(t *testing.T) {
 		assert.Equal(t, 200, usersXP[user2.ID])
 	})
 }
+
+func TestUserRepository_Integration_XPBalance(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	txManager := database.NewTxManager(db)
+	ctx := context.Background()
+
+	alice := &User{Name: "Alice", Email: "alice@example.com", Nickname: "alice"}
+	bob := &User{Name: "Bob", Email: "bob@example.com", Nickname: "bob"}
+	carol := &User{Name: "Carol", Email: "carol@example.com", Nickname: "carol"}
+	for _, user := range []*User{alice, bob, carol} {
+		require.NoError(t, repo.Create(ctx, user))
+	}
+
+	require.NoError(t, repo.CreateUserXP(ctx, NewUserXP(alice.ID, XPSourceChallenge, "1", 100)))
+	require.NoError(t, repo.CreateUserXP(ctx, NewUserXP(bob.ID, XPSourceChallenge, "1", 300)))
+	require.NoError(t, repo.CreateUserXP(ctx, NewUserXP(alice.ID, XPSourceChallenge, "2", 50)))
+
+	t.Run("should keep balance with the ledger", func(t *testing.T) {
+		var balance UserXPBalance
+		require.NoError(t, db.First(&balance, "user_id = ?", alice.ID).Error)
+		assert.Equal(t, 150, balance.Balance)
+
+		err := txManager.InTransaction(ctx, func(ctx context.Context) error {
+			return repo.CreateUserXP(ctx, NewUserXP(bob.ID, XPSourceChallenge, "1", -100))
+		})
+		require.NoError(t, err)
+
+		total, err := repo.GetUserTotalXP(ctx, bob.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 200, total)
+	})
+
+	t.Run("should roll back balance with the ledger", func(t *testing.T) {
+		err := txManager.InTransaction(ctx, func(ctx context.Context) error {
+			require.NoError(t, repo.CreateUserXP(ctx, NewUserXP(carol.ID, XPSourceChallenge, "3", 999)))
+			return assert.AnError
+		})
+		require.ErrorIs(t, err, assert.AnError)
+
+		total, err := repo.GetUserTotalXP(ctx, carol.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+	})
+
+	t.Run("should rank users by balance", func(t *testing.T) {
+		ranking, err := repo.GetUsersRankedByXP(ctx, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, ranking, 3)
+		assert.Equal(t, bob.ID, ranking[0].User.ID)
+		assert.Equal(t, 200, ranking[0].TotalXP)
+		assert.Equal(t, alice.ID, ranking[1].User.ID)
+		assert.Equal(t, carol.ID, ranking[2].User.ID)
+		assert.Equal(t, 0, ranking[2].TotalXP)
+	})
+}
+
+func TestUserRepository_Integration_ExpireXP(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	txManager := database.NewTxManager(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	newExpiredGrant := func(t *testing.T, userID uint, sourceID string) *UserXP {
+		grant := NewUserXP(userID, XPSourceChallenge, sourceID, 100)
+		expiresAt := now.Add(-time.Hour)
+		grant.ExpiresAt = &expiresAt
+		require.NoError(t, repo.CreateUserXP(ctx, grant))
+		return grant
+	}
+
+	user := &User{Name: "Alice", Email: "alice@example.com", Nickname: "alice"}
+	require.NoError(t, repo.Create(ctx, user))
+
+	t.Run("should cap expiry at the source net", func(t *testing.T) {
+		grant := newExpiredGrant(t, user.ID, "1")
+		require.NoError(t, repo.CreateUserXP(ctx, NewUserXP(user.ID, XPSourceChallenge, "1", -60)))
+
+		expired, err := repo.ExpireXP(ctx, grant, now)
+		require.NoError(t, err)
+		assert.Equal(t, 40, expired)
+
+		// Segunda execução não expira de novo
+		expired, err = repo.ExpireXP(ctx, grant, now)
+		require.NoError(t, err)
+		assert.Zero(t, expired)
+	})
+
+	t.Run("should wait for a concurrent removal holding the source lock", func(t *testing.T) {
+		if database.IsSQLite(db) {
+			t.Skip("SQLite serializes writes; the source lock only applies to PostgreSQL")
+		}
+		grant := newExpiredGrant(t, user.ID, "2")
+
+		locked := make(chan struct{})
+		release := make(chan struct{})
+		removal := make(chan error, 1)
+		go func() {
+			removal <- txManager.InTransaction(ctx, func(ctx context.Context) error {
+				if err := repo.LockXPSource(ctx, user.ID, XPSourceChallenge); err != nil {
+					return err
+				}
+				if err := repo.CreateUserXP(ctx, NewUserXP(user.ID, XPSourceChallenge, "2", -100)); err != nil {
+					return err
+				}
+				close(locked)
+				<-release
+				return nil
+			})
+		}()
+		<-locked
+
+		type result struct {
+			expired int
+			err     error
+		}
+		expiry := make(chan result, 1)
+		go func() {
+			expired, err := repo.ExpireXP(ctx, grant, now)
+			expiry <- result{expired, err}
+		}()
+
+		select {
+		case <-expiry:
+			t.Fatal("ExpireXP read the source net while the removal held the lock")
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		close(release)
+		require.NoError(t, <-removal)
+
+		// A remoção já zerou a fonte: nada a expirar
+		res := <-expiry
+		require.NoError(t, res.err)
+		assert.Zero(t, res.expired)
+
+		net, err := repo.GetSourceXPNet(ctx, user.ID, XPSourceChallenge, "2")
+		require.NoError(t, err)
+		assert.Zero(t, net)
+	})
+}
@@ -0,0 +1,169 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
+	"github.com/rafaelcoelhox/labbend/pkg/rest"
+)
+
+// ===== REST ROUTES (/api/v1) =====
+
+// RESTRoutes - endpoints REST de usuários e histórico de XP; as respostas
+// omitem os campos do perfil que o solicitante não pode ver (User.Redacted)
+func RESTRoutes(service Service) []rest.Route {
+	tags := []string{"users"}
+	userID := rest.IDParam("id", "ID do usuário")
+
+	return []rest.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/users",
+			OperationID: "listUsers",
+			Summary:     "Lista usuários (paginação por cursor)",
+			Tags:        tags,
+			Params:      rest.PageParams,
+			Response:    pagination.Connection[*User]{},
+			Handler:     listUsersHandler(service),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/users",
+			OperationID: "createUser",
+			Summary:     "Cria um usuário",
+			Tags:        tags,
+			Body:        CreateUserInput{},
+			Response:    User{},
+			Status:      http.StatusCreated,
+			Handler:     createUserHandler(service),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/users/:id",
+			OperationID: "getUser",
+			Summary:     "Busca um usuário com o XP total",
+			Tags:        tags,
+			Params:      []rest.Param{userID},
+			Response:    UserWithXP{},
+			Handler:     getUserHandler(service),
+		},
+		{
+			Method:      http.MethodPatch,
+			Path:        "/users/:id",
+			OperationID: "updateUser",
+			Summary:     "Atualiza campos de um usuário",
+			Tags:        tags,
+			Params:      []rest.Param{userID},
+			Body:        UpdateUserInput{},
+			Response:    User{},
+			Handler:     updateUserHandler(service),
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/users/:id",
+			OperationID: "deleteUser",
+			Summary:     "Remove um usuário",
+			Tags:        tags,
+			Params:      []rest.Param{userID},
+			Status:      http.StatusNoContent,
+			Handler:     deleteUserHandler(service),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/users/:id/xp-history",
+			OperationID: "listUserXPHistory",
+			Summary:     "Histórico de XP do usuário (paginação por cursor)",
+			Tags:        tags,
+			Params:      append([]rest.Param{userID}, rest.PageParams...),
+			Response:    pagination.Connection[*UserXP]{},
+			Handler:     xpHistoryHandler(service),
+		},
+	}
+}
+
+func listUsersHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		args, err := rest.Page(c)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := service.ListUsersConnection(c.Request.Context(), args)
+		if err != nil {
+			return nil, err
+		}
+		viewer := auth.ViewerFromContext(c.Request.Context())
+		return pagination.Map(conn, func(user *User) *User {
+			return user.Redacted(viewer)
+		}), nil
+	}
+}
+
+func createUserHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		var input CreateUserInput
+		if err := rest.Bind(c, &input); err != nil {
+			return nil, err
+		}
+		return service.CreateUser(c.Request.Context(), input)
+	}
+}
+
+func getUserHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		id, err := rest.PathID(c, "id")
+		if err != nil {
+			return nil, err
+		}
+		userWithXP, err := service.GetUserWithXP(c.Request.Context(), id)
+		if err != nil {
+			return nil, err
+		}
+		userWithXP.User = userWithXP.User.Redacted(auth.ViewerFromContext(c.Request.Context()))
+		return userWithXP, nil
+	}
+}
+
+func updateUserHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		id, err := rest.PathID(c, "id")
+		if err != nil {
+			return nil, err
+		}
+		var input UpdateUserInput
+		if err := rest.Bind(c, &input); err != nil {
+			return nil, err
+		}
+		user, err := service.UpdateUser(c.Request.Context(), id, input)
+		if err != nil {
+			return nil, err
+		}
+		return user.Redacted(auth.ViewerFromContext(c.Request.Context())), nil
+	}
+}
+
+func deleteUserHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		id, err := rest.PathID(c, "id")
+		if err != nil {
+			return nil, err
+		}
+		return nil, service.DeleteUser(c.Request.Context(), id)
+	}
+}
+
+func xpHistoryHandler(service Service) rest.HandlerFunc {
+	return func(c *gin.Context) (interface{}, error) {
+		id, err := rest.PathID(c, "id")
+		if err != nil {
+			return nil, err
+		}
+		args, err := rest.Page(c)
+		if err != nil {
+			return nil, err
+		}
+		return service.GetUserXPHistoryConnection(c.Request.Context(), id, args)
+	}
+}
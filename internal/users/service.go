@@ -2,43 +2,48 @@ package users
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"go.uber.org/zap"
-	"gorm.io/gorm"
 
+	"github.com/rafaelcoelhox/labbend/pkg/cache"
 	"github.com/rafaelcoelhox/labbend/pkg/database"
 	"github.com/rafaelcoelhox/labbend/pkg/errors"
 	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
 	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
+	"github.com/rafaelcoelhox/labbend/pkg/validation"
 )
 
 type EventBus interface {
 	Publish(event eventbus.Event)
-	PublishWithTx(ctx context.Context, tx *gorm.DB, event eventbus.Event) error
 }
 
 type Service interface {
 	CreateUser(ctx context.Context, input CreateUserInput) (*User, error)
 	GetUser(ctx context.Context, id uint) (*User, error)
+	GetUsersByIDs(ctx context.Context, ids []uint) (map[uint]*User, error)
 	GetUserWithXP(ctx context.Context, id uint) (*UserWithXP, error)
 	UpdateUser(ctx context.Context, id uint, input UpdateUserInput) (*User, error)
 	DeleteUser(ctx context.Context, id uint) error
 	ListUsers(ctx context.Context, limit, offset int) ([]*User, error)
 	ListUsersWithXP(ctx context.Context, limit, offset int) ([]*UserWithXP, error)
+	ListLeaderboard(ctx context.Context, limit, offset int) ([]*UserWithXP, error)
+	ListUsersConnection(ctx context.Context, args pagination.Args) (*pagination.Connection[*User], error)
 
 	GiveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error
 	GetUserTotalXP(ctx context.Context, userID uint) (int, error)
+	GetMultipleUsersXP(ctx context.Context, userIDs []uint) (map[uint]int, error)
 	GetUserXPHistory(ctx context.Context, userID uint) ([]*UserXP, error)
-
-	// Métodos transacionais
-	GiveUserXPWithTx(ctx context.Context, tx *gorm.DB, userID uint, sourceType, sourceID string, amount int) error
-	RemoveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error
-	RemoveUserXPWithTx(ctx context.Context, tx *gorm.DB, userID uint, sourceType, sourceID string, amount int) error
+	GetUserXPHistoryConnection(ctx context.Context, userID uint, args pagination.Args) (*pagination.Connection[*UserXP], error)
+	RemoveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) (int, error)
+	RestoreUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error
 }
 
 type UserWithXP struct {
-	User    *User
-	TotalXP int
+	User    *User `json:"user"`
+	TotalXP int   `json:"total_xp"`
 }
 
 type service struct {
@@ -46,29 +51,29 @@ type service struct {
 	logger    logger.Logger
 	eventBus  EventBus
 	txManager *database.TxManager
+	cache     cache.Cache
+	rules     XPRules
 }
 
-func NewService(repo Repository, logger logger.Logger, eventBus EventBus, txManager *database.TxManager) Service {
+// NewService - usersCache guarda usuários e totais de XP (nil desativa); a
+// invalidação vem dos eventos (Module.EventSubscriptions). rules são aplicadas
+// a cada XP concedido (XPRules{} concede o valor informado)
+func NewService(repo Repository, logger logger.Logger, eventBus EventBus, txManager *database.TxManager, usersCache cache.Cache, rules XPRules) Service {
 	return &service{
 		repo:      repo,
 		logger:    logger,
 		eventBus:  eventBus,
 		txManager: txManager,
+		cache:     usersCache,
+		rules:     rules,
 	}
 }
 
 // === USER MANAGEMENT ===
 
 func (s *service) CreateUser(ctx context.Context, input CreateUserInput) (*User, error) {
-	if input.Name == "" {
-		return nil, errors.InvalidInput("name is required")
-	}
-	if input.Email == "" {
-		return nil, errors.InvalidInput("email is required")
-	}
-
-	if input.Nickname == "" {
-		return nil, errors.InvalidInput("nickname is required")
+	if err := validation.Struct(input); err != nil {
+		return nil, err
 	}
 
 	_, err := s.repo.GetByEmail(ctx, input.Email)
@@ -78,6 +83,9 @@ func (s *service) CreateUser(ctx context.Context, input CreateUserInput) (*User,
 	if !errors.Is(err, errors.ErrNotFound) {
 		return nil, err
 	}
+	if err := s.checkNicknameAvailable(ctx, input.Nickname, 0); err != nil {
+		return nil, err
+	}
 
 	user := &User{
 		Name:     input.Name,
@@ -94,14 +102,16 @@ func (s *service) CreateUser(ctx context.Context, input CreateUserInput) (*User,
 		return nil, err
 	}
 
-	s.eventBus.Publish(eventbus.Event{
-		Type:   "UserCreated",
-		Source: "users",
-		Data: map[string]interface{}{
-			"userID": user.ID,
-			"email":  user.Email,
-			"name":   user.Name,
-		},
+	s.txManager.OnCommit(ctx, func(context.Context) {
+		s.eventBus.Publish(eventbus.Event{
+			Type:   "UserCreated",
+			Source: "users",
+			Data: map[string]interface{}{
+				"userID": user.ID,
+				"email":  user.Email,
+				"name":   user.Name,
+			},
+		})
 	})
 
 	s.logger.Info("user created successfully", zap.Uint("user_id", user.ID), zap.String("email", user.Email))
@@ -109,7 +119,7 @@ func (s *service) CreateUser(ctx context.Context, input CreateUserInput) (*User,
 }
 
 func (s *service) GetUser(ctx context.Context, id uint) (*User, error) {
-	user, err := s.repo.GetByID(ctx, id)
+	user, err := s.getCachedUser(ctx, id)
 	if err != nil {
 		s.logger.Error("failed to get user", zap.Error(err), zap.Uint("user_id", id))
 		return nil, err
@@ -117,13 +127,34 @@ func (s *service) GetUser(ctx context.Context, id uint) (*User, error) {
 	return user, nil
 }
 
+func (s *service) GetUsersByIDs(ctx context.Context, ids []uint) (map[uint]*User, error) {
+	users, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("failed to get users", zap.Error(err), zap.Int("count", len(ids)))
+		return nil, err
+	}
+
+	byID := make(map[uint]*User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+	return byID, nil
+}
+
+// getCachedUser - usuário pelo cache (fora de transação) ou pelo repositório
+func (s *service) getCachedUser(ctx context.Context, id uint) (*User, error) {
+	return cache.GetOrLoad(ctx, s.readCache(ctx), userCacheKey(id), 0, func(ctx context.Context) (*User, error) {
+		return s.repo.GetByID(ctx, id)
+	})
+}
+
 func (s *service) GetUserWithXP(ctx context.Context, id uint) (*UserWithXP, error) {
-	user, err := s.repo.GetByID(ctx, id)
+	user, err := s.getCachedUser(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	totalXP, err := s.repo.GetUserTotalXP(ctx, id)
+	totalXP, err := s.GetUserTotalXP(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +166,10 @@ func (s *service) GetUserWithXP(ctx context.Context, id uint) (*UserWithXP, erro
 }
 
 func (s *service) UpdateUser(ctx context.Context, id uint, input UpdateUserInput) (*User, error) {
+	if err := validation.Struct(input); err != nil {
+		return nil, err
+	}
+
 	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -146,16 +181,29 @@ func (s *service) UpdateUser(ctx context.Context, id uint, input UpdateUserInput
 	if input.Email != nil {
 		user.Email = *input.Email
 	}
-	if input.Nickname != nil {
+	if input.Nickname != nil && *input.Nickname != user.Nickname {
+		if err := s.checkNicknameAvailable(ctx, *input.Nickname, user.ID); err != nil {
+			return nil, err
+		}
 		user.Nickname = *input.Nickname
 	}
-
-	if user.Nickname != "" {
-		_, err = s.repo.GetByNickname(ctx, user.Nickname)
-		if err == nil {
-			return nil, errors.AlreadyExists("user", "nickname", user.Nickname)
-		}
-		if !errors.Is(err, errors.ErrNotFound) {
+	if input.Bio != nil {
+		user.Bio = *input.Bio
+	}
+	if input.AvatarURL != nil {
+		user.AvatarURL = *input.AvatarURL
+	}
+	if input.Links != nil {
+		user.Links = *input.Links
+	}
+	if input.Locale != nil {
+		user.Locale = *input.Locale
+	}
+	if input.Timezone != nil {
+		user.Timezone = *input.Timezone
+	}
+	if len(input.Privacy) > 0 {
+		if err := user.SetPrivacy(input.Privacy); err != nil {
 			return nil, err
 		}
 	}
@@ -169,21 +217,40 @@ func (s *service) UpdateUser(ctx context.Context, id uint, input UpdateUserInput
 		return nil, err
 	}
 
-	s.eventBus.Publish(eventbus.Event{
-		Type:   "UserUpdated",
-		Source: "users",
-		Data: map[string]interface{}{
-			"userID":   user.ID,
-			"name":     user.Name,
-			"email":    user.Email,
-			"nickname": user.Nickname,
-		},
+	s.txManager.OnCommit(ctx, func(context.Context) {
+		s.eventBus.Publish(eventbus.Event{
+			Type:   "UserUpdated",
+			Source: "users",
+			Data: map[string]interface{}{
+				"userID":   user.ID,
+				"name":     user.Name,
+				"email":    user.Email,
+				"nickname": user.Nickname,
+			},
+		})
 	})
 
 	s.logger.Info("user updated successfully", zap.Uint("user_id", user.ID))
 	return user, nil
 }
 
+// checkNicknameAvailable - nickname livre ou do próprio usuário ownerID (0 em
+// cadastros). O índice único idx_users_nickname continua valendo para
+// cadastros simultâneos
+func (s *service) checkNicknameAvailable(ctx context.Context, nickname string, ownerID uint) error {
+	existing, err := s.repo.GetByNickname(ctx, nickname)
+	if err == nil {
+		if existing.ID == ownerID {
+			return nil
+		}
+		return errors.AlreadyExists("user", "nickname", nickname)
+	}
+	if !errors.Is(err, errors.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
 func (s *service) DeleteUser(ctx context.Context, id uint) error {
 	_, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -195,12 +262,14 @@ func (s *service) DeleteUser(ctx context.Context, id uint) error {
 		return err
 	}
 
-	s.eventBus.Publish(eventbus.Event{
-		Type:   "UserDeleted",
-		Source: "users",
-		Data: map[string]interface{}{
-			"userID": id,
-		},
+	s.txManager.OnCommit(ctx, func(context.Context) {
+		s.eventBus.Publish(eventbus.Event{
+			Type:   "UserDeleted",
+			Source: "users",
+			Data: map[string]interface{}{
+				"userID": id,
+			},
+		})
 	})
 
 	s.logger.Info("user deleted successfully", zap.Uint("user_id", id))
@@ -227,6 +296,24 @@ func (s *service) ListUsers(ctx context.Context, limit, offset int) ([]*User, er
 	return users, nil
 }
 
+// ListUsersConnection - página de usuários por cursor (estável com inserções concorrentes)
+func (s *service) ListUsersConnection(ctx context.Context, args pagination.Args) (*pagination.Connection[*User], error) {
+	page, err := args.Page()
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.repo.ListPaginated(ctx, page)
+	if err != nil {
+		s.logger.Error("failed to list users", zap.Error(err))
+		return nil, err
+	}
+
+	return pagination.NewConnection(users, page, func(user *User) pagination.Cursor {
+		return userKeyset.Cursor(user.CreatedAt, user.ID)
+	}), nil
+}
+
 // ListUsersWithXP - método otimizado para buscar usuários com XP
 func (s *service) ListUsersWithXP(ctx context.Context, limit, offset int) ([]*UserWithXP, error) {
 	if limit <= 0 {
@@ -248,8 +335,31 @@ func (s *service) ListUsersWithXP(ctx context.Context, limit, offset int) ([]*Us
 	return usersWithXP, nil
 }
 
+// ListLeaderboard - usuários ordenados pelo saldo de XP (maior primeiro)
+func (s *service) ListLeaderboard(ctx context.Context, limit, offset int) ([]*UserWithXP, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	ranking, err := s.repo.GetUsersRankedByXP(ctx, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to list leaderboard", zap.Error(err))
+		return nil, err
+	}
+
+	return ranking, nil
+}
+
 // === XP MANAGEMENT ===
 
+// GiveUserXP - participa da transação em ctx (savepoint), então aprovações
+// gravam decisão e XP juntas; UserXPGranted sai depois do commit
 func (s *service) GiveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error {
 	s.logger.Info("giving XP to user",
 		zap.Uint("user_id", userID),
@@ -261,85 +371,129 @@ func (s *service) GiveUserXP(ctx context.Context, userID uint, sourceType, sourc
 		return errors.InvalidInput("XP amount must be positive")
 	}
 
-	_, err := s.repo.GetByID(ctx, userID)
-	if err != nil {
-		return err
+	return s.txManager.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.GetByID(ctx, userID); err != nil {
+			return err
+		}
+
+		userXP, err := s.applyRules(userID, sourceType, sourceID, amount, func(since time.Time) ([]*UserXP, error) {
+			// Sem o lock, duas concessões concorrentes leem o mesmo histórico
+			// e passam juntas do limite diário
+			if err := s.repo.LockXPSource(ctx, userID, sourceType); err != nil {
+				return nil, err
+			}
+			return s.repo.GetXPGrantsSince(ctx, userID, sourceType, since)
+		})
+		if err != nil {
+			return err
+		}
+		if userXP.Amount == 0 {
+			s.logger.Info("XP not granted: daily cap reached", zap.Uint("user_id", userID), zap.String("source_type", sourceType))
+			return nil
+		}
+
+		if err := s.repo.CreateUserXP(ctx, userXP); err != nil {
+			s.logger.Error("failed to create user XP", zap.Error(err))
+			return err
+		}
+
+		s.txManager.OnCommit(ctx, func(context.Context) {
+			s.eventBus.Publish(eventbus.Event{
+				Type:   "UserXPGranted",
+				Source: "users",
+				Data: map[string]interface{}{
+					"userID":     userID,
+					"sourceType": sourceType,
+					"sourceID":   sourceID,
+					"amount":     userXP.Amount,
+					"baseAmount": amount,
+				},
+			})
+		})
+
+		s.logger.Info("XP granted successfully", zap.Uint("user_id", userID), zap.Int("amount", userXP.Amount))
+		return nil
+	})
+}
+
+// applyRules - lançamento de amount com as regras de XP aplicadas e o cálculo
+// registrado em Breakdown; grantsSince só é chamado quando a fonte tem limite
+// diário ou sequência
+func (s *service) applyRules(userID uint, sourceType, sourceID string, amount int, grantsSince func(since time.Time) ([]*UserXP, error)) (*UserXP, error) {
+	now := time.Now()
+
+	var grants []*UserXP
+	if since, ok := s.rules.HistorySince(sourceType, now); ok {
+		var err error
+		if grants, err = grantsSince(since); err != nil {
+			return nil, err
+		}
 	}
 
-	userXP := NewUserXP(userID, sourceType, sourceID, amount)
-	if err := s.repo.CreateUserXP(ctx, userXP); err != nil {
-		s.logger.Error("failed to create user XP", zap.Error(err))
-		return err
+	breakdown := s.rules.Apply(sourceType, amount, now, grants)
+	data, err := json.Marshal(breakdown)
+	if err != nil {
+		return nil, errors.Internal(err)
 	}
 
-	s.eventBus.Publish(eventbus.Event{
-		Type:   "UserXPGranted",
-		Source: "users",
-		Data: map[string]interface{}{
-			"userID":     userID,
-			"sourceType": sourceType,
-			"sourceID":   sourceID,
-			"amount":     amount,
-		},
-	})
+	userXP := NewUserXP(userID, sourceType, sourceID, breakdown.FinalAmount)
+	userXP.CreatedAt = now
+	userXP.Breakdown = data
+	userXP.ExpiresAt = s.rules.ExpiresAt(sourceType, now)
+	return userXP, nil
+}
 
-	s.logger.Info("XP granted successfully", zap.Uint("user_id", userID), zap.Int("amount", amount))
-	return nil
+// removalAmount - XP a remover de uma fonte. Com regras configuradas o valor
+// concedido pode diferir do informado (multiplicadores, bônus, limite,
+// expiração), então remove-se o líquido ainda existente para a fonte
+func (s *service) removalAmount(amount int, sourceNet func() (int, error)) (int, error) {
+	if s.rules.IsZero() {
+		return amount, nil
+	}
+	net, err := sourceNet()
+	if err != nil {
+		return 0, err
+	}
+	return max(net, 0), nil
 }
 
+// GetUserTotalXP - total pelo cache (fora de transação) ou pelo saldo materializado
 func (s *service) GetUserTotalXP(ctx context.Context, userID uint) (int, error) {
-	return s.repo.GetUserTotalXP(ctx, userID)
+	return cache.GetOrLoad(ctx, s.readCache(ctx), xpTotalCacheKey(userID), 0, func(ctx context.Context) (int, error) {
+		return s.repo.GetUserTotalXP(ctx, userID)
+	})
+}
+
+func (s *service) GetMultipleUsersXP(ctx context.Context, userIDs []uint) (map[uint]int, error) {
+	return s.repo.GetMultipleUsersXP(ctx, userIDs)
 }
 
 func (s *service) GetUserXPHistory(ctx context.Context, userID uint) ([]*UserXP, error) {
 	return s.repo.GetUserXPHistory(ctx, userID)
 }
 
-// Métodos transacionais
-func (s *service) GiveUserXPWithTx(ctx context.Context, tx *gorm.DB, userID uint, sourceType, sourceID string, amount int) error {
-	s.logger.Info("giving XP to user with transaction",
-		zap.Uint("user_id", userID),
-		zap.String("source_type", sourceType),
-		zap.String("source_id", sourceID),
-		zap.Int("amount", amount))
-
-	if amount <= 0 {
-		return errors.InvalidInput("XP amount must be positive")
-	}
-
-	// Verificar se usuário existe
-	_, err := s.repo.GetByIDWithTx(ctx, tx, userID)
+// GetUserXPHistoryConnection - página do histórico de XP por cursor
+func (s *service) GetUserXPHistoryConnection(ctx context.Context, userID uint, args pagination.Args) (*pagination.Connection[*UserXP], error) {
+	page, err := args.Page()
 	if err != nil {
-		return err
-	}
-
-	// Criar XP dentro da transação
-	userXP := NewUserXP(userID, sourceType, sourceID, amount)
-	if err := s.repo.CreateUserXPWithTx(ctx, tx, userXP); err != nil {
-		s.logger.Error("failed to create user XP in transaction", zap.Error(err))
-		return err
+		return nil, err
 	}
 
-	// Publicar evento usando event bus transacional
-	if err := s.eventBus.PublishWithTx(ctx, tx, eventbus.Event{
-		Type:   "UserXPGranted",
-		Source: "users",
-		Data: map[string]interface{}{
-			"userID":     userID,
-			"sourceType": sourceType,
-			"sourceID":   sourceID,
-			"amount":     amount,
-		},
-	}); err != nil {
-		s.logger.Error("failed to publish XP event", zap.Error(err))
-		return err
+	history, err := s.repo.GetUserXPHistoryPaginated(ctx, userID, page)
+	if err != nil {
+		s.logger.Error("failed to get user XP history", zap.Error(err))
+		return nil, err
 	}
 
-	s.logger.Info("XP granted successfully in transaction", zap.Uint("user_id", userID), zap.Int("amount", amount))
-	return nil
+	return pagination.NewConnection(history, page, func(xp *UserXP) pagination.Cursor {
+		return userXPKeyset.Cursor(xp.CreatedAt, xp.ID)
+	}), nil
 }
 
-func (s *service) RemoveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error {
+// RemoveUserXP - lança o valor negativo na fonte e retorna o valor removido
+// (o líquido da fonte quando há regras); como GiveUserXP, participa da
+// transação em ctx
+func (s *service) RemoveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) (int, error) {
 	s.logger.Info("removing XP from user",
 		zap.Uint("user_id", userID),
 		zap.String("source_type", sourceType),
@@ -347,39 +501,51 @@ func (s *service) RemoveUserXP(ctx context.Context, userID uint, sourceType, sou
 		zap.Int("amount", amount))
 
 	if amount <= 0 {
-		return errors.InvalidInput("XP amount must be positive")
+		return 0, errors.InvalidInput("XP amount must be positive")
 	}
 
-	// Verificar se usuário existe
-	_, err := s.repo.GetByID(ctx, userID)
-	if err != nil {
-		return err
-	}
+	var removed int
+	err := s.txManager.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.GetByID(ctx, userID); err != nil {
+			return err
+		}
 
-	// Criar XP negativo para compensação
-	userXP := NewUserXP(userID, sourceType, sourceID, -amount)
-	if err := s.repo.CreateUserXP(ctx, userXP); err != nil {
-		s.logger.Error("failed to create negative user XP", zap.Error(err))
-		return err
-	}
+		var err error
+		removed, err = s.removalAmount(amount, func() (int, error) {
+			if err := s.repo.LockXPSource(ctx, userID, sourceType); err != nil {
+				return 0, err
+			}
+			return s.repo.GetSourceXPNet(ctx, userID, sourceType, sourceID)
+		})
+		if err != nil {
+			return err
+		}
+		if removed == 0 {
+			s.logger.Info("no XP left to remove", zap.Uint("user_id", userID), zap.String("source_type", sourceType), zap.String("source_id", sourceID))
+			return nil
+		}
 
-	s.eventBus.Publish(eventbus.Event{
-		Type:   "UserXPRemoved",
-		Source: "users",
-		Data: map[string]interface{}{
-			"userID":     userID,
-			"sourceType": sourceType,
-			"sourceID":   sourceID,
-			"amount":     amount,
-		},
-	})
+		// Criar XP negativo para compensação
+		if err := s.repo.CreateUserXP(ctx, NewUserXP(userID, sourceType, sourceID, -removed)); err != nil {
+			s.logger.Error("failed to create negative user XP", zap.Error(err))
+			return err
+		}
 
-	s.logger.Info("XP removed successfully", zap.Uint("user_id", userID), zap.Int("amount", amount))
-	return nil
+		s.publishAfterCommit(ctx, "UserXPRemoved", userID, sourceType, sourceID, removed)
+		s.logger.Info("XP removed successfully", zap.Uint("user_id", userID), zap.Int("amount", removed))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
 }
 
-func (s *service) RemoveUserXPWithTx(ctx context.Context, tx *gorm.DB, userID uint, sourceType, sourceID string, amount int) error {
-	s.logger.Info("removing XP from user with transaction",
+// RestoreUserXP - devolve XP estornado por RemoveUserXP (compensações de
+// saga). O lançamento vai direto ao ledger, sem multiplicadores, limite
+// diário nem expiração: o valor devolvido é exatamente o removido
+func (s *service) RestoreUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error {
+	s.logger.Info("restoring XP to user",
 		zap.Uint("user_id", userID),
 		zap.String("source_type", sourceType),
 		zap.String("source_id", sourceID),
@@ -389,33 +555,32 @@ func (s *service) RemoveUserXPWithTx(ctx context.Context, tx *gorm.DB, userID ui
 		return errors.InvalidInput("XP amount must be positive")
 	}
 
-	// Verificar se usuário existe
-	_, err := s.repo.GetByIDWithTx(ctx, tx, userID)
-	if err != nil {
-		return err
-	}
-
-	// Remover XP dentro da transação
-	if err := s.repo.RemoveUserXPWithTx(ctx, tx, userID, sourceType, sourceID, amount); err != nil {
-		s.logger.Error("failed to remove user XP in transaction", zap.Error(err))
-		return err
-	}
+	return s.txManager.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.GetByID(ctx, userID); err != nil {
+			return err
+		}
+		if err := s.repo.CreateUserXP(ctx, NewUserXP(userID, sourceType, sourceID, amount)); err != nil {
+			s.logger.Error("failed to restore user XP", zap.Error(err))
+			return err
+		}
 
-	// Publicar evento usando event bus transacional
-	if err := s.eventBus.PublishWithTx(ctx, tx, eventbus.Event{
-		Type:   "UserXPRemoved",
-		Source: "users",
-		Data: map[string]interface{}{
-			"userID":     userID,
-			"sourceType": sourceType,
-			"sourceID":   sourceID,
-			"amount":     amount,
-		},
-	}); err != nil {
-		s.logger.Error("failed to publish XP removal event", zap.Error(err))
-		return err
-	}
+		s.publishAfterCommit(ctx, "UserXPRestored", userID, sourceType, sourceID, amount)
+		return nil
+	})
+}
 
-	s.logger.Info("XP removed successfully in transaction", zap.Uint("user_id", userID), zap.Int("amount", amount))
-	return nil
+// publishAfterCommit - evento de XP publicado depois do commit da transação em ctx
+func (s *service) publishAfterCommit(ctx context.Context, eventType string, userID uint, sourceType, sourceID string, amount int) {
+	s.txManager.OnCommit(ctx, func(context.Context) {
+		s.eventBus.Publish(eventbus.Event{
+			Type:   eventType,
+			Source: "users",
+			Data: map[string]interface{}{
+				"userID":     userID,
+				"sourceType": sourceType,
+				"sourceID":   sourceID,
+				"amount":     amount,
+			},
+		})
+	})
 }
@@ -3,11 +3,13 @@ package users_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/rafaelcoelhox/labbend/internal/mocks"
 	"github.com/rafaelcoelhox/labbend/internal/users"
 	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/database/databasetest"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
@@ -31,7 +33,7 @@ func TestUserService_WithGomock(t *testing.T) {
 	assert.NotNil(t, mockLogger)
 	assert.NotNil(t, mockEventBus)
 
-	service := users.NewService(mockRepo, mockLogger, mockEventBus, txManager)
+	service := users.NewService(mockRepo, mockLogger, mockEventBus, txManager, nil, users.XPRules{})
 
 	// Exemplo de configuração de expectativas
 	mockRepo.EXPECT().
@@ -53,3 +55,31 @@ func TestUserService_WithGomock(t *testing.T) {
 
 	t.Log("✅ Mocks gerados pelo gomock funcionam corretamente para users")
 }
+
+func TestUserService_GiveUserXP_LocksDailyCap_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepository(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockEventBus := mocks.NewMockUsersEventBus(ctrl)
+
+	rules := users.XPRules{Sources: map[string]users.SourceRules{users.XPSourceDailyTask: {DailyCap: 100}}}
+	service := users.NewService(mockRepo, mockLogger, mockEventBus, database.NewTxManager(databasetest.New(t)), nil, rules)
+
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockEventBus.EXPECT().Publish(gomock.Any())
+
+	// O histórico do dia só é lido depois do lock da fonte
+	gomock.InOrder(
+		mockRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(&users.User{ID: 1}, nil),
+		mockRepo.EXPECT().LockXPSource(gomock.Any(), uint(1), users.XPSourceDailyTask).Return(nil),
+		mockRepo.EXPECT().GetXPGrantsSince(gomock.Any(), uint(1), users.XPSourceDailyTask, gomock.Any()).
+			Return([]*users.UserXP{{Amount: 80, CreatedAt: time.Now()}}, nil),
+		mockRepo.EXPECT().CreateUserXP(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, userXP *users.UserXP) { assert.Equal(t, 20, userXP.Amount) }).
+			Return(nil),
+	)
+
+	assert.NoError(t, service.GiveUserXP(context.Background(), 1, users.XPSourceDailyTask, "task-1", 50))
+}
@@ -0,0 +1,60 @@
+package users
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/database/databasetest"
+	applogger "github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+func TestService_XPEventsAfterCommit(t *testing.T) {
+	db := databasetest.New(t)
+	ctx := context.Background()
+	repo := NewRepository(db)
+	log, err := applogger.New()
+	require.NoError(t, err)
+
+	user := &User{Name: "Alice", Email: "alice@example.com", Nickname: "alice"}
+	require.NoError(t, repo.Create(ctx, user))
+
+	bus := &recordingBus{}
+	txManager := database.NewTxManager(db)
+	svc := NewService(repo, log, bus, txManager, nil, XPRules{})
+
+	t.Run("should publish only after the caller commits", func(t *testing.T) {
+		err := txManager.InTransaction(ctx, func(ctx context.Context) error {
+			if err := svc.GiveUserXP(ctx, user.ID, XPSourceChallenge, "1", 100); err != nil {
+				return err
+			}
+			if _, err := svc.RemoveUserXP(ctx, user.ID, XPSourceChallenge, "1", 40); err != nil {
+				return err
+			}
+			assert.Empty(t, bus.events, "events must wait for the commit")
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.Len(t, bus.events, 2)
+		assert.Equal(t, "UserXPGranted", bus.events[0].Type)
+		assert.Equal(t, "UserXPRemoved", bus.events[1].Type)
+	})
+
+	t.Run("should not publish nor record XP on rollback", func(t *testing.T) {
+		bus.events = nil
+		err := txManager.InTransaction(ctx, func(ctx context.Context) error {
+			require.NoError(t, svc.GiveUserXP(ctx, user.ID, XPSourceChallenge, "2", 500))
+			return assert.AnError
+		})
+		require.ErrorIs(t, err, assert.AnError)
+
+		assert.Empty(t, bus.events)
+		total, err := repo.GetUserTotalXP(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 60, total)
+	})
+}
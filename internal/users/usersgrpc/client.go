@@ -0,0 +1,72 @@
+package usersgrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/rafaelcoelhox/labbend/internal/users"
+	"github.com/rafaelcoelhox/labbend/internal/users/userspb"
+	"github.com/rafaelcoelhox/labbend/pkg/grpcerrors"
+)
+
+// Client - acesso remoto ao módulo users; implementa challenges.UserService,
+// então o módulo challenges roda igual contra um users service separado.
+// Erros do servidor voltam como erros de pkg/errors (grpcerrors.FromStatus)
+type Client struct {
+	client userspb.UsersServiceClient
+}
+
+// NewClient - cria o client sobre uma conexão gRPC (grpc.NewClient)
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{client: userspb.NewUsersServiceClient(conn)}
+}
+
+func (c *Client) GetUser(ctx context.Context, id uint) (*users.User, error) {
+	resp, err := c.client.GetUser(ctx, &userspb.GetUserRequest{UserId: uint64(id)})
+	if err != nil {
+		return nil, grpcerrors.FromStatus(err)
+	}
+	return fromProto(resp.GetUser()), nil
+}
+
+func (c *Client) GetUserTotalXP(ctx context.Context, userID uint) (int, error) {
+	resp, err := c.client.GetUserTotalXP(ctx, &userspb.GetUserTotalXPRequest{UserId: uint64(userID)})
+	if err != nil {
+		return 0, grpcerrors.FromStatus(err)
+	}
+	return int(resp.GetTotalXp()), nil
+}
+
+func (c *Client) GiveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error {
+	_, err := c.client.GiveUserXP(ctx, &userspb.GiveUserXPRequest{
+		UserId:     uint64(userID),
+		SourceType: sourceType,
+		SourceId:   sourceID,
+		Amount:     int32(amount),
+	})
+	return grpcerrors.FromStatus(err)
+}
+
+func (c *Client) RemoveUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) (int, error) {
+	resp, err := c.client.RemoveUserXP(ctx, &userspb.RemoveUserXPRequest{
+		UserId:     uint64(userID),
+		SourceType: sourceType,
+		SourceId:   sourceID,
+		Amount:     int32(amount),
+	})
+	if err != nil {
+		return 0, grpcerrors.FromStatus(err)
+	}
+	return int(resp.GetRemoved()), nil
+}
+
+func (c *Client) RestoreUserXP(ctx context.Context, userID uint, sourceType, sourceID string, amount int) error {
+	_, err := c.client.RestoreUserXP(ctx, &userspb.RestoreUserXPRequest{
+		UserId:     uint64(userID),
+		SourceType: sourceType,
+		SourceId:   sourceID,
+		Amount:     int32(amount),
+	})
+	return grpcerrors.FromStatus(err)
+}
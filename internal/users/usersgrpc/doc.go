@@ -0,0 +1,32 @@
+// Package usersgrpc expõe usuários e XP via gRPC para acesso
+// service-to-service e fornece o client usado pelos módulos consumidores.
+//
+// Este pacote fornece:
+//   - Server: UsersService (api/proto/users/v1/users.proto) sobre users.Service
+//   - Client: implementa challenges.UserService sobre uma conexão gRPC
+//   - Erros de pkg/errors preservados nos dois sentidos (pkg/grpcerrors)
+//
+// # Servidor
+//
+//	server := grpc.NewServer(grpc.UnaryInterceptor(
+//		grpcerrors.UnaryServerInterceptor(grpcerrors.Config{Production: true, Logger: log}),
+//	))
+//	usersgrpc.Register(server, usersService)
+//	go server.Serve(listener)
+//
+// # Client
+//
+//	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	client := usersgrpc.NewClient(conn)
+//
+//	// challenges roda sem mudanças contra o users service remoto
+//	deps := &module.Deps{Remote: map[string]interface{}{users.ModuleName: client}}
+//
+// # Transações
+//
+// A transação do chamador (database.TxManager.InTransaction) não atravessa a
+// rede: GiveUserXP e RemoveUserXP são gravados pelo users service na
+// transação dele. Fluxos que desfazem a transação do chamador depois da
+// chamada precisam estornar o XP (RemoveUserXP), como fazem as compensações
+// das sagas.
+package usersgrpc
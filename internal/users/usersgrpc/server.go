@@ -0,0 +1,118 @@
+package usersgrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rafaelcoelhox/labbend/internal/users"
+	"github.com/rafaelcoelhox/labbend/internal/users/userspb"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+)
+
+// Server - implementação gRPC de UsersService sobre o users.Service local.
+// Os handlers retornam erros de pkg/errors; registre o servidor com
+// grpcerrors.UnaryServerInterceptor para convertê-los em status
+type Server struct {
+	userspb.UnimplementedUsersServiceServer
+	service users.Service
+}
+
+// NewServer - cria o servidor
+func NewServer(service users.Service) *Server {
+	return &Server{service: service}
+}
+
+// Register - registra UsersService no servidor gRPC
+func Register(registrar grpc.ServiceRegistrar, service users.Service) {
+	userspb.RegisterUsersServiceServer(registrar, NewServer(service))
+}
+
+func (s *Server) GetUser(ctx context.Context, req *userspb.GetUserRequest) (*userspb.GetUserResponse, error) {
+	id, err := userID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.service.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &userspb.GetUserResponse{User: toProto(user)}, nil
+}
+
+func (s *Server) GetUserTotalXP(ctx context.Context, req *userspb.GetUserTotalXPRequest) (*userspb.GetUserTotalXPResponse, error) {
+	id, err := userID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.service.GetUserTotalXP(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &userspb.GetUserTotalXPResponse{TotalXp: int64(total)}, nil
+}
+
+func (s *Server) GiveUserXP(ctx context.Context, req *userspb.GiveUserXPRequest) (*userspb.GiveUserXPResponse, error) {
+	id, err := userID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.GiveUserXP(ctx, id, req.GetSourceType(), req.GetSourceId(), int(req.GetAmount())); err != nil {
+		return nil, err
+	}
+	return &userspb.GiveUserXPResponse{}, nil
+}
+
+func (s *Server) RemoveUserXP(ctx context.Context, req *userspb.RemoveUserXPRequest) (*userspb.RemoveUserXPResponse, error) {
+	id, err := userID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	removed, err := s.service.RemoveUserXP(ctx, id, req.GetSourceType(), req.GetSourceId(), int(req.GetAmount()))
+	if err != nil {
+		return nil, err
+	}
+	return &userspb.RemoveUserXPResponse{Removed: int32(removed)}, nil // #nosec G115 - limitado ao amount (int32) da requisição
+}
+
+func (s *Server) RestoreUserXP(ctx context.Context, req *userspb.RestoreUserXPRequest) (*userspb.RestoreUserXPResponse, error) {
+	id, err := userID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.RestoreUserXP(ctx, id, req.GetSourceType(), req.GetSourceId(), int(req.GetAmount())); err != nil {
+		return nil, err
+	}
+	return &userspb.RestoreUserXPResponse{}, nil
+}
+
+// userID - IDs do protocolo são uint64; os do GORM, uint
+func userID(id uint64) (uint, error) {
+	if id == 0 || id > uint64(^uint32(0)) {
+		return 0, errors.Validation(errors.FieldError{Field: "user_id", Message: "must be a valid ID"})
+	}
+	return uint(id), nil
+}
+
+func toProto(user *users.User) *userspb.User {
+	return &userspb.User{
+		Id:        uint64(user.ID),
+		Name:      user.Name,
+		Email:     user.Email,
+		Nickname:  user.Nickname,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
+
+func fromProto(user *userspb.User) *users.User {
+	return &users.User{
+		ID:        uint(user.GetId()),
+		Name:      user.GetName(),
+		Email:     user.GetEmail(),
+		Nickname:  user.GetNickname(),
+		CreatedAt: user.GetCreatedAt().AsTime(),
+		UpdatedAt: user.GetUpdatedAt().AsTime(),
+	}
+}
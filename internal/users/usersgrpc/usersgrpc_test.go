@@ -0,0 +1,117 @@
+package usersgrpc_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/rafaelcoelhox/labbend/internal/challenges"
+	"github.com/rafaelcoelhox/labbend/internal/mocks"
+	"github.com/rafaelcoelhox/labbend/internal/users"
+	"github.com/rafaelcoelhox/labbend/internal/users/usersgrpc"
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/grpcerrors"
+)
+
+// O client é usado pelo módulo challenges no lugar do users.Service local
+var _ challenges.UserService = (*usersgrpc.Client)(nil)
+
+// newClient - servidor em memória (bufconn) com o users.Service mockado
+func newClient(t *testing.T, service users.Service) *usersgrpc.Client {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(grpcerrors.UnaryServerInterceptor(grpcerrors.Config{Production: true})))
+	usersgrpc.Register(server, service)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return usersgrpc.NewClient(conn)
+}
+
+func TestClient_XPOperations_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := mocks.NewMockUsersService(ctrl)
+	client := newClient(t, service)
+	ctx := context.Background()
+
+	service.EXPECT().GiveUserXP(gomock.Any(), uint(7), "challenge", "42", 100).Return(nil)
+	service.EXPECT().RemoveUserXP(gomock.Any(), uint(7), "challenge", "42", 100).Return(80, nil)
+	service.EXPECT().RestoreUserXP(gomock.Any(), uint(7), "challenge", "42", 80).Return(nil)
+	service.EXPECT().GetUserTotalXP(gomock.Any(), uint(7)).Return(250, nil)
+
+	require.NoError(t, client.GiveUserXP(ctx, 7, "challenge", "42", 100))
+	removed, err := client.RemoveUserXP(ctx, 7, "challenge", "42", 100)
+	require.NoError(t, err)
+	assert.Equal(t, 80, removed)
+	require.NoError(t, client.RestoreUserXP(ctx, 7, "challenge", "42", removed))
+
+	total, err := client.GetUserTotalXP(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 250, total)
+}
+
+func TestClient_GetUser_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := mocks.NewMockUsersService(ctrl)
+	client := newClient(t, service)
+
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.EXPECT().GetUser(gomock.Any(), uint(7)).Return(&users.User{
+		ID: 7, Name: "Ana", Email: "ana@example.com", Nickname: "ana", CreatedAt: createdAt, UpdatedAt: createdAt,
+	}, nil)
+
+	user, err := client.GetUser(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), user.ID)
+	assert.Equal(t, "ana@example.com", user.Email)
+	assert.True(t, createdAt.Equal(user.CreatedAt))
+}
+
+func TestClient_Errors_WithGomock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := mocks.NewMockUsersService(ctrl)
+	client := newClient(t, service)
+	ctx := context.Background()
+
+	t.Run("not found", func(t *testing.T) {
+		service.EXPECT().GiveUserXP(gomock.Any(), uint(99), "challenge", "1", 10).Return(errors.NotFound("user", 99))
+
+		err := client.GiveUserXP(ctx, 99, "challenge", "1", 10)
+		assert.True(t, errors.Is(err, errors.ErrNotFound))
+		assert.Equal(t, errors.CodeNotFound, errors.Code(err))
+		assert.Equal(t, "user with id 99 not found", errors.PublicMessage(err))
+	})
+
+	t.Run("campos inválidos", func(t *testing.T) {
+		err := client.GiveUserXP(ctx, 0, "challenge", "1", 10)
+		assert.Equal(t, errors.CodeInvalidInput, errors.Code(err))
+		assert.Equal(t, []errors.FieldError{{Field: "user_id", Message: "must be a valid ID"}}, errors.Fields(err))
+	})
+
+	t.Run("interno oculto em produção", func(t *testing.T) {
+		service.EXPECT().GetUserTotalXP(gomock.Any(), uint(7)).Return(0, assert.AnError)
+
+		_, err := client.GetUserTotalXP(ctx, 7)
+		assert.Equal(t, errors.CodeInternal, errors.Code(err))
+		assert.Contains(t, err.Error(), grpcerrors.InternalMessage+" (correlationId: ")
+		assert.False(t, strings.Contains(err.Error(), assert.AnError.Error()))
+	})
+}
@@ -0,0 +1,286 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: users/v1/users.proto
+
+// Users - acesso service-to-service aos usuários e ao XP.
+// O código Go fica em internal/users/userspb (make proto).
+
+package userspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UsersService_GetUser_FullMethodName        = "/labbend.users.v1.UsersService/GetUser"
+	UsersService_GetUserTotalXP_FullMethodName = "/labbend.users.v1.UsersService/GetUserTotalXP"
+	UsersService_GiveUserXP_FullMethodName     = "/labbend.users.v1.UsersService/GiveUserXP"
+	UsersService_RemoveUserXP_FullMethodName   = "/labbend.users.v1.UsersService/RemoveUserXP"
+	UsersService_RestoreUserXP_FullMethodName  = "/labbend.users.v1.UsersService/RestoreUserXP"
+)
+
+// UsersServiceClient is the client API for UsersService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UsersServiceClient interface {
+	// GetUser - usuário por ID (NOT_FOUND quando não existe)
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
+	// GetUserTotalXP - soma do XP do usuário
+	GetUserTotalXP(ctx context.Context, in *GetUserTotalXPRequest, opts ...grpc.CallOption) (*GetUserTotalXPResponse, error)
+	// GiveUserXP - concede XP de uma origem (ex.: "challenge", "42")
+	GiveUserXP(ctx context.Context, in *GiveUserXPRequest, opts ...grpc.CallOption) (*GiveUserXPResponse, error)
+	// RemoveUserXP - registra o estorno de XP de uma origem; responde com o valor removido
+	RemoveUserXP(ctx context.Context, in *RemoveUserXPRequest, opts ...grpc.CallOption) (*RemoveUserXPResponse, error)
+	// RestoreUserXP - devolve XP estornado direto no ledger, sem as regras de XP
+	RestoreUserXP(ctx context.Context, in *RestoreUserXPRequest, opts ...grpc.CallOption) (*RestoreUserXPResponse, error)
+}
+
+type usersServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUsersServiceClient(cc grpc.ClientConnInterface) UsersServiceClient {
+	return &usersServiceClient{cc}
+}
+
+func (c *usersServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserResponse)
+	err := c.cc.Invoke(ctx, UsersService_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) GetUserTotalXP(ctx context.Context, in *GetUserTotalXPRequest, opts ...grpc.CallOption) (*GetUserTotalXPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserTotalXPResponse)
+	err := c.cc.Invoke(ctx, UsersService_GetUserTotalXP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) GiveUserXP(ctx context.Context, in *GiveUserXPRequest, opts ...grpc.CallOption) (*GiveUserXPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GiveUserXPResponse)
+	err := c.cc.Invoke(ctx, UsersService_GiveUserXP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) RemoveUserXP(ctx context.Context, in *RemoveUserXPRequest, opts ...grpc.CallOption) (*RemoveUserXPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveUserXPResponse)
+	err := c.cc.Invoke(ctx, UsersService_RemoveUserXP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) RestoreUserXP(ctx context.Context, in *RestoreUserXPRequest, opts ...grpc.CallOption) (*RestoreUserXPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreUserXPResponse)
+	err := c.cc.Invoke(ctx, UsersService_RestoreUserXP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UsersServiceServer is the server API for UsersService service.
+// All implementations must embed UnimplementedUsersServiceServer
+// for forward compatibility.
+type UsersServiceServer interface {
+	// GetUser - usuário por ID (NOT_FOUND quando não existe)
+	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	// GetUserTotalXP - soma do XP do usuário
+	GetUserTotalXP(context.Context, *GetUserTotalXPRequest) (*GetUserTotalXPResponse, error)
+	// GiveUserXP - concede XP de uma origem (ex.: "challenge", "42")
+	GiveUserXP(context.Context, *GiveUserXPRequest) (*GiveUserXPResponse, error)
+	// RemoveUserXP - registra o estorno de XP de uma origem; responde com o valor removido
+	RemoveUserXP(context.Context, *RemoveUserXPRequest) (*RemoveUserXPResponse, error)
+	// RestoreUserXP - devolve XP estornado direto no ledger, sem as regras de XP
+	RestoreUserXP(context.Context, *RestoreUserXPRequest) (*RestoreUserXPResponse, error)
+	mustEmbedUnimplementedUsersServiceServer()
+}
+
+// UnimplementedUsersServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUsersServiceServer struct{}
+
+func (UnimplementedUsersServiceServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedUsersServiceServer) GetUserTotalXP(context.Context, *GetUserTotalXPRequest) (*GetUserTotalXPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserTotalXP not implemented")
+}
+func (UnimplementedUsersServiceServer) GiveUserXP(context.Context, *GiveUserXPRequest) (*GiveUserXPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GiveUserXP not implemented")
+}
+func (UnimplementedUsersServiceServer) RemoveUserXP(context.Context, *RemoveUserXPRequest) (*RemoveUserXPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserXP not implemented")
+}
+func (UnimplementedUsersServiceServer) RestoreUserXP(context.Context, *RestoreUserXPRequest) (*RestoreUserXPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreUserXP not implemented")
+}
+func (UnimplementedUsersServiceServer) mustEmbedUnimplementedUsersServiceServer() {}
+func (UnimplementedUsersServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeUsersServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UsersServiceServer will
+// result in compilation errors.
+type UnsafeUsersServiceServer interface {
+	mustEmbedUnimplementedUsersServiceServer()
+}
+
+func RegisterUsersServiceServer(s grpc.ServiceRegistrar, srv UsersServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUsersServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UsersService_ServiceDesc, srv)
+}
+
+func _UsersService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_GetUserTotalXP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserTotalXPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).GetUserTotalXP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_GetUserTotalXP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).GetUserTotalXP(ctx, req.(*GetUserTotalXPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_GiveUserXP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GiveUserXPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).GiveUserXP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_GiveUserXP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).GiveUserXP(ctx, req.(*GiveUserXPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_RemoveUserXP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveUserXPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).RemoveUserXP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_RemoveUserXP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).RemoveUserXP(ctx, req.(*RemoveUserXPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_RestoreUserXP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserXPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).RestoreUserXP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_RestoreUserXP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).RestoreUserXP(ctx, req.(*RestoreUserXPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UsersService_ServiceDesc is the grpc.ServiceDesc for UsersService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UsersService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "labbend.users.v1.UsersService",
+	HandlerType: (*UsersServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUser",
+			Handler:    _UsersService_GetUser_Handler,
+		},
+		{
+			MethodName: "GetUserTotalXP",
+			Handler:    _UsersService_GetUserTotalXP_Handler,
+		},
+		{
+			MethodName: "GiveUserXP",
+			Handler:    _UsersService_GiveUserXP_Handler,
+		},
+		{
+			MethodName: "RemoveUserXP",
+			Handler:    _UsersService_RemoveUserXP_Handler,
+		},
+		{
+			MethodName: "RestoreUserXP",
+			Handler:    _UsersService_RestoreUserXP_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "users/v1/users.proto",
+}
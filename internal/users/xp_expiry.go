@@ -0,0 +1,80 @@
+package users
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+const (
+	// DefaultExpiryInterval - intervalo padrão do job de expiração de XP
+	DefaultExpiryInterval = time.Hour
+	// expiryBatchSize - concessões vencidas processadas por consulta
+	expiryBatchSize = 100
+)
+
+// Expirer - lança a expiração das concessões com ExpiresAt vencido
+// (SourceRules.ExpiresAfter): um lançamento negativo na mesma fonte, que
+// atualiza o saldo e reduz o XP removível da fonte
+type Expirer struct {
+	repo     Repository
+	logger   logger.Logger
+	eventBus EventBus
+	now      func() time.Time
+}
+
+// NewExpirer - eventBus nil não publica UserXPExpired
+func NewExpirer(repo Repository, logger logger.Logger, eventBus EventBus) *Expirer {
+	return &Expirer{repo: repo, logger: logger, eventBus: eventBus, now: time.Now}
+}
+
+// ExpireDue - expira as concessões vencidas; retorna quantas geraram lançamento
+func (e *Expirer) ExpireDue(ctx context.Context) (int, error) {
+	now := e.now()
+	expired := 0
+
+	for {
+		grants, err := e.repo.ListExpiredXP(ctx, now, expiryBatchSize)
+		if err != nil {
+			return expired, err
+		}
+
+		for _, grant := range grants {
+			amount, err := e.repo.ExpireXP(ctx, grant, now)
+			if err != nil {
+				return expired, err
+			}
+			if amount == 0 {
+				continue
+			}
+			expired++
+
+			if e.eventBus != nil {
+				e.eventBus.Publish(eventbus.Event{
+					Type:   "UserXPExpired",
+					Source: "users",
+					Data: map[string]interface{}{
+						"userID":     grant.UserID,
+						"sourceType": grant.SourceType,
+						"sourceID":   grant.SourceID,
+						"amount":     amount,
+						"userXPID":   grant.ID,
+					},
+				})
+			}
+		}
+
+		if len(grants) < expiryBatchSize {
+			break
+		}
+	}
+
+	if expired > 0 {
+		e.logger.Info("XP expired", zap.Int("grants", expired))
+	}
+	return expired, nil
+}
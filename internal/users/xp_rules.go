@@ -0,0 +1,343 @@
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultStreakLookback - dias consultados para a sequência quando o bônus não tem teto
+const defaultStreakLookback = 30
+
+// XPRules - regras da economia de XP aplicadas em GiveUserXP: multiplicadores
+// de eventos, limite diário, bônus de sequência e expiração por SourceType.
+// O valor zero não altera nada (o XP concedido é o informado pelo chamador)
+type XPRules struct {
+	// Location - fuso que define o "dia" dos limites e das sequências (nil = UTC)
+	Location *time.Location
+	Sources  map[string]SourceRules
+	Events   []XPEvent
+}
+
+// SourceRules - regras de um SourceType
+type SourceRules struct {
+	// DailyCap - XP máximo concedido por dia para a fonte (0 = sem limite)
+	DailyCap int
+	// Streak - bônus por dias consecutivos com XP da fonte (nil = sem bônus)
+	Streak *StreakRule
+	// ExpiresAfter - validade do XP concedido (0 = não expira)
+	ExpiresAfter time.Duration
+}
+
+// StreakRule - cada dia consecutivo além do primeiro soma BonusPerDay
+// (0.1 = +10%) até MaxBonus (0 = sem teto)
+type StreakRule struct {
+	BonusPerDay float64
+	MaxBonus    float64
+}
+
+// XPEvent - multiplicador ativo em uma janela de tempo (ex.: XP em dobro nos
+// fins de semana). Start/End zero não limitam; Weekdays vazio vale todos os
+// dias; Sources vazio vale todas as fontes. Eventos simultâneos se multiplicam
+type XPEvent struct {
+	Name       string
+	Multiplier float64
+	Sources    []string
+	Start      time.Time
+	End        time.Time
+	Weekdays   []time.Weekday
+}
+
+// XPBreakdown - como o valor de um lançamento foi calculado; gravado em
+// UserXP.Breakdown
+type XPBreakdown struct {
+	BaseAmount  int      `json:"base_amount"`
+	Multiplier  float64  `json:"multiplier,omitempty"`
+	Events      []string `json:"events,omitempty"`
+	StreakDays  int      `json:"streak_days,omitempty"`
+	StreakBonus float64  `json:"streak_bonus,omitempty"`
+	CappedBy    int      `json:"capped_by,omitempty"` // XP descartado pelo limite diário
+	FinalAmount int      `json:"final_amount"`
+	// ExpiryOf - lançamento expirado (apenas nos lançamentos de expiração)
+	ExpiryOf uint `json:"expiry_of,omitempty"`
+}
+
+// IsZero - sem nenhuma regra configurada
+func (r XPRules) IsZero() bool {
+	return len(r.Sources) == 0 && len(r.Events) == 0
+}
+
+// Expires - alguma fonte tem validade configurada
+func (r XPRules) Expires() bool {
+	for _, source := range r.Sources {
+		if source.ExpiresAfter > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (r XPRules) location() *time.Location {
+	if r.Location == nil {
+		return time.UTC
+	}
+	return r.Location
+}
+
+// startOfDay - início do dia de t no fuso das regras
+func (r XPRules) startOfDay(t time.Time) time.Time {
+	t = t.In(r.location())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// HistorySince - a partir de quando os lançamentos da fonte são necessários
+// para Apply (limite de hoje e sequência); ok = false quando nenhum é
+func (r XPRules) HistorySince(sourceType string, now time.Time) (since time.Time, ok bool) {
+	source, exists := r.Sources[sourceType]
+	if !exists || (source.DailyCap == 0 && source.Streak == nil) {
+		return time.Time{}, false
+	}
+
+	days := 0
+	if source.Streak != nil {
+		days = defaultStreakLookback
+		if source.Streak.MaxBonus > 0 && source.Streak.BonusPerDay > 0 {
+			days = int(math.Ceil(source.Streak.MaxBonus / source.Streak.BonusPerDay))
+		}
+	}
+	return r.startOfDay(now).AddDate(0, 0, -days), true
+}
+
+// Apply - valor final de amount para sourceType em now. grants são os
+// lançamentos positivos da fonte desde HistorySince
+func (r XPRules) Apply(sourceType string, amount int, now time.Time, grants []*UserXP) XPBreakdown {
+	breakdown := XPBreakdown{BaseAmount: amount}
+	value := float64(amount)
+
+	multiplier := 1.0
+	for _, event := range r.Events {
+		if event.activeAt(sourceType, now.In(r.location())) {
+			multiplier *= event.Multiplier
+			breakdown.Events = append(breakdown.Events, event.Name)
+		}
+	}
+	if multiplier != 1 {
+		breakdown.Multiplier = multiplier
+		value *= multiplier
+	}
+
+	source := r.Sources[sourceType]
+	if source.Streak != nil {
+		breakdown.StreakDays = r.streakDays(now, grants)
+		bonus := source.Streak.BonusPerDay * float64(breakdown.StreakDays-1)
+		if source.Streak.MaxBonus > 0 && bonus > source.Streak.MaxBonus {
+			bonus = source.Streak.MaxBonus
+		}
+		if bonus > 0 {
+			breakdown.StreakBonus = bonus
+			value *= 1 + bonus
+		}
+	}
+
+	final := int(math.Round(value))
+	if source.DailyCap > 0 {
+		today := r.startOfDay(now)
+		granted := 0
+		for _, grant := range grants {
+			if !grant.CreatedAt.Before(today) && grant.Amount > 0 {
+				granted += grant.Amount
+			}
+		}
+		if remaining := max(source.DailyCap-granted, 0); final > remaining {
+			breakdown.CappedBy = final - remaining
+			final = remaining
+		}
+	}
+
+	breakdown.FinalAmount = final
+	return breakdown
+}
+
+// ExpiresAt - validade de um lançamento da fonte concedido em now (nil = não expira)
+func (r XPRules) ExpiresAt(sourceType string, now time.Time) *time.Time {
+	source, ok := r.Sources[sourceType]
+	if !ok || source.ExpiresAfter <= 0 {
+		return nil
+	}
+	expiresAt := now.Add(source.ExpiresAfter)
+	return &expiresAt
+}
+
+// streakDays - hoje mais os dias imediatamente anteriores com lançamento
+func (r XPRules) streakDays(now time.Time, grants []*UserXP) int {
+	active := make(map[time.Time]bool, len(grants))
+	for _, grant := range grants {
+		if grant.Amount > 0 {
+			active[r.startOfDay(grant.CreatedAt)] = true
+		}
+	}
+
+	days := 1
+	for day := r.startOfDay(now).AddDate(0, 0, -1); active[day]; day = day.AddDate(0, 0, -1) {
+		days++
+	}
+	return days
+}
+
+func (e XPEvent) activeAt(sourceType string, now time.Time) bool {
+	if !e.Start.IsZero() && now.Before(e.Start) {
+		return false
+	}
+	if !e.End.IsZero() && !now.Before(e.End) {
+		return false
+	}
+	if len(e.Weekdays) > 0 && !containsWeekday(e.Weekdays, now.Weekday()) {
+		return false
+	}
+	return len(e.Sources) == 0 || containsString(e.Sources, sourceType)
+}
+
+func containsWeekday(weekdays []time.Weekday, day time.Weekday) bool {
+	for _, weekday := range weekdays {
+		if weekday == day {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// === ARQUIVO DE REGRAS ===
+
+// xpRulesFile - formato JSON de XP_RULES_FILE (ver configs/xp_rules.example.json)
+type xpRulesFile struct {
+	Timezone string `json:"timezone"`
+	Sources  map[string]struct {
+		DailyCap int `json:"daily_cap"`
+		Streak   *struct {
+			BonusPerDay float64 `json:"bonus_per_day"`
+			MaxBonus    float64 `json:"max_bonus"`
+		} `json:"streak"`
+		ExpiresAfter string `json:"expires_after"`
+	} `json:"sources"`
+	Events []struct {
+		Name       string    `json:"name"`
+		Multiplier float64   `json:"multiplier"`
+		Sources    []string  `json:"sources"`
+		Start      time.Time `json:"start"`
+		End        time.Time `json:"end"`
+		Weekdays   []string  `json:"weekdays"`
+	} `json:"events"`
+}
+
+// xpSources - SourceTypes aceitos nas regras
+var xpSources = []string{XPSourceChallenge, XPSourceDailyTask, XPSourceCompletion}
+
+var weekdaysByName = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// LoadXPRules - lê as regras de um arquivo JSON; path vazio retorna as regras zero
+func LoadXPRules(path string) (XPRules, error) {
+	if path == "" {
+		return XPRules{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return XPRules{}, fmt.Errorf("read XP rules: %w", err)
+	}
+	rules, err := ParseXPRules(data)
+	if err != nil {
+		return XPRules{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// ParseXPRules - converte e valida o JSON das regras
+func ParseXPRules(data []byte) (XPRules, error) {
+	var file xpRulesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return XPRules{}, fmt.Errorf("invalid XP rules: %w", err)
+	}
+
+	rules := XPRules{Location: time.UTC, Sources: make(map[string]SourceRules, len(file.Sources))}
+	if file.Timezone != "" {
+		location, err := time.LoadLocation(file.Timezone)
+		if err != nil {
+			return XPRules{}, fmt.Errorf("invalid timezone %q: %w", file.Timezone, err)
+		}
+		rules.Location = location
+	}
+
+	for sourceType, source := range file.Sources {
+		if !containsString(xpSources, sourceType) {
+			return XPRules{}, fmt.Errorf("unknown XP source %q (expected one of %s)", sourceType, strings.Join(xpSources, ", "))
+		}
+		if source.DailyCap < 0 {
+			return XPRules{}, fmt.Errorf("source %q: daily_cap must not be negative", sourceType)
+		}
+
+		parsed := SourceRules{DailyCap: source.DailyCap}
+		if source.Streak != nil {
+			if source.Streak.BonusPerDay <= 0 || source.Streak.MaxBonus < 0 {
+				return XPRules{}, fmt.Errorf("source %q: streak bonus_per_day must be positive and max_bonus not negative", sourceType)
+			}
+			parsed.Streak = &StreakRule{BonusPerDay: source.Streak.BonusPerDay, MaxBonus: source.Streak.MaxBonus}
+		}
+		if source.ExpiresAfter != "" {
+			expiresAfter, err := time.ParseDuration(source.ExpiresAfter)
+			if err != nil || expiresAfter <= 0 {
+				return XPRules{}, fmt.Errorf("source %q: invalid expires_after %q", sourceType, source.ExpiresAfter)
+			}
+			parsed.ExpiresAfter = expiresAfter
+		}
+		rules.Sources[sourceType] = parsed
+	}
+
+	for i, event := range file.Events {
+		if event.Name == "" {
+			return XPRules{}, fmt.Errorf("event %d: name is required", i)
+		}
+		if event.Multiplier <= 0 {
+			return XPRules{}, fmt.Errorf("event %q: multiplier must be positive", event.Name)
+		}
+		if !event.Start.IsZero() && !event.End.IsZero() && !event.End.After(event.Start) {
+			return XPRules{}, fmt.Errorf("event %q: end must be after start", event.Name)
+		}
+		for _, sourceType := range event.Sources {
+			if !containsString(xpSources, sourceType) {
+				return XPRules{}, fmt.Errorf("event %q: unknown XP source %q", event.Name, sourceType)
+			}
+		}
+
+		parsed := XPEvent{
+			Name:       event.Name,
+			Multiplier: event.Multiplier,
+			Sources:    event.Sources,
+			Start:      event.Start,
+			End:        event.End,
+		}
+		for _, name := range event.Weekdays {
+			weekday, ok := weekdaysByName[strings.ToLower(name)]
+			if !ok {
+				return XPRules{}, fmt.Errorf("event %q: unknown weekday %q", event.Name, name)
+			}
+			parsed.Weekdays = append(parsed.Weekdays, weekday)
+		}
+		rules.Events = append(rules.Events, parsed)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,242 @@
+package users
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/database/databasetest"
+	applogger "github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+// 2026-10-17 é um sábado
+var saturday = time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC)
+
+func grantAt(amount int, at time.Time) *UserXP {
+	return &UserXP{SourceType: XPSourceDailyTask, Amount: amount, CreatedAt: at}
+}
+
+func TestXPRules_Apply(t *testing.T) {
+	t.Run("zero rules should keep the amount", func(t *testing.T) {
+		breakdown := XPRules{}.Apply(XPSourceChallenge, 100, saturday, nil)
+		assert.Equal(t, XPBreakdown{BaseAmount: 100, FinalAmount: 100}, breakdown)
+	})
+
+	t.Run("should multiply inside event windows", func(t *testing.T) {
+		rules := XPRules{Events: []XPEvent{
+			{Name: "double_xp_weekend", Multiplier: 2, Weekdays: []time.Weekday{time.Saturday, time.Sunday}},
+			{Name: "launch", Multiplier: 1.5, Sources: []string{XPSourceChallenge}, Start: saturday.Add(-time.Hour), End: saturday.Add(time.Hour)},
+			{Name: "past", Multiplier: 3, End: saturday.Add(-time.Hour)},
+		}}
+
+		breakdown := rules.Apply(XPSourceChallenge, 100, saturday, nil)
+		assert.Equal(t, 3.0, breakdown.Multiplier)
+		assert.Equal(t, []string{"double_xp_weekend", "launch"}, breakdown.Events)
+		assert.Equal(t, 300, breakdown.FinalAmount)
+
+		breakdown = rules.Apply(XPSourceDailyTask, 100, saturday, nil)
+		assert.Equal(t, []string{"double_xp_weekend"}, breakdown.Events)
+		assert.Equal(t, 200, breakdown.FinalAmount)
+
+		monday := saturday.AddDate(0, 0, 2)
+		assert.Equal(t, 100, rules.Apply(XPSourceDailyTask, 100, monday, nil).FinalAmount)
+	})
+
+	t.Run("should use the rules timezone for weekdays", func(t *testing.T) {
+		saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+		require.NoError(t, err)
+		rules := XPRules{Location: saoPaulo, Events: []XPEvent{
+			{Name: "weekend", Multiplier: 2, Weekdays: []time.Weekday{time.Saturday}},
+		}}
+
+		// Sábado 01:00 UTC ainda é sexta em São Paulo
+		early := time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)
+		assert.Equal(t, 10, rules.Apply(XPSourceChallenge, 10, early, nil).FinalAmount)
+	})
+
+	t.Run("should add streak bonus for consecutive days", func(t *testing.T) {
+		rules := XPRules{Sources: map[string]SourceRules{
+			XPSourceDailyTask: {Streak: &StreakRule{BonusPerDay: 0.1, MaxBonus: 0.25}},
+		}}
+		grants := []*UserXP{
+			grantAt(10, saturday.AddDate(0, 0, -1)),
+			grantAt(10, saturday.AddDate(0, 0, -2)),
+			grantAt(10, saturday.AddDate(0, 0, -4)), // fora da sequência
+		}
+
+		breakdown := rules.Apply(XPSourceDailyTask, 100, saturday, grants)
+		assert.Equal(t, 3, breakdown.StreakDays)
+		assert.InDelta(t, 0.2, breakdown.StreakBonus, 1e-9)
+		assert.Equal(t, 120, breakdown.FinalAmount)
+
+		grants = append(grants, grantAt(10, saturday.AddDate(0, 0, -3)))
+		breakdown = rules.Apply(XPSourceDailyTask, 100, saturday, grants)
+		assert.Equal(t, 5, breakdown.StreakDays)
+		assert.Equal(t, 0.25, breakdown.StreakBonus)
+		assert.Equal(t, 125, breakdown.FinalAmount)
+	})
+
+	t.Run("should cap daily XP per source", func(t *testing.T) {
+		rules := XPRules{Sources: map[string]SourceRules{XPSourceDailyTask: {DailyCap: 150}}}
+		grants := []*UserXP{
+			grantAt(100, saturday.Add(-time.Hour)),
+			grantAt(500, saturday.AddDate(0, 0, -1)), // ontem não conta
+		}
+
+		breakdown := rules.Apply(XPSourceDailyTask, 80, saturday, grants)
+		assert.Equal(t, 30, breakdown.CappedBy)
+		assert.Equal(t, 50, breakdown.FinalAmount)
+
+		grants = append(grants, grantAt(50, saturday.Add(-time.Minute)))
+		assert.Equal(t, 0, rules.Apply(XPSourceDailyTask, 80, saturday, grants).FinalAmount)
+	})
+
+	t.Run("should set expiry per source", func(t *testing.T) {
+		rules := XPRules{Sources: map[string]SourceRules{XPSourceChallenge: {ExpiresAfter: 24 * time.Hour}}}
+		assert.True(t, rules.Expires())
+		assert.Equal(t, saturday.Add(24*time.Hour), *rules.ExpiresAt(XPSourceChallenge, saturday))
+		assert.Nil(t, rules.ExpiresAt(XPSourceDailyTask, saturday))
+	})
+}
+
+func TestParseXPRules(t *testing.T) {
+	t.Run("should parse the example file", func(t *testing.T) {
+		rules, err := LoadXPRules("../../configs/xp_rules.example.json")
+		require.NoError(t, err)
+		assert.Equal(t, "America/Sao_Paulo", rules.Location.String())
+		assert.Equal(t, 300, rules.Sources[XPSourceDailyTask].DailyCap)
+		assert.Equal(t, 90*24*time.Hour, rules.Sources[XPSourceDailyTask].ExpiresAfter)
+		assert.Equal(t, []time.Weekday{time.Saturday, time.Sunday}, rules.Events[0].Weekdays)
+	})
+
+	t.Run("empty path should return zero rules", func(t *testing.T) {
+		rules, err := LoadXPRules("")
+		require.NoError(t, err)
+		assert.True(t, rules.IsZero())
+	})
+
+	invalid := map[string]string{
+		"unknown source":   `{"sources": {"quest": {"daily_cap": 10}}}`,
+		"negative cap":     `{"sources": {"challenge": {"daily_cap": -1}}}`,
+		"invalid streak":   `{"sources": {"challenge": {"streak": {"bonus_per_day": 0}}}}`,
+		"invalid expiry":   `{"sources": {"challenge": {"expires_after": "30d"}}}`,
+		"invalid timezone": `{"timezone": "Mars/Olympus"}`,
+		"no multiplier":    `{"events": [{"name": "weekend"}]}`,
+		"unknown weekday":  `{"events": [{"name": "weekend", "multiplier": 2, "weekdays": ["caturday"]}]}`,
+		"inverted window":  `{"events": [{"name": "e", "multiplier": 2, "start": "2026-10-18T00:00:00Z", "end": "2026-10-17T00:00:00Z"}]}`,
+	}
+	for name, data := range invalid {
+		t.Run("should reject "+name, func(t *testing.T) {
+			_, err := ParseXPRules([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestService_XPRules(t *testing.T) {
+	db := databasetest.New(t)
+	ctx := context.Background()
+	repo := NewRepository(db)
+	log, err := applogger.New()
+	require.NoError(t, err)
+
+	user := &User{Name: "Alice", Email: "alice@example.com", Nickname: "alice"}
+	require.NoError(t, repo.Create(ctx, user))
+
+	rules := XPRules{
+		Sources: map[string]SourceRules{XPSourceDailyTask: {DailyCap: 150, ExpiresAfter: time.Hour}},
+		Events:  []XPEvent{{Name: "double", Multiplier: 2, Sources: []string{XPSourceDailyTask}}},
+	}
+	bus := &recordingBus{}
+	svc := NewService(repo, log, bus, database.NewTxManager(db), nil, rules)
+
+	t.Run("should record the breakdown and expiry", func(t *testing.T) {
+		require.NoError(t, svc.GiveUserXP(ctx, user.ID, XPSourceDailyTask, "task-1", 50))
+
+		history, err := repo.GetUserXPHistory(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, 100, history[0].Amount)
+		assert.NotNil(t, history[0].ExpiresAt)
+
+		breakdown, err := history[0].ParseBreakdown()
+		require.NoError(t, err)
+		assert.Equal(t, &XPBreakdown{BaseAmount: 50, Multiplier: 2, Events: []string{"double"}, FinalAmount: 100}, breakdown)
+	})
+
+	t.Run("should stop at the daily cap", func(t *testing.T) {
+		require.NoError(t, svc.GiveUserXP(ctx, user.ID, XPSourceDailyTask, "task-2", 50))
+		require.NoError(t, svc.GiveUserXP(ctx, user.ID, XPSourceDailyTask, "task-3", 50))
+
+		total, err := repo.GetUserTotalXP(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 150, total)
+
+		history, err := repo.GetUserXPHistory(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Len(t, history, 2)
+	})
+
+	t.Run("should remove the net XP of the source", func(t *testing.T) {
+		removed, err := svc.RemoveUserXP(ctx, user.ID, XPSourceDailyTask, "task-1", 50)
+		require.NoError(t, err)
+		assert.Equal(t, 100, removed)
+
+		total, err := repo.GetUserTotalXP(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 50, total)
+	})
+
+	t.Run("expirer should expire due grants once", func(t *testing.T) {
+		expirer := NewExpirer(repo, log, bus)
+		expirer.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+		expired, err := expirer.ExpireDue(ctx)
+		require.NoError(t, err)
+		// task-1 já foi removido; só task-2 tem XP a expirar
+		assert.Equal(t, 1, expired)
+
+		total, err := repo.GetUserTotalXP(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.Equal(t, "UserXPExpired", bus.events[len(bus.events)-1].Type)
+
+		expired, err = expirer.ExpireDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, expired)
+	})
+}
+
+func TestService_RestoreUserXP(t *testing.T) {
+	db := databasetest.New(t)
+	ctx := context.Background()
+	repo := NewRepository(db)
+	log, err := applogger.New()
+	require.NoError(t, err)
+
+	user := &User{Name: "Bob", Email: "bob@example.com", Nickname: "bob"}
+	require.NoError(t, repo.Create(ctx, user))
+
+	rules := XPRules{
+		Sources: map[string]SourceRules{XPSourceChallenge: {DailyCap: 100}},
+		Events:  []XPEvent{{Name: "double", Multiplier: 2, Sources: []string{XPSourceChallenge}}},
+	}
+	svc := NewService(repo, log, &recordingBus{}, database.NewTxManager(db), nil, rules)
+
+	require.NoError(t, svc.GiveUserXP(ctx, user.ID, XPSourceChallenge, "7", 50))
+	removed, err := svc.RemoveUserXP(ctx, user.ID, XPSourceChallenge, "7", 50)
+	require.NoError(t, err)
+	assert.Equal(t, 100, removed)
+
+	// A compensação devolve o valor removido: nem multiplicador nem limite
+	// diário (já atingido pela concessão original) se aplicam
+	require.NoError(t, svc.RestoreUserXP(ctx, user.ID, XPSourceChallenge, "7", removed))
+
+	total, err := repo.GetUserTotalXP(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 100, total)
+}
@@ -0,0 +1,34 @@
+package auth
+
+import "context"
+
+// Viewer - solicitante da request; UserID 0 é anônimo. Role é o papel usado
+// em limites por papel (ex.: "moderator"); vazio usa os limites padrão
+type Viewer struct {
+	UserID uint
+	Admin  bool
+	Role   string
+}
+
+// IsAnonymous - request sem usuário autenticado
+func (v Viewer) IsAnonymous() bool {
+	return v.UserID == 0
+}
+
+// CanManage - o solicitante é o usuário userID ou um admin
+func (v Viewer) CanManage(userID uint) bool {
+	return v.Admin || (!v.IsAnonymous() && v.UserID == userID)
+}
+
+type viewerKey struct{}
+
+// WithViewer - define o solicitante (ex.: no middleware de autenticação)
+func WithViewer(ctx context.Context, viewer Viewer) context.Context {
+	return context.WithValue(ctx, viewerKey{}, viewer)
+}
+
+// ViewerFromContext - solicitante da request ou Viewer anônimo
+func ViewerFromContext(ctx context.Context) Viewer {
+	viewer, _ := ctx.Value(viewerKey{}).(Viewer)
+	return viewer
+}
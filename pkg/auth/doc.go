@@ -0,0 +1,22 @@
+// Package auth guarda no contexto da request quem é o solicitante (Viewer),
+// usado pelos resolvers para decidir o que ele pode ver.
+//
+// O middleware de autenticação define o Viewer com WithViewer; sem ele a
+// request é anônima e só enxerga dados públicos.
+//
+// Tokens emite e valida os tokens de acesso ("payload.assinatura", HMAC-SHA256
+// com expiração). O app os lê do header "Authorization: Bearer" nas requests
+// HTTP e do authToken do connection_init nas conexões WebSocket.
+//
+// # Exemplo de Uso
+//
+//	tokens := auth.NewTokens(secret)
+//	if viewer, err := tokens.Verify(auth.BearerToken(r)); err == nil {
+//		ctx = auth.WithViewer(ctx, viewer)
+//	}
+//
+//	// no resolver
+//	if auth.ViewerFromContext(ctx).CanManage(user.ID) {
+//		// dono do perfil ou admin
+//	}
+package auth
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid auth token")
+	ErrTokenExpired = errors.New("auth token expired")
+)
+
+// claims - payload do token
+type claims struct {
+	Subject   uint   `json:"sub"`
+	Admin     bool   `json:"admin,omitempty"`
+	Role      string `json:"role,omitempty"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Tokens - emite e valida tokens "payload.assinatura" (base64url, HMAC-SHA256)
+type Tokens struct {
+	secret []byte
+	now    func() time.Time
+}
+
+// NewTokens - secret é a chave HMAC compartilhada com quem emite os tokens
+func NewTokens(secret []byte) *Tokens {
+	return &Tokens{secret: secret, now: time.Now}
+}
+
+// Issue - token do viewer válido por ttl
+func (t *Tokens) Issue(viewer Viewer, ttl time.Duration) (string, error) {
+	if viewer.IsAnonymous() {
+		return "", ErrInvalidToken
+	}
+	payload, err := json.Marshal(claims{
+		Subject:   viewer.UserID,
+		Admin:     viewer.Admin,
+		Role:      viewer.Role,
+		ExpiresAt: t.now().Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(t.sign(encoded)), nil
+}
+
+// Verify - confere assinatura e expiração e devolve o Viewer do token
+func (t *Tokens) Verify(token string) (Viewer, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Viewer{}, ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, t.sign(encoded)) {
+		return Viewer{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Viewer{}, ErrInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil || c.Subject == 0 {
+		return Viewer{}, ErrInvalidToken
+	}
+	if t.now().Unix() >= c.ExpiresAt {
+		return Viewer{}, ErrTokenExpired
+	}
+
+	return Viewer{UserID: c.Subject, Admin: c.Admin, Role: c.Role}, nil
+}
+
+func (t *Tokens) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// BearerToken - token do header "Authorization: Bearer <token>" (vazio se ausente)
+func BearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
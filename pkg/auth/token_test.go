@@ -0,0 +1,73 @@
+package auth_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/auth"
+)
+
+func TestTokens_IssueAndVerify(t *testing.T) {
+	tokens := auth.NewTokens([]byte("secret"))
+	viewer := auth.Viewer{UserID: 42, Admin: true, Role: "moderator"}
+
+	token, err := tokens.Issue(viewer, time.Minute)
+	require.NoError(t, err)
+
+	verified, err := tokens.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, viewer, verified)
+
+	t.Run("should reject tampered, foreign and malformed tokens", func(t *testing.T) {
+		payload, signature, _ := strings.Cut(token, ".")
+		forged, err := auth.NewTokens([]byte("other")).Issue(auth.Viewer{UserID: 1, Admin: true}, time.Minute)
+		require.NoError(t, err)
+
+		for _, candidate := range []string{
+			"",
+			"anything",
+			payload + "." + signature + "x",
+			strings.ToUpper(payload) + "." + signature,
+			forged,
+		} {
+			_, err := tokens.Verify(candidate)
+			assert.ErrorIs(t, err, auth.ErrInvalidToken, candidate)
+		}
+	})
+
+	t.Run("should reject expired tokens", func(t *testing.T) {
+		expired, err := tokens.Issue(viewer, -time.Second)
+		require.NoError(t, err)
+
+		_, err = tokens.Verify(expired)
+		assert.ErrorIs(t, err, auth.ErrTokenExpired)
+	})
+
+	t.Run("should not issue anonymous tokens", func(t *testing.T) {
+		_, err := tokens.Issue(auth.Viewer{}, time.Minute)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := map[string]string{
+		"":                 "",
+		"Bearer abc.def":   "abc.def",
+		"bearer  abc.def ": "abc.def",
+		"Basic abc":        "",
+		"abc.def":          "",
+	}
+
+	for header, want := range tests {
+		r := httptest.NewRequest("GET", "/graphql", nil)
+		if header != "" {
+			r.Header.Set("Authorization", header)
+		}
+		assert.Equal(t, want, auth.BearerToken(r), header)
+	}
+}
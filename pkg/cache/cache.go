@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// DefaultTTL - validade das entradas gravadas com ttl <= 0
+const DefaultTTL = 5 * time.Minute
+
+// Cache - armazenamento chave/valor com expiração, compartilhado entre requests
+// (diferente do cache por request do dataloader). Os valores são bytes para
+// que implementações em memória e remotas sejam intercambiáveis
+type Cache interface {
+	// Get - valor da chave; ok = false quando ausente ou expirada
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set - grava a chave; ttl <= 0 usa o TTL padrão da implementação
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete - remove as chaves (ausentes são ignoradas)
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// GetOrLoad - valor em cache (JSON) ou o resultado de load, gravado para as
+// próximas leituras. Falhas do cache viram miss: o cache nunca derruba a
+// leitura. Erros de load não ficam em cache; c nil sempre chama load
+func GetOrLoad[T any](ctx context.Context, c Cache, key string, ttl time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	if c == nil {
+		return load(ctx)
+	}
+
+	if data, ok, err := c.Get(ctx, key); err == nil && ok {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+	}
+
+	value, err := load(ctx)
+	if err != nil {
+		return value, err
+	}
+	if data, err := json.Marshal(value); err == nil {
+		_ = c.Set(ctx, key, data, ttl)
+	}
+	return value, nil
+}
+
+// Key - chave composta pelas partes separadas por ":" (ex.: Key("user", "42"))
+func Key(parts ...string) string {
+	return strings.Join(parts, ":")
+}
+
+// Prefix - visão de c com as chaves prefixadas (ex.: um namespace por módulo)
+func Prefix(c Cache, prefix string) Cache {
+	return &prefixed{cache: c, prefix: prefix}
+}
+
+type prefixed struct {
+	cache  Cache
+	prefix string
+}
+
+func (p *prefixed) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return p.cache.Get(ctx, p.prefix+key)
+}
+
+func (p *prefixed) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return p.cache.Set(ctx, p.prefix+key, value, ttl)
+}
+
+func (p *prefixed) Delete(ctx context.Context, keys ...string) error {
+	prefixedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		prefixedKeys[i] = p.prefix + key
+	}
+	return p.cache.Delete(ctx, prefixedKeys...)
+}
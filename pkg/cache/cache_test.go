@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestMemory_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(2, time.Minute)
+
+	require.NoError(t, m.Set(ctx, "a", []byte("1"), 0))
+	require.NoError(t, m.Set(ctx, "b", []byte("2"), 0))
+	_, ok, _ := m.Get(ctx, "a") // "b" passa a ser o menos usado
+	require.True(t, ok)
+	require.NoError(t, m.Set(ctx, "c", []byte("3"), 0))
+
+	_, ok, _ = m.Get(ctx, "b")
+	assert.False(t, ok)
+	_, ok, _ = m.Get(ctx, "a")
+	assert.True(t, ok)
+	_, ok, _ = m.Get(ctx, "c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestMemory_ExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	m := NewMemory(0, time.Minute)
+	m.now = func() time.Time { return now }
+
+	require.NoError(t, m.Set(ctx, "default", []byte("1"), 0))
+	require.NoError(t, m.Set(ctx, "short", []byte("2"), time.Second))
+
+	now = now.Add(2 * time.Second)
+	_, ok, _ := m.Get(ctx, "short")
+	assert.False(t, ok)
+	_, ok, _ = m.Get(ctx, "default")
+	assert.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok, _ = m.Get(ctx, "default")
+	assert.False(t, ok)
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestRedis_GetSetDelete(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	r := NewRedis(redis.NewClient(&redis.Options{Addr: server.Addr()}), "labend:", time.Minute)
+	t.Cleanup(func() { _ = r.Close() })
+
+	_, ok, err := r.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, r.Set(ctx, "user:1", []byte("alice"), 0))
+	assert.True(t, server.Exists("labend:user:1"))
+	assert.Equal(t, time.Minute, server.TTL("labend:user:1"))
+
+	value, ok, err := r.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("alice"), value)
+
+	require.NoError(t, r.Delete(ctx, "user:1", "user:2"))
+	_, ok, _ = r.Get(ctx, "user:1")
+	assert.False(t, ok)
+
+	server.FastForward(time.Minute)
+	require.NoError(t, r.Set(ctx, "user:3", []byte("bob"), time.Second))
+	server.FastForward(2 * time.Second)
+	_, ok, _ = r.Get(ctx, "user:3")
+	assert.False(t, ok)
+}
+
+func TestGetOrLoad(t *testing.T) {
+	ctx := context.Background()
+	c := Prefix(NewMemory(0, 0), "users:")
+	loads := 0
+	load := func(ctx context.Context) (*item, error) {
+		loads++
+		return &item{ID: 1, Name: "alice"}, nil
+	}
+
+	first, err := GetOrLoad(ctx, c, Key("user", "1"), 0, load)
+	require.NoError(t, err)
+	second, err := GetOrLoad(ctx, c, Key("user", "1"), 0, load)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, loads)
+
+	require.NoError(t, c.Delete(ctx, Key("user", "1")))
+	_, err = GetOrLoad(ctx, c, Key("user", "1"), 0, load)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads)
+}
+
+func TestGetOrLoad_DoesNotCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemory(0, 0)
+	errNotFound := errors.New("not found")
+
+	_, err := GetOrLoad(ctx, c, "missing", 0, func(ctx context.Context) (*item, error) {
+		return nil, errNotFound
+	})
+	assert.ErrorIs(t, err, errNotFound)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestGetOrLoad_NilCacheAndBackendFailure(t *testing.T) {
+	ctx := context.Background()
+	load := func(ctx context.Context) (int, error) { return 42, nil }
+
+	value, err := GetOrLoad[int](ctx, nil, "answer", 0, load)
+	require.NoError(t, err)
+	assert.Equal(t, 42, value)
+
+	// Redis fora do ar: a leitura cai no loader sem erro
+	server := miniredis.RunT(t)
+	r := NewRedis(redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1}), "", 0)
+	t.Cleanup(func() { _ = r.Close() })
+	server.Close()
+
+	value, err = GetOrLoad(ctx, Cache(r), "answer", 0, load)
+	require.NoError(t, err)
+	assert.Equal(t, 42, value)
+}
+
+func TestInstrument(t *testing.T) {
+	ctx := context.Background()
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	c := Instrument(NewMemory(0, 0), metrics, "users")
+
+	_, _, _ = c.Get(ctx, "k")
+	require.NoError(t, c.Set(ctx, "k", []byte("v"), 0))
+	_, _, _ = c.Get(ctx, "k")
+	_, _, _ = c.Get(ctx, "k")
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.hits.WithLabelValues("users")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.misses.WithLabelValues("users")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.errors.WithLabelValues("users", "get")))
+}
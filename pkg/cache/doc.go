@@ -0,0 +1,35 @@
+// Package cache implementa o cache compartilhado entre requests usado pelos
+// services (usuários, totais de XP e challenges).
+//
+// Este pacote fornece:
+//   - Cache: interface chave/valor com TTL, valores em bytes
+//   - Memory: LRU em memória, por instância
+//   - Redis: cache compartilhado entre instâncias (go-redis)
+//   - GetOrLoad: leitura tipada (JSON) com fallback para o loader
+//   - Prefix e Instrument: namespace por módulo e métricas Prometheus
+//
+// # Consistência
+//
+// O cache é uma otimização: GetOrLoad trata falhas do backend como miss e a
+// leitura segue para o banco. Os services não usam o cache dentro de
+// transações e a invalidação acontece pelos eventos publicados depois do
+// commit (módulo users); o TTL limita a janela de dados obsoletos quando uma
+// invalidação se perde.
+//
+// # Exemplo de Uso
+//
+//	store := cache.NewMemory(10000, 5*time.Minute)
+//	usersCache := cache.Prefix(store, "users:")
+//
+//	user, err := cache.GetOrLoad(ctx, usersCache, cache.Key("user", "42"), 0,
+//		func(ctx context.Context) (*users.User, error) {
+//			return repo.GetByID(ctx, 42)
+//		})
+//
+// Nos módulos o cache chega por module.Deps.Cache(nome), já prefixado e
+// instrumentado; nil quando CACHE_DRIVER=off.
+//
+// # Thread Safety
+//
+// Memory, Redis e os wrappers são seguros para uso concorrente.
+package cache
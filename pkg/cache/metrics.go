@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics - hits, misses e erros por cache nomeado
+type Metrics struct {
+	hits   *prometheus.CounterVec
+	misses *prometheus.CounterVec
+	errors *prometheus.CounterVec
+}
+
+// NewMetrics - registra os contadores em registerer (ex.: o registry do monitoring)
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	metrics := &Metrics{
+		hits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "labend_cache_hits_total",
+			Help: "Leituras encontradas no cache",
+		}, []string{"cache"}),
+		misses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "labend_cache_misses_total",
+			Help: "Leituras ausentes no cache",
+		}, []string{"cache"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "labend_cache_errors_total",
+			Help: "Falhas do backend do cache por operação",
+		}, []string{"cache", "operation"}),
+	}
+
+	for _, collector := range []prometheus.Collector{metrics.hits, metrics.misses, metrics.errors} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return metrics, nil
+}
+
+// Instrument - c contando hits/misses/erros com o rótulo cache=name
+func Instrument(c Cache, metrics *Metrics, name string) Cache {
+	return &instrumented{
+		cache:  c,
+		hits:   metrics.hits.WithLabelValues(name),
+		misses: metrics.misses.WithLabelValues(name),
+		errors: metrics.errors.MustCurryWith(prometheus.Labels{"cache": name}),
+	}
+}
+
+type instrumented struct {
+	cache  Cache
+	hits   prometheus.Counter
+	misses prometheus.Counter
+	errors *prometheus.CounterVec
+}
+
+func (i *instrumented) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, ok, err := i.cache.Get(ctx, key)
+	switch {
+	case err != nil:
+		i.errors.WithLabelValues("get").Inc()
+		i.misses.Inc()
+	case ok:
+		i.hits.Inc()
+	default:
+		i.misses.Inc()
+	}
+	return value, ok, err
+}
+
+func (i *instrumented) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	err := i.cache.Set(ctx, key, value, ttl)
+	if err != nil {
+		i.errors.WithLabelValues("set").Inc()
+	}
+	return err
+}
+
+func (i *instrumented) Delete(ctx context.Context, keys ...string) error {
+	err := i.cache.Delete(ctx, keys...)
+	if err != nil {
+		i.errors.WithLabelValues("delete").Inc()
+	}
+	return err
+}
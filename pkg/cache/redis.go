@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis - cache compartilhado entre as instâncias da aplicação; as entradas
+// expiram pelo TTL do próprio Redis
+type Redis struct {
+	client     redis.UniversalClient
+	prefix     string
+	defaultTTL time.Duration
+}
+
+// NewRedis - prefix isola as chaves da aplicação (ex.: "labend:") e
+// defaultTTL <= 0 usa DefaultTTL
+func NewRedis(client redis.UniversalClient, prefix string, defaultTTL time.Duration) *Redis {
+	if defaultTTL <= 0 {
+		defaultTTL = DefaultTTL
+	}
+	return &Redis{client: client, prefix: prefix, defaultTTL: defaultTTL}
+}
+
+// NewRedisFromURL - conecta pela URL (ex.: "redis://localhost:6379/0")
+func NewRedisFromURL(url, prefix string, defaultTTL time.Duration) (*Redis, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return NewRedis(redis.NewClient(options), prefix, defaultTTL), nil
+}
+
+// Get - valor da chave; redis.Nil vira miss
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := r.client.Get(ctx, r.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set - grava a chave com expiração
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = r.defaultTTL
+	}
+	return r.client.Set(ctx, r.prefix+key, value, ttl).Err()
+}
+
+// Delete - remove as chaves em um único DEL
+func (r *Redis) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		prefixedKeys[i] = r.prefix + key
+	}
+	return r.client.Del(ctx, prefixedKeys...).Err()
+}
+
+// Ping - verifica a conexão (health checks)
+func (r *Redis) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
+// Close - fecha o client
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,437 @@
+package complexity
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// DefaultListSize - multiplicador de listas sem argumento de tamanho (ou com ele omitido)
+const DefaultListSize = 10
+
+// MaxListSize - maior multiplicador considerado; os resolvers reduzem páginas
+// maiores para o mesmo teto (pagination.MaxLimit)
+const MaxListSize = 100
+
+// sizeArgs - argumentos que limitam o tamanho de uma lista ou connection
+var sizeArgs = []string{"first", "last", "limit"}
+
+// Costs - custo por campo no formato "Tipo.campo" (ex.: "User.totalXP").
+// Campos sem anotação custam 1 quando retornam objetos e 0 quando retornam escalares/enums.
+type Costs map[string]int
+
+// Merge - copia as anotações de other (sobrescrevendo as existentes)
+func (c Costs) Merge(other Costs) {
+	for field, cost := range other {
+		c[field] = cost
+	}
+}
+
+// Result - resultado da análise estática de uma operação
+type Result struct {
+	Depth int `json:"depth"`
+	Cost  int `json:"cost"`
+}
+
+// Analyzer - calcula profundidade e custo de operações sem executá-las
+type Analyzer struct {
+	schema          *graphql.Schema
+	costs           Costs
+	defaultListSize int
+}
+
+// NewAnalyzer - costs pode ser nil; defaultListSize <= 0 usa DefaultListSize
+func NewAnalyzer(schema *graphql.Schema, costs Costs, defaultListSize int) *Analyzer {
+	if costs == nil {
+		costs = Costs{}
+	}
+	if defaultListSize <= 0 {
+		defaultListSize = DefaultListSize
+	}
+	return &Analyzer{
+		schema:          schema,
+		costs:           costs,
+		defaultListSize: defaultListSize,
+	}
+}
+
+// Analyze - analisa a operação escolhida por operationName (ou a única do documento).
+// Erros de sintaxe são retornados; campos desconhecidos são ignorados e ficam
+// para a validação do graphql-go.
+func (a *Analyzer) Analyze(query, operationName string, variables map[string]interface{}) (Result, error) {
+	return a.AnalyzeWithin(query, operationName, variables, Limit{})
+}
+
+// AnalyzeWithin - como Analyze, mas interrompe a análise assim que limit é
+// ultrapassado; o Result então traz valores parciais, já acima do limite
+func (a *Analyzer) AnalyzeWithin(query, operationName string, variables map[string]interface{}, limit Limit) (Result, error) {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return Result{}, err
+	}
+
+	walker := &walker{
+		analyzer:  a,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+		visiting:  make(map[string]bool),
+		memo:      make(map[fragmentKey]fragmentCost),
+		limit:     limit,
+		scale:     1,
+	}
+
+	var operation *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			walker.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				if operation == nil {
+					operation = def
+				}
+			}
+		}
+	}
+	if operation == nil {
+		return Result{}, nil
+	}
+
+	walker.variables = withDefaults(operation, variables)
+
+	var root *graphql.Object
+	switch operation.Operation {
+	case ast.OperationTypeMutation:
+		root = a.schema.MutationType()
+	case ast.OperationTypeSubscription:
+		root = a.schema.SubscriptionType()
+	default:
+		root = a.schema.QueryType()
+	}
+	if root == nil {
+		return Result{}, nil
+	}
+
+	cost, depth := walker.selectionSet(root, operation.SelectionSet, false)
+	if walker.exceeded {
+		return Result{Depth: max(depth, walker.maxDepth), Cost: max(cost, walker.spent)}, nil
+	}
+	return Result{Depth: depth, Cost: cost}, nil
+}
+
+// withDefaults - aplica os valores default declarados nas variáveis da operação
+func withDefaults(operation *ast.OperationDefinition, variables map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(variables))
+	for _, def := range operation.VariableDefinitions {
+		if def.DefaultValue != nil {
+			merged[def.Variable.Name.Value] = def.DefaultValue.GetValue()
+		}
+	}
+	for name, value := range variables {
+		merged[name] = value
+	}
+	return merged
+}
+
+// walker - estado de uma análise
+type walker struct {
+	analyzer  *Analyzer
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	visiting  map[string]bool
+	memo      map[fragmentKey]fragmentCost
+
+	// Parada antecipada: spent acumula custo * scale (produto dos
+	// multiplicadores dos ancestrais), ou seja, o custo total já garantido
+	limit    Limit
+	scale    int
+	spent    int
+	depth    int
+	maxDepth int
+	exceeded bool
+}
+
+// fragmentKey - o custo de um fragment depende do tipo em que é expandido e de
+// a connection pai já ter multiplicado pela página
+type fragmentKey struct {
+	name   string
+	parent string
+	sized  bool
+}
+
+type fragmentCost struct {
+	cost  int
+	depth int
+}
+
+// selectionSet - custo e profundidade de uma seleção sobre parent.
+// sized indica que o campo pai já multiplicou pelo tamanho da página (connections):
+// a lista interna (edges) não é multiplicada de novo.
+func (w *walker) selectionSet(parent graphql.Type, set *ast.SelectionSet, sized bool) (cost, depth int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		if w.exceeded {
+			break
+		}
+		var c, d int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if w.skipped(selection.Directives) {
+				continue
+			}
+			c, d = w.field(parent, selection, sized)
+		case *ast.InlineFragment:
+			if w.skipped(selection.Directives) {
+				continue
+			}
+			target := parent
+			if selection.TypeCondition != nil {
+				if t := w.analyzer.schema.Type(selection.TypeCondition.Name.Value); t != nil {
+					target = t
+				}
+			}
+			c, d = w.selectionSet(target, selection.SelectionSet, sized)
+		case *ast.FragmentSpread:
+			if w.skipped(selection.Directives) {
+				continue
+			}
+			c, d = w.fragment(parent, selection.Name.Value, sized)
+		}
+
+		cost = satAdd(cost, c)
+		if d > depth {
+			depth = d
+		}
+	}
+	return cost, depth
+}
+
+// fragment - expande um fragment nomeado (ciclos são ignorados; a validação os rejeita).
+// O resultado é memorizado por (fragment, tipo): spreads repetidos não percorrem
+// o fragment de novo
+func (w *walker) fragment(parent graphql.Type, name string, sized bool) (int, int) {
+	fragment, ok := w.fragments[name]
+	if !ok || w.visiting[name] {
+		return 0, 0
+	}
+
+	key := fragmentKey{name: name, parent: parent.Name(), sized: sized}
+	if cached, ok := w.memo[key]; ok {
+		w.charge(cached.cost, cached.depth)
+		return cached.cost, cached.depth
+	}
+
+	w.visiting[name] = true
+	defer delete(w.visiting, name)
+
+	target := parent
+	if fragment.TypeCondition != nil {
+		if t := w.analyzer.schema.Type(fragment.TypeCondition.Name.Value); t != nil {
+			target = t
+		}
+	}
+	cost, depth := w.selectionSet(target, fragment.SelectionSet, sized)
+	if !w.exceeded {
+		w.memo[key] = fragmentCost{cost: cost, depth: depth}
+	}
+	return cost, depth
+}
+
+// field - custo = custo do campo + multiplicador * custo da seleção filha
+func (w *walker) field(parent graphql.Type, field *ast.Field, sized bool) (int, int) {
+	name := field.Name.Value
+	// Introspecção não entra na conta
+	if strings.HasPrefix(name, "__") {
+		return 0, 0
+	}
+
+	def := fieldDefinition(parent, name)
+	if def == nil {
+		return 0, 0
+	}
+
+	named := namedType(def.Type)
+	cost, annotated := w.analyzer.costs[parent.Name()+"."+name]
+	if !annotated && !graphql.IsLeafType(named) {
+		cost = 1
+	}
+	w.charge(cost, 1)
+
+	multiplier := 1
+	childSized := false
+	if size, ok := w.sizeArgument(def, field); ok {
+		multiplier = size
+		// Uma connection paginada já contou o tamanho da página
+		childSized = !isList(def.Type)
+	} else if isList(def.Type) && !sized {
+		multiplier = w.analyzer.defaultListSize
+	}
+
+	scale := w.scale
+	w.scale = satMul(scale, multiplier)
+	w.depth++
+	childCost, childDepth := w.selectionSet(named, field.SelectionSet, childSized)
+	w.depth--
+	w.scale = scale
+
+	return satAdd(cost, satMul(multiplier, childCost)), childDepth + 1
+}
+
+// charge - registra cost (relativo ao campo atual) e a profundidade alcançada,
+// marcando exceeded quando o limite já não pode ser respeitado
+func (w *walker) charge(cost, depth int) {
+	w.spent = satAdd(w.spent, satMul(cost, w.scale))
+	if reached := w.depth + depth; reached > w.maxDepth {
+		w.maxDepth = reached
+	}
+	if (w.limit.MaxCost > 0 && w.spent > w.limit.MaxCost) ||
+		(w.limit.MaxDepth > 0 && w.maxDepth > w.limit.MaxDepth) {
+		w.exceeded = true
+	}
+}
+
+// sizeArgument - valor de first/last/limit; campos que declaram o argumento
+// sem recebê-lo usam o default do argumento ou o tamanho padrão de lista
+func (w *walker) sizeArgument(def *graphql.FieldDefinition, field *ast.Field) (int, bool) {
+	for _, argName := range sizeArgs {
+		var declared *graphql.Argument
+		for _, arg := range def.Args {
+			if arg.Name() == argName {
+				declared = arg
+				break
+			}
+		}
+		if declared == nil {
+			continue
+		}
+
+		for _, arg := range field.Arguments {
+			if arg.Name.Value == argName {
+				if size, ok := toInt(w.value(arg.Value)); ok && size >= 0 {
+					return min(size, MaxListSize), true
+				}
+			}
+		}
+		if size, ok := toInt(declared.DefaultValue); ok && size >= 0 {
+			return min(size, MaxListSize), true
+		}
+		return w.analyzer.defaultListSize, true
+	}
+	return 0, false
+}
+
+// skipped - avalia @skip(if:) e @include(if:)
+func (w *walker) skipped(directives []*ast.Directive) bool {
+	for _, directive := range directives {
+		for _, arg := range directive.Arguments {
+			if arg.Name.Value != "if" {
+				continue
+			}
+			condition, _ := w.value(arg.Value).(bool)
+			switch directive.Name.Value {
+			case "skip":
+				if condition {
+					return true
+				}
+			case "include":
+				if !condition {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// value - valor literal ou de variável
+func (w *walker) value(value ast.Value) interface{} {
+	if variable, ok := value.(*ast.Variable); ok {
+		return w.variables[variable.Name.Value]
+	}
+	if value == nil {
+		return nil
+	}
+	return value.GetValue()
+}
+
+func fieldDefinition(parent graphql.Type, name string) *graphql.FieldDefinition {
+	switch parent := parent.(type) {
+	case *graphql.Object:
+		return parent.Fields()[name]
+	case *graphql.Interface:
+		return parent.Fields()[name]
+	}
+	return nil
+}
+
+// namedType - remove NonNull e List
+func namedType(t graphql.Type) graphql.Type {
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			t = wrapped.OfType
+		default:
+			return t
+		}
+	}
+}
+
+func isList(t graphql.Type) bool {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	_, ok := t.(*graphql.List)
+	return ok
+}
+
+// toInt - literais do AST chegam como string; variáveis JSON como float64.
+// Valores fora de int (ex.: 1e300) saturam em vez de estourar
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		if math.IsNaN(v) {
+			return 0, false
+		}
+		if v >= math.MaxInt32 {
+			return math.MaxInt32, true
+		}
+		return int(v), true
+	case string:
+		n, err := strconv.Atoi(v)
+		if err != nil && errors.Is(err, strconv.ErrRange) && !strings.HasPrefix(v, "-") {
+			return math.MaxInt32, true
+		}
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// satAdd e satMul - aritmética que satura em math.MaxInt32 (custos são >= 0)
+func satAdd(a, b int) int {
+	if a > math.MaxInt32-b {
+		return math.MaxInt32
+	}
+	return a + b
+}
+
+func satMul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	if a > math.MaxInt32/b {
+		return math.MaxInt32
+	}
+	return a * b
+}
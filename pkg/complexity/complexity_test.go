@@ -0,0 +1,223 @@
+package complexity_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/complexity"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
+)
+
+// newSchema - Query { item, items(first, after): ItemConnection, search(limit): [Item] }
+// com Item { id, name, tags: [String], children: [Item] }
+func newSchema(t *testing.T) *graphql.Schema {
+	t.Helper()
+
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.ID},
+			"name": &graphql.Field{Type: graphql.String},
+			"tags": &graphql.Field{Type: graphql.NewList(graphql.String)},
+		},
+	})
+	itemType.AddFieldConfig("children", &graphql.Field{Type: graphql.NewList(itemType)})
+
+	resolveItem := func(p graphql.ResolveParams) (interface{}, error) {
+		return map[string]interface{}{"id": "1", "name": "a"}, nil
+	}
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"item": &graphql.Field{Type: itemType, Resolve: resolveItem},
+				"items": &graphql.Field{
+					Type: pagination.NewConnectionType("Item", itemType),
+					Args: pagination.ConnectionArgs(nil),
+				},
+				"search": &graphql.Field{
+					Type: graphql.NewList(itemType),
+					Args: graphql.FieldConfigArgument{
+						"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 5},
+					},
+				},
+			},
+		}),
+		Extensions: []graphql.Extension{complexity.Extension{}},
+	})
+	require.NoError(t, err)
+	return &schema
+}
+
+func TestAnalyzer_Analyze(t *testing.T) {
+	analyzer := complexity.NewAnalyzer(newSchema(t), complexity.Costs{"Item.name": 3}, 10)
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		depth     int
+		cost      int
+	}{
+		{"escalares custam 0", `{ item { id } }`, nil, 2, 1},
+		{"custo anotado", `{ item { name } }`, nil, 2, 4},
+		{"lista sem tamanho usa o padrão", `{ item { children { id } } }`, nil, 3, 1 + (1 + 10*0)},
+		{"lista aninhada multiplica", `{ item { children { children { id } } } }`, nil, 4, 1 + 1 + 10*1},
+		// items(1) + 20 * (edges(1) + node(1)) — edges não multiplica de novo
+		{"connection usa first", `{ items(first: 20) { edges { node { id } } } }`, nil, 4, 1 + 20*2},
+		{"first via variável", `query($n: Int) { items(first: $n) { edges { node { id } } } }`,
+			map[string]interface{}{"n": float64(50)}, 4, 1 + 50*2},
+		{"default do argumento", `{ search { id } }`, nil, 2, 1},
+		{"limit explícito", `{ search(limit: 30) { children { id } } }`, nil, 3, 1 + 30*1},
+		{"fragments", `{ item { ...F } } fragment F on Item { name children { id } }`, nil, 3, 1 + 3 + 1},
+		{"skip", `{ item { children @skip(if: true) { id } } }`, nil, 1, 1},
+		{"introspecção é ignorada", `{ __schema { types { name } } item { id } }`, nil, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := analyzer.Analyze(tt.query, "", tt.variables)
+			require.NoError(t, err)
+			assert.Equal(t, tt.depth, result.Depth, "depth")
+			assert.Equal(t, tt.cost, result.Cost, "cost")
+		})
+	}
+}
+
+func TestLimiter_RolesAndErrors(t *testing.T) {
+	limiter := complexity.NewLimiter(complexity.NewAnalyzer(newSchema(t), nil, 10), complexity.Limits{
+		Default: complexity.Limit{MaxDepth: 3, MaxCost: 50},
+		Roles:   map[string]complexity.Limit{"moderator": {MaxDepth: 5, MaxCost: 500}},
+	})
+	query := `{ items(first: 100) { edges { node { id } } } }`
+
+	_, err := limiter.Check(context.Background(), `{ item { children { children { id } } } }`, "", nil)
+	var limitErr *complexity.LimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, "query depth 4 exceeds the maximum of 3", err.Error())
+	assert.Equal(t, complexity.ErrorCode, limitErr.Extensions()["code"])
+	assert.Equal(t, complexity.RoleDefault, limitErr.Extensions()["role"])
+
+	// A análise para em edges (1 + 100*1), antes de alcançar a profundidade 4
+	_, err = limiter.Check(context.Background(), query, "", nil)
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, "query cost 101 exceeds the maximum of 50", err.Error())
+
+	ctx, err := limiter.Check(complexity.WithRole(context.Background(), "moderator"), query, "", nil)
+	require.NoError(t, err)
+	report := complexity.ReportFromContext(ctx)
+	require.NotNil(t, report)
+	assert.Equal(t, 201, report.Cost)
+	assert.Equal(t, 500, report.MaxCost)
+}
+
+func TestLimiter_Handler(t *testing.T) {
+	schema := newSchema(t)
+	limiter := complexity.NewLimiter(complexity.NewAnalyzer(schema, nil, 10), complexity.Limits{
+		Default: complexity.Limit{MaxDepth: 5, MaxCost: 20},
+	})
+	server := httptest.NewServer(limiter.Handler(handler.New(&handler.Config{Schema: schema})))
+	defer server.Close()
+
+	post := func(query string) map[string]interface{} {
+		body, _ := json.Marshal(map[string]string{"query": query})
+		resp, err := http.Post(server.URL, "application/json", strings.NewReader(string(body)))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return result
+	}
+
+	// Aceita: executa e reporta o custo nas extensions
+	accepted := post(`{ item { id } }`)
+	assert.Equal(t, map[string]interface{}{"item": map[string]interface{}{"id": "1"}}, accepted["data"])
+	assert.Equal(t, map[string]interface{}{"depth": 2.0, "cost": 1.0, "maxDepth": 5.0, "maxCost": 20.0},
+		accepted["extensions"].(map[string]interface{})[complexity.ExtensionName])
+
+	// Rejeitada: não executa e retorna erro estruturado
+	rejected := post(`{ items(first: 50) { edges { node { id } } } }`)
+	assert.Nil(t, rejected["data"])
+	errs := rejected["errors"].([]interface{})
+	require.Len(t, errs, 1)
+	extensions := errs[0].(map[string]interface{})["extensions"].(map[string]interface{})
+	assert.Equal(t, complexity.ErrorCode, extensions["code"])
+	// Custo parcial: a análise para em edges (1 + 50*1), já acima do limite
+	assert.Equal(t, 51.0, extensions["cost"])
+	assert.Equal(t, 20.0, extensions["maxCost"])
+}
+
+func TestAnalyzer_Bounds(t *testing.T) {
+	analyzer := complexity.NewAnalyzer(newSchema(t), nil, 10)
+
+	t.Run("tamanhos acima de MaxListSize são reduzidos", func(t *testing.T) {
+		for _, variables := range []map[string]interface{}{
+			{"n": float64(1e300)},
+			{"n": float64(1000000)},
+		} {
+			result, err := analyzer.Analyze(`query($n: Int) { items(first: $n) { edges { node { id } } } }`, "", variables)
+			require.NoError(t, err)
+			assert.Equal(t, 1+complexity.MaxListSize*2, result.Cost)
+		}
+
+		result, err := analyzer.Analyze(`{ search(limit: 99999999999999999999) { id } }`, "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Cost)
+		result, err = analyzer.Analyze(`{ search(limit: 99999999999999999999) { children { id } } }`, "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1+complexity.MaxListSize, result.Cost)
+	})
+
+	t.Run("custo satura em vez de estourar", func(t *testing.T) {
+		query := "{ item " + strings.Repeat("{ children ", 12) + "{ id }" + strings.Repeat(" }", 12) + " }"
+		result, err := analyzer.Analyze(query, "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, math.MaxInt32, result.Cost)
+	})
+
+	t.Run("fragments repetidos são memorizados", func(t *testing.T) {
+		// F0 expande F1 duas vezes, F1 expande F2 duas vezes... sem memo seriam 2^30 visitas
+		const levels = 30
+		var doc strings.Builder
+		doc.WriteString("{ item { ...F0 } }")
+		for i := 0; i < levels; i++ {
+			fmt.Fprintf(&doc, " fragment F%d on Item { ...F%d ...F%d }", i, i+1, i+1)
+		}
+		fmt.Fprintf(&doc, " fragment F%d on Item { children { id } }", levels)
+
+		result, err := analyzer.Analyze(doc.String(), "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1+1<<levels, result.Cost)
+		assert.Equal(t, 3, result.Depth)
+	})
+
+	t.Run("para ao ultrapassar o limite", func(t *testing.T) {
+		query := `{ a: items(first: 100) { edges { node { id } } } b: items(first: 100) { edges { node { id } } } }`
+
+		full, err := analyzer.Analyze(query, "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2*(1+100*2), full.Cost)
+
+		partial, err := analyzer.AnalyzeWithin(query, "", nil, complexity.Limit{MaxCost: 50})
+		require.NoError(t, err)
+		assert.Greater(t, partial.Cost, 50)
+		assert.Less(t, partial.Cost, full.Cost)
+
+		deep, err := analyzer.AnalyzeWithin(`{ item { children { children { children { id } } } } }`, "", nil, complexity.Limit{MaxDepth: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 3, deep.Depth)
+	})
+}
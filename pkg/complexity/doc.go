@@ -0,0 +1,43 @@
+// Package complexity implementa a análise estática de profundidade e custo
+// de operações GraphQL, aplicada antes da execução.
+//
+// Este pacote fornece:
+//   - Analyzer: percorre o documento (fragments, @skip/@include, variáveis) sobre o schema
+//   - Costs: anotações de custo por campo no formato "Tipo.campo"
+//   - Limits: limites de profundidade e custo por papel (WithRole/RoleFromContext)
+//   - Limiter.Handler: middleware HTTP que rejeita operações com erro estruturado
+//   - Extension: reporta a análise em "extensions.complexity" da resposta
+//
+// # Cálculo
+//
+// Cada campo custa o valor anotado em Costs ou, sem anotação, 1 quando retorna
+// um objeto e 0 quando retorna escalar/enum. O custo dos filhos é multiplicado
+// pelo argumento first/last/limit do campo; listas sem esse argumento usam o
+// tamanho padrão. Em connections, a lista edges não é multiplicada de novo.
+//
+//	custo(campo) = custo anotado + multiplicador * Σ custo(filhos)
+//
+// Campos de introspecção (__schema, __type, __typename) não entram na conta.
+//
+// Tamanhos são limitados a MaxListSize (o mesmo teto dos resolvers) e a conta
+// satura em math.MaxInt32. Fragments são calculados uma vez por tipo, e o
+// Limiter interrompe a análise assim que o limite do papel é ultrapassado;
+// nesse caso o custo reportado é o parcial, já acima do limite.
+//
+// # Exemplo de Uso
+//
+//	schema.AddExtensions(complexity.Extension{})
+//	limiter := complexity.NewLimiter(
+//		complexity.NewAnalyzer(&schema, costs, complexity.DefaultListSize),
+//		complexity.Limits{
+//			Default: complexity.Limit{MaxDepth: 10, MaxCost: 1000},
+//			Roles:   map[string]complexity.Limit{"moderator": {MaxDepth: 15, MaxCost: 5000}},
+//		},
+//	)
+//	http.Handle("/graphql", limiter.Handler(graphqlHandler))
+//
+// # Thread Safety
+//
+// Analyzer e Limiter não guardam estado por operação e podem ser
+// compartilhados entre requests.
+package complexity
@@ -0,0 +1,44 @@
+package complexity
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// ExtensionName - chave da análise nas extensions da resposta
+const ExtensionName = "complexity"
+
+// Extension - extensão do graphql-go que reporta profundidade, custo e limites
+// calculados pelo Limiter em "extensions.complexity" da resposta
+type Extension struct{}
+
+var _ graphql.Extension = Extension{}
+
+func (Extension) Init(ctx context.Context, _ *graphql.Params) context.Context { return ctx }
+
+func (Extension) Name() string { return ExtensionName }
+
+func (Extension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(error) {}
+}
+
+func (Extension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func([]gqlerrors.FormattedError) {}
+}
+
+func (Extension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	return ctx, func(*graphql.Result) {}
+}
+
+func (Extension) ResolveFieldDidStart(ctx context.Context, _ *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	return ctx, func(interface{}, error) {}
+}
+
+func (Extension) HasResult() bool { return true }
+
+// GetResult - nil quando a operação não passou pelo Limiter
+func (Extension) GetResult(ctx context.Context) interface{} {
+	return ReportFromContext(ctx)
+}
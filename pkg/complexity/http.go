@@ -0,0 +1,77 @@
+package complexity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/handler"
+)
+
+// Handler - middleware que rejeita operações acima dos limites antes de chamar next.
+// A rejeição segue o formato de resposta GraphQL: {"errors": [{..., "extensions": {"code": ...}}]}.
+func (l *Limiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// O corpo é lido para a análise e restaurado para o handler GraphQL
+		var body []byte
+		if r.Body != nil {
+			var err error
+			if body, err = io.ReadAll(r.Body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		opts := handler.NewRequestOptions(r)
+		if body != nil {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		if opts == nil || opts.Query == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, err := l.Check(r.Context(), opts.Query, opts.OperationName, opts.Variables)
+		if err != nil {
+			writeLimitError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func writeLimitError(w http.ResponseWriter, err error) {
+	response := map[string]interface{}{
+		"errors": []gqlerrors.FormattedError{FormatError(err)},
+	}
+
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) {
+		response["extensions"] = map[string]interface{}{
+			ExtensionName: Report{Result: limitErr.Result, Limit: limitErr.Limit},
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// FormatError - erro GraphQL com as extensions de erros que as fornecem (ex.: *LimitError)
+func FormatError(err error) gqlerrors.FormattedError {
+	formatted := gqlerrors.FormattedError{
+		Message:   err.Error(),
+		Locations: []location.SourceLocation{},
+	}
+	var extended gqlerrors.ExtendedError
+	if errors.As(err, &extended) {
+		formatted.Extensions = extended.Extensions()
+	}
+	return formatted
+}
@@ -0,0 +1,126 @@
+package complexity
+
+import (
+	"context"
+	"fmt"
+)
+
+// RoleDefault - papel usado quando o contexto não informa nenhum
+const RoleDefault = "default"
+
+// ErrorCode - código do erro estruturado retornado ao cliente
+const ErrorCode = "QUERY_TOO_COMPLEX"
+
+// Limit - limites de uma operação (0 = sem limite)
+type Limit struct {
+	MaxDepth int `json:"maxDepth"`
+	MaxCost  int `json:"maxCost"`
+}
+
+// Limits - limites por papel; papéis sem entrada usam Default
+type Limits struct {
+	Default Limit
+	Roles   map[string]Limit
+}
+
+// For - limites do papel informado
+func (l Limits) For(role string) Limit {
+	if limit, ok := l.Roles[role]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+type roleKey struct{}
+
+// WithRole - define o papel do solicitante (ex.: no middleware de autenticação)
+func WithRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, roleKey{}, role)
+}
+
+// RoleFromContext - papel do solicitante ou RoleDefault
+func RoleFromContext(ctx context.Context) string {
+	if role, ok := ctx.Value(roleKey{}).(string); ok && role != "" {
+		return role
+	}
+	return RoleDefault
+}
+
+// LimitError - operação acima dos limites do papel
+type LimitError struct {
+	Result Result
+	Limit  Limit
+	Role   string
+}
+
+func (e *LimitError) Error() string {
+	if e.Limit.MaxDepth > 0 && e.Result.Depth > e.Limit.MaxDepth {
+		return fmt.Sprintf("query depth %d exceeds the maximum of %d", e.Result.Depth, e.Limit.MaxDepth)
+	}
+	return fmt.Sprintf("query cost %d exceeds the maximum of %d", e.Result.Cost, e.Limit.MaxCost)
+}
+
+// Extensions - detalhes do erro no formato de extensions do GraphQL
+func (e *LimitError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":     ErrorCode,
+		"role":     e.Role,
+		"depth":    e.Result.Depth,
+		"maxDepth": e.Limit.MaxDepth,
+		"cost":     e.Result.Cost,
+		"maxCost":  e.Limit.MaxCost,
+	}
+}
+
+// Check - retorna *LimitError se o resultado ultrapassar algum limite
+func (l Limit) Check(result Result, role string) error {
+	if (l.MaxDepth > 0 && result.Depth > l.MaxDepth) || (l.MaxCost > 0 && result.Cost > l.MaxCost) {
+		return &LimitError{Result: result, Limit: l, Role: role}
+	}
+	return nil
+}
+
+// Report - análise aceita, reportada nas extensions da resposta
+type Report struct {
+	Result
+	Limit
+}
+
+type reportKey struct{}
+
+// ReportFromContext - análise da operação em andamento (nil se não houve)
+func ReportFromContext(ctx context.Context) *Report {
+	report, _ := ctx.Value(reportKey{}).(*Report)
+	return report
+}
+
+// Limiter - analisa operações e aplica os limites do papel do solicitante
+type Limiter struct {
+	analyzer *Analyzer
+	limits   Limits
+}
+
+// NewLimiter - cria o limiter
+func NewLimiter(analyzer *Analyzer, limits Limits) *Limiter {
+	return &Limiter{analyzer: analyzer, limits: limits}
+}
+
+// Check - analisa a operação com os limites do papel do contexto (WithRole);
+// se aceita, devolve o contexto com o Report. A análise para assim que o
+// limite é ultrapassado. Erros de sintaxe não são tratados aqui: a execução
+// os reporta normalmente.
+func (l *Limiter) Check(ctx context.Context, query, operationName string, variables map[string]interface{}) (context.Context, error) {
+	role := RoleFromContext(ctx)
+	limit := l.limits.For(role)
+
+	result, err := l.analyzer.AnalyzeWithin(query, operationName, variables, limit)
+	if err != nil {
+		return ctx, nil
+	}
+
+	if err := limit.Check(result, role); err != nil {
+		return ctx, err
+	}
+
+	return context.WithValue(ctx, reportKey{}, &Report{Result: result, Limit: limit}), nil
+}
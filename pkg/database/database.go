@@ -5,18 +5,24 @@ import (
 	"sync"
 	"time"
 
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
 // Config - configuração do banco de dados
 type Config struct {
+	// Driver - "postgres" ou "sqlite"; vazio detecta pelo esquema do DSN
+	Driver       string
 	DSN          string
 	MaxIdleConns int
 	MaxOpenConns int
 	MaxLifetime  time.Duration
 	LogLevel     logger.LogLevel
+
+	// ReplicaDSNs - réplicas de leitura; só as queries com o scope ReadReplica usam
+	ReplicaDSNs []string
+	// ReplicaPolicy - escolha da réplica de cada leitura (padrão RoundRobin)
+	ReplicaPolicy ReplicaPolicy
 }
 
 // ModelRegistry - registro global de modelos para migração
@@ -46,9 +52,15 @@ func GetRegisteredModels() []interface{} {
 	return result
 }
 
-// Connect - conecta ao banco de dados PostgreSQL
+// Connect - conecta ao banco de dados (primário e réplicas de leitura) com o
+// driver da configuração ou o detectado pelo DSN
 func Connect(config Config) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(config.DSN), &gorm.Config{
+	dialect, err := dialector(config.Driver, config.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialect, &gorm.Config{
 		Logger: logger.Default.LogMode(config.LogLevel),
 	})
 	if err != nil {
@@ -64,6 +76,13 @@ func Connect(config Config) (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
 	sqlDB.SetConnMaxLifetime(config.MaxLifetime)
 
+	if len(config.ReplicaDSNs) > 0 {
+		if err := useReplicas(db, config); err != nil {
+			sqlDB.Close()
+			return nil, err
+		}
+	}
+
 	return db, nil
 }
 
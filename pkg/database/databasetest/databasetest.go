@@ -0,0 +1,57 @@
+// Package databasetest cria bancos SQLite em memória para testes de
+// repositórios e services, sem PostgreSQL.
+//
+//	func TestRepository_Create(t *testing.T) {
+//		db := databasetest.New(t, &User{}, &UserXP{})
+//		repo := NewRepository(db)
+//		...
+//	}
+//
+// Cada chamada abre um banco isolado (nome único com cache compartilhado
+// entre as conexões do pool), migra os modelos registrados com
+// database.RegisterModel mais os informados e o fecha no t.Cleanup.
+// SQL específico do PostgreSQL (tsvector, advisory locks) não roda no SQLite.
+package databasetest
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+)
+
+var sequence atomic.Uint64
+
+// New - banco SQLite em memória com os modelos registrados e models migrados
+func New(t testing.TB, models ...interface{}) *gorm.DB {
+	t.Helper()
+
+	// O banco em memória existe enquanto houver conexão aberta: sem MaxLifetime
+	// o pool mantém as conexões ociosas até o Close
+	dsn := fmt.Sprintf("file:labbend_test_%d?mode=memory&cache=shared&_fk=1", sequence.Add(1))
+	db, err := database.Connect(database.Config{
+		Driver:       database.DriverSQLite,
+		DSN:          dsn,
+		MaxIdleConns: 4,
+		MaxOpenConns: 4,
+		LogLevel:     logger.Silent,
+	})
+	if err != nil {
+		t.Fatalf("databasetest: failed to open sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		database.Close(db)
+	})
+
+	models = append(database.GetRegisteredModels(), models...)
+	if len(models) > 0 {
+		if err := database.AutoMigrate(db, models...); err != nil {
+			t.Fatalf("databasetest: failed to migrate models: %v", err)
+		}
+	}
+	return db
+}
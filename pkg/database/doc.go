@@ -5,13 +5,68 @@
 //
 // # Funcionalidades Principais
 //
-//   - Conexão configurável com PostgreSQL via GORM
+//   - Conexão configurável com PostgreSQL ou SQLite via GORM (driver pelo DSN)
 //   - Pool de conexões otimizado
+//   - Migrações SQL versionadas (Migrator) com schema_migrations e advisory lock
+//   - Réplicas de leitura com read-your-writes por request
 //   - Sistema de registro automático de modelos
-//   - Migração automática thread-safe
-//   - Gerenciamento de transações
+//   - Migração automática thread-safe (testes e ferramentas)
+//   - Transações no contexto, com savepoints, isolamento e retry
 //   - Logging integrado
 //
+// # Migrações Versionadas
+//
+// Cada módulo mantém arquivos "<versão>_<nome>.up.sql" e ".down.sql"; a
+// versão (timestamp UTC) ordena as migrações de todos os módulos. Cada
+// migração roda em transação junto com o registro em schema_migrations, e no
+// PostgreSQL um advisory lock impede que duas instâncias migrem ao mesmo tempo:
+//
+//	migrations, err := database.LoadMigrations("users", usersMigrationsFS)
+//	migrator, err := database.NewMigrator(db, migrations)
+//	applied, err := migrator.Up(ctx)
+//	reverted, err := migrator.Down(ctx, 1)
+//	statuses, err := migrator.Status(ctx)
+//
+// # Transações
+//
+// TxManager guarda a transação ativa no contexto; repositórios que usam
+// FromContext participam dela, chamadas aninhadas viram savepoints e falhas
+// de serialização ou deadlocks repetem a transação externa:
+//
+//	err := txManager.InTransaction(ctx, func(ctx context.Context) error {
+//		return database.FromContext(ctx, db).Create(&user).Error
+//	}, database.WithIsolation(sql.LevelSerializable))
+//
+// Efeitos fora do banco (eventos, cache, notificações) são registrados com
+// OnCommit e OnRollback e só rodam quando a transação termina.
+//
+// # Réplicas de Leitura
+//
+// Config.ReplicaDSNs abre as réplicas e Config.ReplicaPolicy escolhe uma a
+// cada leitura. Só queries com o scope ReadReplica usam réplicas; escritas,
+// transações e as leituras de um request que já escreveu (WithReadYourWrites)
+// ficam no primário:
+//
+//	ctx = database.WithReadYourWrites(ctx)
+//	db.WithContext(ctx).Scopes(database.ReadReplica).Find(&users)
+//
+// # SQLite
+//
+// Config.Driver (ou o esquema do DSN: "postgres://", "sqlite://", "file:")
+// escolhe o driver. Modelos usam JSON, StringList e TSVector para colunas
+// que mudam de tipo entre os bancos; no SQLite o schema vem do AutoMigrate
+// dos modelos registrados, e o pacote databasetest cria bancos em memória
+// para testes:
+//
+//	db := databasetest.New(t, &User{}, &UserXP{})
+//
+// IsUniqueViolation reconhece violações de índice único nos dois drivers
+// (o GORM não traduz os erros sem TranslateError):
+//
+//	if database.IsUniqueViolation(err, "idx_users_nickname", "users.nickname") {
+//		return errors.AlreadyExists("user", "nickname", user.Nickname)
+//	}
+//
 // # Registro Automático de Modelos
 //
 // O pacote oferece um sistema de registro automático que permite que módulos
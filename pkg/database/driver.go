@@ -0,0 +1,102 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Drivers suportados (Config.Driver)
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// DriverFromDSN - driver pelo esquema do DSN: "postgres://" e "postgresql://"
+// (ou o formato "host=... dbname=...") usam PostgreSQL; "sqlite://" e "file:"
+// usam SQLite
+func DriverFromDSN(dsn string) (string, error) {
+	scheme, _, found := strings.Cut(dsn, ":")
+	if !found {
+		if strings.Contains(dsn, "=") {
+			return DriverPostgres, nil
+		}
+		return "", fmt.Errorf("cannot detect database driver from DSN")
+	}
+	switch strings.ToLower(scheme) {
+	case "postgres", "postgresql":
+		return DriverPostgres, nil
+	case "sqlite", "sqlite3", "file":
+		return DriverSQLite, nil
+	}
+	return "", fmt.Errorf("unsupported database DSN scheme %q", scheme)
+}
+
+// dialector - dialector do GORM para o driver; vazio detecta pelo DSN
+func dialector(driver, dsn string) (gorm.Dialector, error) {
+	if driver == "" {
+		detected, err := DriverFromDSN(dsn)
+		if err != nil {
+			return nil, err
+		}
+		driver = detected
+	}
+
+	switch driver {
+	case DriverPostgres:
+		return postgres.Open(dsn), nil
+	case DriverSQLite:
+		return sqlite.Open(sqliteDSN(dsn)), nil
+	}
+	return nil, fmt.Errorf("unsupported database driver %q", driver)
+}
+
+// sqliteDSN - remove o esquema "sqlite://": "sqlite://labend.db" abre o
+// arquivo labend.db e "sqlite://:memory:" um banco em memória; DSNs "file:"
+// seguem sem mudança para o go-sqlite3
+func sqliteDSN(dsn string) string {
+	for _, prefix := range []string{"sqlite://", "sqlite3://", "sqlite:", "sqlite3:"} {
+		if len(dsn) >= len(prefix) && strings.EqualFold(dsn[:len(prefix)], prefix) {
+			return dsn[len(prefix):]
+		}
+	}
+	return dsn
+}
+
+// IsSQLite - indica se db usa SQLite (ex.: pular SQL específico do PostgreSQL)
+func IsSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == DriverSQLite
+}
+
+// sqlStateUniqueViolation - SQLSTATE do PostgreSQL para violação de índice único
+const sqlStateUniqueViolation = "23505"
+
+// IsUniqueViolation - err viola um índice único (PostgreSQL 23505 ou
+// "UNIQUE constraint failed" do SQLite). Com names, apenas violações cuja
+// mensagem cita um deles: o índice no PostgreSQL ("idx_users_nickname") ou a
+// coluna no SQLite ("users.nickname")
+func IsUniqueViolation(err error, names ...string) bool {
+	if err == nil {
+		return false
+	}
+
+	var pgErr interface{ SQLState() string }
+	unique := errors.Is(err, gorm.ErrDuplicatedKey) ||
+		(errors.As(err, &pgErr) && pgErr.SQLState() == sqlStateUniqueViolation) ||
+		strings.Contains(err.Error(), "UNIQUE constraint failed")
+	if !unique || len(names) == 0 {
+		return unique
+	}
+
+	message := err.Error()
+	for _, name := range names {
+		if strings.Contains(message, name) {
+			return true
+		}
+	}
+	return false
+}
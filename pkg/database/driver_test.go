@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+)
+
+// recordingDriver - driver database/sql falso; o DSN é o nome do pool e cada
+// comando registra o pool que o executou
+type recordingDriver struct {
+	mu      sync.Mutex
+	entries []recordedStatement
+}
+
+type recordedStatement struct {
+	pool  string
+	query string
+	tx    bool // BEGIN/COMMIT/ROLLBACK
+}
+
+var routingDriver = &recordingDriver{}
+
+func init() {
+	sql.Register("labbend-routing-test", routingDriver)
+}
+
+func (d *recordingDriver) Open(name string) (driver.Conn, error) {
+	return &recordingConn{driver: d, pool: name}, nil
+}
+
+func (d *recordingDriver) record(entry recordedStatement) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries = append(d.entries, entry)
+}
+
+func (d *recordingDriver) drain() []recordedStatement {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entries := d.entries
+	d.entries = nil
+	return entries
+}
+
+// take - pools que executaram queries (sem BEGIN/COMMIT/ROLLBACK)
+func (d *recordingDriver) take() []string {
+	var pools []string
+	for _, entry := range d.drain() {
+		if !entry.tx {
+			pools = append(pools, entry.pool)
+		}
+	}
+	return pools
+}
+
+// statements - SQL executado, inclusive BEGIN/COMMIT/ROLLBACK
+func (d *recordingDriver) statements() []string {
+	var queries []string
+	for _, entry := range d.drain() {
+		queries = append(queries, entry.query)
+	}
+	return queries
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+	pool   string
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *recordingConn) Close() error { return nil }
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *recordingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.driver.record(recordedStatement{pool: c.pool, query: "BEGIN", tx: true})
+	return c, nil
+}
+
+func (c *recordingConn) Commit() error {
+	c.driver.record(recordedStatement{pool: c.pool, query: "COMMIT", tx: true})
+	return nil
+}
+
+func (c *recordingConn) Rollback() error {
+	c.driver.record(recordedStatement{pool: c.pool, query: "ROLLBACK", tx: true})
+	return nil
+}
+
+func (c *recordingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.driver.record(recordedStatement{pool: c.pool, query: query})
+	return emptyRows{}, nil
+}
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.driver.record(recordedStatement{pool: c.pool, query: query})
+	return driver.RowsAffected(1), nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string              { return []string{"id"} }
+func (emptyRows) Close() error                   { return nil }
+func (emptyRows) Next(dest []driver.Value) error { return io.EOF }
@@ -0,0 +1,305 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MigrationLockID - chave do advisory lock do PostgreSQL que serializa as migrações
+// entre instâncias
+const MigrationLockID int64 = 7_340_512_019
+
+// MigrationVersionFormat - formato da versão gerada por "migrate create" (timestamp UTC)
+const MigrationVersionFormat = "20060102150405"
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration - migração SQL versionada; Version ordena a aplicação entre todos os módulos
+type Migration struct {
+	Version int64
+	Name    string
+	Source  string // módulo (ou pacote) dono da migração
+	Up      string
+	Down    string
+}
+
+// MigrationStatus - migração e seu estado no banco
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt *time.Time
+	// Missing - aplicada no banco mas sem arquivo correspondente
+	Missing bool
+}
+
+// schemaMigration - registro de uma migração aplicada (tabela schema_migrations)
+type schemaMigration struct {
+	Version   int64  `gorm:"primaryKey;autoIncrement:false"`
+	Source    string `gorm:"not null"`
+	Name      string `gorm:"not null"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// LoadMigrations - lê os arquivos "<versão>_<nome>.up.sql" e "<versão>_<nome>.down.sql"
+// da raiz de fsys ou do diretório migrations/ (embed de "migrations/*.sql");
+// o .down.sql é opcional
+func LoadMigrations(source string, fsys fs.FS) ([]Migration, error) {
+	if fsys == nil {
+		return nil, nil
+	}
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		// embed.FS com "migrations/*.sql": os arquivos ficam no subdiretório
+		if sub, subErr := fs.Sub(fsys, "migrations"); subErr == nil {
+			if files, err = fs.Glob(sub, "*.sql"); err == nil && len(files) > 0 {
+				fsys = sub
+			}
+		}
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, file := range files {
+		match := migrationFilePattern.FindStringSubmatch(path.Base(file))
+		if match == nil {
+			return nil, fmt.Errorf("%s: invalid migration file name %q (expected <version>_<name>.up.sql)", source, file)
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid migration version in %q: %w", source, file, err)
+		}
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2], Source: source}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("%s: migration version %d used by %q and %q", source, version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("%s: migration %d_%s has no .up.sql", source, migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sortMigrations(migrations)
+	return migrations, nil
+}
+
+func sortMigrations(migrations []Migration) {
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+}
+
+// Migrator - aplica e desfaz migrações registrando-as em schema_migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator - cria o migrator; versões repetidas entre módulos são erro
+func NewMigrator(db *gorm.DB, migrations []Migration) (*Migrator, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sortMigrations(sorted)
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Version == sorted[i-1].Version {
+			return nil, fmt.Errorf("migration version %d used by %s/%s and %s/%s",
+				sorted[i].Version, sorted[i-1].Source, sorted[i-1].Name, sorted[i].Source, sorted[i].Name)
+		}
+	}
+	return &Migrator{db: db, migrations: sorted}, nil
+}
+
+// Up - aplica as migrações pendentes em ordem de versão (inclusive versões
+// antigas ainda não aplicadas, ex.: vindas de outra branch); cada migração
+// roda na própria transação
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		done, err := m.applied(db)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(db, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down - desfaz as últimas n migrações aplicadas, da mais recente para a mais antiga
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("down requires a positive number of migrations, got %d", n)
+	}
+
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	var reverted []Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		var records []schemaMigration
+		if err := db.Order("version DESC").Limit(n).Find(&records).Error; err != nil {
+			return fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		for _, record := range records {
+			migration, ok := known[record.Version]
+			if !ok {
+				return fmt.Errorf("migration %d_%s (%s) is applied but its files are missing", record.Version, record.Name, record.Source)
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s (%s) has no .down.sql", migration.Version, migration.Name, migration.Source)
+			}
+			if err := m.revert(db, migration); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status - todas as migrações conhecidas e as aplicadas sem arquivo, em ordem de versão
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	db := m.db.WithContext(ctx)
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	done, err := m.applied(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if record, ok := done[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			delete(done, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range done {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, MigrationStatus{
+			Migration: Migration{Version: record.Version, Name: record.Name, Source: record.Source},
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Missing:   true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+func (m *Migrator) applied(db *gorm.DB) (map[int64]schemaMigration, error) {
+	var records []schemaMigration
+	if err := db.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	done := make(map[int64]schemaMigration, len(records))
+	for _, record := range records {
+		done[record.Version] = record
+	}
+	return done, nil
+}
+
+func (m *Migrator) apply(db *gorm.DB, migration Migration) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err
+		}
+		return tx.Create(&schemaMigration{
+			Version:   migration.Version,
+			Source:    migration.Source,
+			Name:      migration.Name,
+			AppliedAt: time.Now().UTC(),
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration %d_%s (%s) failed: %w", migration.Version, migration.Name, migration.Source, err)
+	}
+	return nil
+}
+
+func (m *Migrator) revert(db *gorm.DB, migration Migration) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&schemaMigration{}, "version = ?", migration.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("rollback of %d_%s (%s) failed: %w", migration.Version, migration.Name, migration.Source, err)
+	}
+	return nil
+}
+
+// withLock - executa fn com o advisory lock de migração; no PostgreSQL a
+// sessão do lock é a mesma que aplica as migrações, então outras instâncias
+// esperam e, ao entrar, encontram as migrações já aplicadas
+func (m *Migrator) withLock(ctx context.Context, fn func(db *gorm.DB) error) error {
+	if m.db.Dialector.Name() != "postgres" {
+		db := m.db.WithContext(ctx)
+		if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %w", err)
+		}
+		return fn(db)
+	}
+
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", MigrationLockID).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		// Sem o contexto da chamada: um cancelamento não pode deixar o lock preso na conexão do pool
+		defer conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", MigrationLockID)
+
+		if err := conn.AutoMigrate(&schemaMigration{}); err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %w", err)
+		}
+		return fn(conn)
+	})
+}
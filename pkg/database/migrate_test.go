@@ -0,0 +1,60 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/20250102000000_add_bio.up.sql":        {Data: []byte("ALTER TABLE users ADD COLUMN bio text;")},
+		"migrations/20250102000000_add_bio.down.sql":      {Data: []byte("ALTER TABLE users DROP COLUMN bio;")},
+		"migrations/20250101000000_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id bigserial);")},
+		"migrations/20250101000000_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"migrations/20250103000000_backfill.up.sql":       {Data: []byte("UPDATE users SET bio = '';")},
+	}
+
+	migrations, err := LoadMigrations("users", fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+
+	assert.Equal(t, Migration{
+		Version: 20250101000000,
+		Name:    "create_users",
+		Source:  "users",
+		Up:      "CREATE TABLE users (id bigserial);",
+		Down:    "DROP TABLE users;",
+	}, migrations[0])
+	assert.Equal(t, "add_bio", migrations[1].Name)
+	assert.Equal(t, "backfill", migrations[2].Name)
+	assert.Empty(t, migrations[2].Down, "down é opcional")
+}
+
+func TestLoadMigrations_Errors(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"nome inválido": {"create-users.up.sql": {Data: []byte("SELECT 1;")}},
+		"sem up":        {"20250101000000_create_users.down.sql": {Data: []byte("DROP TABLE users;")}},
+		"versão repetida": {
+			"20250101000000_a.up.sql": {Data: []byte("SELECT 1;")},
+			"20250101000000_b.up.sql": {Data: []byte("SELECT 2;")},
+		},
+	}
+
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadMigrations("users", fsys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewMigrator_DuplicateVersionAcrossSources(t *testing.T) {
+	_, err := NewMigrator(nil, []Migration{
+		{Version: 1, Name: "create_users", Source: "users", Up: "SELECT 1;"},
+		{Version: 1, Name: "create_challenges", Source: "challenges", Up: "SELECT 1;"},
+	})
+	assert.EqualError(t, err, "migration version 1 used by users/create_users and challenges/create_challenges")
+}
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Políticas de escolha da réplica (DB_REPLICA_POLICY)
+const (
+	PolicyRoundRobin = "round_robin"
+	PolicyRandom     = "random"
+)
+
+const replicasPluginName = "labbend:replicas"
+
+// ReplicaPolicy - escolhe a réplica de cada leitura
+type ReplicaPolicy interface {
+	Resolve(replicas []gorm.ConnPool) gorm.ConnPool
+}
+
+// RoundRobin - alterna entre as réplicas em ordem
+func RoundRobin() ReplicaPolicy {
+	return &roundRobin{}
+}
+
+type roundRobin struct {
+	next atomic.Uint64
+}
+
+func (p *roundRobin) Resolve(replicas []gorm.ConnPool) gorm.ConnPool {
+	return replicas[(p.next.Add(1)-1)%uint64(len(replicas))]
+}
+
+// Random - escolhe uma réplica aleatória
+func Random() ReplicaPolicy {
+	return randomPolicy{}
+}
+
+type randomPolicy struct{}
+
+func (randomPolicy) Resolve(replicas []gorm.ConnPool) gorm.ConnPool {
+	return replicas[rand.Intn(len(replicas))]
+}
+
+// ParseReplicaPolicy - política pelo nome ("round_robin" ou "random"; vazio = round_robin)
+func ParseReplicaPolicy(name string) (ReplicaPolicy, error) {
+	switch name {
+	case "", PolicyRoundRobin:
+		return RoundRobin(), nil
+	case PolicyRandom:
+		return Random(), nil
+	}
+	return nil, fmt.Errorf("invalid replica policy %q", name)
+}
+
+type replicaReadKey struct{}
+
+type writeTrackerKey struct{}
+
+// writeTracker - marca que o request já escreveu no primário
+type writeTracker struct {
+	written atomic.Bool
+}
+
+// ReadReplica - scope que envia a query (e seus Preloads) para uma réplica.
+// Dentro de transação, sem réplicas configuradas ou depois de uma escrita no
+// mesmo request (WithReadYourWrites) a leitura continua no primário:
+//
+//	r.db.WithContext(ctx).Scopes(database.ReadReplica).Find(&users)
+func ReadReplica(db *gorm.DB) *gorm.DB {
+	return db.WithContext(context.WithValue(db.Statement.Context, replicaReadKey{}, true))
+}
+
+// WithReadYourWrites - ativa a aderência ao primário no contexto do request:
+// depois da primeira escrita, as leituras com ReadReplica vão para o primário
+// até o fim do request
+func WithReadYourWrites(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(writeTrackerKey{}).(*writeTracker); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, writeTrackerKey{}, &writeTracker{})
+}
+
+// HasWritten - indica se o request já escreveu no primário (WithReadYourWrites)
+func HasWritten(ctx context.Context) bool {
+	tracker, ok := ctx.Value(writeTrackerKey{}).(*writeTracker)
+	return ok && tracker.written.Load()
+}
+
+// replicas - plugin do GORM que troca o pool das leituras marcadas com ReadReplica
+type replicas struct {
+	pools  []gorm.ConnPool
+	dbs    []*gorm.DB
+	policy ReplicaPolicy
+}
+
+func (r *replicas) Name() string {
+	return replicasPluginName
+}
+
+func (r *replicas) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Query().Before("gorm:query").Register("labbend:replica_query", r.route); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("labbend:replica_row", r.route); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register("labbend:track_create", trackWrite); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("labbend:track_update", trackWrite); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register("labbend:track_delete", trackWrite); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("labbend:track_raw", trackWrite)
+}
+
+func (r *replicas) route(db *gorm.DB) {
+	if len(r.pools) == 0 || db.Error != nil {
+		return
+	}
+	// Transações e conexões dedicadas (Begin, Connection) ficam no primário
+	if db.Statement.ConnPool != db.ConnPool {
+		return
+	}
+	ctx := db.Statement.Context
+	if read, _ := ctx.Value(replicaReadKey{}).(bool); !read || HasWritten(ctx) {
+		return
+	}
+	db.Statement.ConnPool = r.policy.Resolve(r.pools)
+}
+
+func trackWrite(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	if tracker, ok := db.Statement.Context.Value(writeTrackerKey{}).(*writeTracker); ok {
+		tracker.written.Store(true)
+	}
+}
+
+// useReplicas - abre as réplicas com a configuração do pool do primário e
+// registra o roteamento de leituras
+func useReplicas(db *gorm.DB, config Config) error {
+	policy := config.ReplicaPolicy
+	if policy == nil {
+		policy = RoundRobin()
+	}
+	plugin := &replicas{policy: policy}
+
+	for i, dsn := range config.ReplicaDSNs {
+		dialect, err := dialector(config.Driver, dsn)
+		if err != nil {
+			closeReplicas(plugin)
+			return fmt.Errorf("invalid replica %d: %w", i, err)
+		}
+		replica, err := gorm.Open(dialect, &gorm.Config{
+			Logger: logger.Default.LogMode(config.LogLevel),
+		})
+		if err != nil {
+			closeReplicas(plugin)
+			return fmt.Errorf("failed to connect to replica %d: %w", i, err)
+		}
+		sqlDB, err := replica.DB()
+		if err != nil {
+			closeReplicas(plugin)
+			return fmt.Errorf("failed to get replica %d sql.DB: %w", i, err)
+		}
+		sqlDB.SetMaxIdleConns(config.MaxIdleConns)
+		sqlDB.SetMaxOpenConns(config.MaxOpenConns)
+		sqlDB.SetConnMaxLifetime(config.MaxLifetime)
+
+		plugin.pools = append(plugin.pools, sqlDB)
+		plugin.dbs = append(plugin.dbs, replica)
+	}
+
+	if err := db.Use(plugin); err != nil {
+		closeReplicas(plugin)
+		return fmt.Errorf("failed to register replica routing: %w", err)
+	}
+	return nil
+}
+
+// Replicas - conexões das réplicas configuradas (ex.: health checks)
+func Replicas(db *gorm.DB) []*gorm.DB {
+	if plugin, ok := db.Config.Plugins[replicasPluginName].(*replicas); ok {
+		return plugin.dbs
+	}
+	return nil
+}
+
+// Close - fecha o pool do primário e os das réplicas
+func Close(db *gorm.DB) error {
+	if plugin, ok := db.Config.Plugins[replicasPluginName].(*replicas); ok {
+		closeReplicas(plugin)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func closeReplicas(plugin *replicas) {
+	for _, replica := range plugin.dbs {
+		if sqlDB, err := replica.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
+}
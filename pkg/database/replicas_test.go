@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type routedUser struct {
+	ID   uint
+	Name string
+}
+
+func openRoutingDB(t *testing.T, replicaNames ...string) *gorm.DB {
+	t.Helper()
+	routingDriver.take()
+
+	open := func(name string) *sql.DB {
+		sqlDB, err := sql.Open("labbend-routing-test", name)
+		require.NoError(t, err)
+		t.Cleanup(func() { sqlDB.Close() })
+		return sqlDB
+	}
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: open("primary")}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	plugin := &replicas{policy: RoundRobin()}
+	for _, name := range replicaNames {
+		plugin.pools = append(plugin.pools, open(name))
+	}
+	require.NoError(t, db.Use(plugin))
+	return db
+}
+
+func TestReadReplica_Routing(t *testing.T) {
+	db := openRoutingDB(t, "replica-1")
+	ctx := context.Background()
+	var users []routedUser
+
+	db.WithContext(ctx).Scopes(ReadReplica).Find(&users)
+	db.WithContext(ctx).Find(&users)
+	db.WithContext(ctx).Scopes(ReadReplica).Table("routed_users").Select("count(*)").Row()
+
+	assert.Equal(t, []string{"replica-1", "primary", "replica-1"}, routingDriver.take())
+}
+
+func TestReadReplica_TransactionUsesPrimary(t *testing.T) {
+	db := openRoutingDB(t, "replica-1")
+	var users []routedUser
+
+	err := NewTxManager(db).WithTransaction(context.Background(), func(tx *gorm.DB) error {
+		return tx.Scopes(ReadReplica).Find(&users).Error
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"primary"}, routingDriver.take())
+}
+
+func TestReadReplica_ReadYourWrites(t *testing.T) {
+	db := openRoutingDB(t, "replica-1")
+	ctx := WithReadYourWrites(context.Background())
+	var users []routedUser
+
+	db.WithContext(ctx).Scopes(ReadReplica).Find(&users)
+	assert.False(t, HasWritten(ctx))
+
+	db.WithContext(ctx).Create(&routedUser{Name: "ana"})
+	assert.True(t, HasWritten(ctx))
+
+	db.WithContext(ctx).Scopes(ReadReplica).Find(&users)
+	// Outro request (outro contexto) continua lendo da réplica
+	db.WithContext(context.Background()).Scopes(ReadReplica).Find(&users)
+
+	assert.Equal(t, []string{"replica-1", "primary", "primary", "replica-1"}, routingDriver.take())
+}
+
+func TestReadReplica_RoundRobin(t *testing.T) {
+	db := openRoutingDB(t, "replica-1", "replica-2")
+	var users []routedUser
+
+	for i := 0; i < 4; i++ {
+		db.Scopes(ReadReplica).Find(&users)
+	}
+
+	assert.Equal(t, []string{"replica-1", "replica-2", "replica-1", "replica-2"}, routingDriver.take())
+}
+
+func TestReadReplica_WithoutReplicasUsesPrimary(t *testing.T) {
+	db := openRoutingDB(t)
+	var users []routedUser
+
+	db.Scopes(ReadReplica).Find(&users)
+
+	assert.Equal(t, []string{"primary"}, routingDriver.take())
+}
+
+func TestParseReplicaPolicy(t *testing.T) {
+	for _, name := range []string{"", PolicyRoundRobin, PolicyRandom} {
+		policy, err := ParseReplicaPolicy(name)
+		require.NoError(t, err, name)
+		assert.NotNil(t, policy)
+	}
+
+	_, err := ParseReplicaPolicy("nearest")
+	assert.Error(t, err)
+}
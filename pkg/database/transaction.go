@@ -2,56 +2,259 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 )
 
-// TxManager - gerenciador de transações
+// Padrões do TxManager
+const (
+	DefaultTxMaxRetries   = 3
+	DefaultTxRetryBackoff = 20 * time.Millisecond
+)
+
+// SQLSTATEs do PostgreSQL que indicam que a transação pode ser repetida
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// TxManager - gerenciador de transações; a transação ativa fica no contexto,
+// então chamadas aninhadas viram savepoints e os repositórios que usam
+// FromContext participam sem receber o *gorm.DB
 type TxManager struct {
-	db *gorm.DB
+	db           *gorm.DB
+	maxRetries   int
+	retryBackoff time.Duration
 }
 
 // NewTxManager - cria novo gerenciador de transações
 func NewTxManager(db *gorm.DB) *TxManager {
-	return &TxManager{db: db}
+	return &TxManager{
+		db:           db,
+		maxRetries:   DefaultTxMaxRetries,
+		retryBackoff: DefaultTxRetryBackoff,
+	}
+}
+
+// TxOption - configuração de uma transação
+type TxOption func(*txOptions)
+
+type txOptions struct {
+	sql        sql.TxOptions
+	maxRetries int
+}
+
+// WithIsolation - nível de isolamento da transação (ex.: sql.LevelSerializable)
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(o *txOptions) {
+		o.sql.Isolation = level
+	}
 }
 
-// WithTransaction - executa função dentro de uma transação
-func (tm *TxManager) WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
-	tx := tm.db.WithContext(ctx).Begin()
+// ReadOnly - transação somente leitura
+func ReadOnly() TxOption {
+	return func(o *txOptions) {
+		o.sql.ReadOnly = true
+	}
+}
+
+// WithMaxRetries - tentativas extras em falha de serialização ou deadlock (0 desativa)
+func WithMaxRetries(n int) TxOption {
+	return func(o *txOptions) {
+		o.maxRetries = n
+	}
+}
+
+type txKey struct{}
+
+// txState - transação ativa no contexto, profundidade dos savepoints e os
+// hooks registrados neste nível
+type txState struct {
+	tx         *gorm.DB
+	depth      int
+	onCommit   []func(ctx context.Context)
+	onRollback []func(ctx context.Context)
+}
+
+// OnCommit - executa fn depois do commit da transação ativa em ctx (descartado
+// em rollback); fora de transação fn roda na hora. Use para eventos,
+// invalidação de cache e notificações que só valem para dados gravados
+func (tm *TxManager) OnCommit(ctx context.Context, fn func(ctx context.Context)) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		fn(ctx)
+		return
+	}
+	state.onCommit = append(state.onCommit, fn)
+}
+
+// OnRollback - executa fn se a transação ativa em ctx (ou o savepoint em que
+// foi registrado) for desfeita; fora de transação não faz nada
+func (tm *TxManager) OnRollback(ctx context.Context, fn func(ctx context.Context)) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		state.onRollback = append(state.onRollback, fn)
+	}
+}
+
+// runHooks - os hooks recebem o contexto de fora da transação
+func runHooks(ctx context.Context, hooks []func(ctx context.Context)) {
+	for _, hook := range hooks {
+		hook(ctx)
+	}
+}
+
+// TxFromContext - transação ativa no contexto, se houver
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return nil, false
+	}
+	return state.tx, true
+}
+
+// FromContext - a transação ativa no contexto ou db fora de transação, já com ctx:
+//
+//	func (r *repository) Create(ctx context.Context, user *User) error {
+//		return database.FromContext(ctx, r.db).Create(user).Error
+//	}
+func FromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// InTransaction - executa fn em uma transação guardada no contexto recebido por
+// fn. Se ctx já tem uma transação, fn roda em um savepoint dela: o erro de fn
+// desfaz só o savepoint e é devolvido para a transação externa decidir. A
+// transação externa é repetida (com backoff) em falha de serialização ou
+// deadlock; isolamento e read-only valem apenas para ela
+func (tm *TxManager) InTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return tm.savepoint(ctx, state, fn)
+	}
+
+	options := txOptions{maxRetries: tm.maxRetries}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := tm.run(ctx, options, fn)
+		if err == nil || attempt >= options.maxRetries || !IsRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(tm.retryBackoff << attempt):
+		}
+	}
+}
+
+// WithTransaction - executa função dentro de uma transação (aninhada em
+// savepoint quando ctx já tem uma)
+func (tm *TxManager) WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error, opts ...TxOption) error {
+	return tm.InTransaction(ctx, func(ctx context.Context) error {
+		tx, _ := TxFromContext(ctx)
+		return fn(tx.WithContext(ctx))
+	}, opts...)
+}
+
+// WithTransactionResult - executa função dentro de uma transação e retorna resultado
+func (tm *TxManager) WithTransactionResult(ctx context.Context, fn func(tx *gorm.DB) (interface{}, error), opts ...TxOption) (interface{}, error) {
+	var result interface{}
+	err := tm.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		result, err = fn(tx)
+		return err
+	}, opts...)
+	return result, err
+}
+
+func (tm *TxManager) run(ctx context.Context, options txOptions, fn func(ctx context.Context) error) error {
+	tx := tm.db.WithContext(ctx).Begin(&options.sql)
 	if tx.Error != nil {
 		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
 	}
 
+	state := &txState{tx: tx}
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
+			runHooks(ctx, state.onRollback)
 			panic(r)
 		}
 	}()
 
-	if err := fn(tx); err != nil {
-		if rbErr := tx.Rollback().Error; rbErr != nil {
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		rbErr := tx.Rollback().Error
+		runHooks(ctx, state.onRollback)
+		if rbErr != nil {
 			return fmt.Errorf("transaction failed: %w, rollback failed: %v", err, rbErr)
 		}
 		return err
 	}
 
 	if err := tx.Commit().Error; err != nil {
+		runHooks(ctx, state.onRollback)
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	runHooks(ctx, state.onCommit)
 	return nil
 }
 
-// WithTransactionResult - executa função dentro de uma transação e retorna resultado
-func (tm *TxManager) WithTransactionResult(ctx context.Context, fn func(tx *gorm.DB) (interface{}, error)) (interface{}, error) {
-	var result interface{}
-	err := tm.WithTransaction(ctx, func(tx *gorm.DB) error {
-		var err error
-		result, err = fn(tx)
+func (tm *TxManager) savepoint(ctx context.Context, parent *txState, fn func(ctx context.Context) error) error {
+	state := &txState{tx: parent.tx, depth: parent.depth + 1}
+	name := fmt.Sprintf("sp_%d", state.depth)
+
+	if err := state.tx.WithContext(ctx).SavePoint(name).Error; err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			state.tx.WithContext(ctx).RollbackTo(name)
+			runHooks(withoutTx(ctx), state.onRollback)
+			panic(r)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		rbErr := state.tx.WithContext(ctx).RollbackTo(name).Error
+		runHooks(withoutTx(ctx), state.onRollback)
+		if rbErr != nil {
+			return fmt.Errorf("transaction failed: %w, rollback to savepoint failed: %v", err, rbErr)
+		}
 		return err
-	})
-	return result, err
+	}
+
+	// Os hooks do savepoint passam a depender do resultado da transação externa
+	parent.onCommit = append(parent.onCommit, state.onCommit...)
+	parent.onRollback = append(parent.onRollback, state.onRollback...)
+	return nil
+}
+
+// withoutTx - contexto sem a transação ativa, para os hooks de um savepoint desfeito
+func withoutTx(ctx context.Context) context.Context {
+	return context.WithValue(ctx, txKey{}, nil)
+}
+
+// IsRetryable - falha de serialização (40001) ou deadlock (40P01) do PostgreSQL
+func IsRetryable(err error) bool {
+	var pgErr interface{ SQLState() string }
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	switch pgErr.SQLState() {
+	case sqlStateSerializationFailure, sqlStateDeadlockDetected:
+		return true
+	}
+	return false
 }
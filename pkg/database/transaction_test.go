@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// sqlStateError - erro com SQLSTATE, como o *pgconn.PgError
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "sqlstate " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+func TestTxManager_ContextPropagation(t *testing.T) {
+	db := openRoutingDB(t)
+	tm := NewTxManager(db)
+
+	err := tm.InTransaction(context.Background(), func(ctx context.Context) error {
+		tx, ok := TxFromContext(ctx)
+		require.True(t, ok)
+		assert.Same(t, tx.Statement.ConnPool, FromContext(ctx, db).Statement.ConnPool)
+		return FromContext(ctx, db).Create(&routedUser{Name: "ana"}).Error
+	})
+	require.NoError(t, err)
+
+	_, ok := TxFromContext(context.Background())
+	assert.False(t, ok)
+	assert.Equal(t, []string{"BEGIN", `INSERT INTO "routed_users" ("name") VALUES ($1) RETURNING "id"`, "COMMIT"},
+		routingDriver.statements())
+}
+
+func TestTxManager_NestedUsesSavepoints(t *testing.T) {
+	db := openRoutingDB(t)
+	tm := NewTxManager(db)
+	errInner := errors.New("inner failed")
+
+	err := tm.InTransaction(context.Background(), func(ctx context.Context) error {
+		require.NoError(t, tm.InTransaction(ctx, func(ctx context.Context) error {
+			return nil
+		}))
+		// Erro no savepoint desfaz só o savepoint; a externa decide seguir
+		assert.ErrorIs(t, tm.WithTransaction(ctx, func(tx *gorm.DB) error {
+			return errInner
+		}), errInner)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"BEGIN",
+		"SAVEPOINT sp_1",
+		"SAVEPOINT sp_1",
+		"ROLLBACK TO SAVEPOINT sp_1",
+		"COMMIT",
+	}, routingDriver.statements())
+}
+
+func TestTxManager_RetriesSerializationFailures(t *testing.T) {
+	db := openRoutingDB(t)
+	tm := NewTxManager(db)
+	tm.retryBackoff = 0
+
+	attempts := 0
+	err := tm.InTransaction(context.Background(), func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return fmt.Errorf("update failed: %w", sqlStateError(sqlStateSerializationFailure))
+		}
+		return nil
+	}, WithIsolation(sql.LevelSerializable))
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = tm.InTransaction(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return sqlStateError(sqlStateDeadlockDetected)
+	}, WithMaxRetries(1))
+	assert.True(t, IsRetryable(err))
+	assert.Equal(t, 2, attempts)
+
+	attempts = 0
+	err = tm.InTransaction(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return sqlStateError("23505") // unique_violation não é repetida
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestTxManager_Hooks(t *testing.T) {
+	db := openRoutingDB(t)
+	tm := NewTxManager(db)
+	var calls []string
+	hook := func(name string) func(context.Context) {
+		return func(ctx context.Context) {
+			_, inTx := TxFromContext(ctx)
+			assert.False(t, inTx, name)
+			calls = append(calls, name)
+		}
+	}
+
+	// Fora de transação OnCommit roda na hora e OnRollback é ignorado
+	tm.OnCommit(context.Background(), hook("immediate"))
+	tm.OnRollback(context.Background(), hook("never"))
+	assert.Equal(t, []string{"immediate"}, calls)
+
+	calls = nil
+	err := tm.InTransaction(context.Background(), func(ctx context.Context) error {
+		tm.OnCommit(ctx, hook("outer commit"))
+		_ = tm.InTransaction(ctx, func(ctx context.Context) error {
+			tm.OnCommit(ctx, hook("failed savepoint commit"))
+			tm.OnRollback(ctx, hook("failed savepoint rollback"))
+			return errors.New("savepoint failed")
+		})
+		require.NoError(t, tm.InTransaction(ctx, func(ctx context.Context) error {
+			tm.OnCommit(ctx, hook("savepoint commit"))
+			return nil
+		}))
+		assert.Equal(t, []string{"failed savepoint rollback"}, calls, "commit hooks esperam o fim da transação")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"failed savepoint rollback", "outer commit", "savepoint commit"}, calls)
+
+	calls = nil
+	err = tm.InTransaction(context.Background(), func(ctx context.Context) error {
+		tm.OnCommit(ctx, hook("commit"))
+		tm.OnRollback(ctx, hook("rollback"))
+		return errors.New("failed")
+	})
+	require.Error(t, err)
+	assert.Equal(t, []string{"rollback"}, calls)
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// JSON - documento JSON bruto; jsonb no PostgreSQL e text no SQLite
+type JSON json.RawMessage
+
+// GormDataType - tipo genérico usado pelo GORM
+func (JSON) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType - tipo da coluna conforme o driver
+func (JSON) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return jsonColumnType(db)
+}
+
+// Value - grava o documento (nil vira NULL)
+func (j JSON) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+	return string(j), nil
+}
+
+// Scan - lê o documento como []byte ou string
+func (j *JSON) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+	case []byte:
+		*j = append((*j)[:0], v...)
+	case string:
+		*j = JSON(v)
+	default:
+		return fmt.Errorf("cannot scan %T into database.JSON", value)
+	}
+	return nil
+}
+
+// MarshalJSON - o documento sem reencodar
+func (j JSON) MarshalJSON() ([]byte, error) {
+	return json.RawMessage(j).MarshalJSON()
+}
+
+// UnmarshalJSON - guarda uma cópia do documento
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	*j = append((*j)[:0], data...)
+	return nil
+}
+
+// StringList - lista de strings gravada como array JSON (jsonb no PostgreSQL,
+// text no SQLite)
+type StringList []string
+
+// GormDataType - tipo genérico usado pelo GORM
+func (StringList) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType - tipo da coluna conforme o driver
+func (StringList) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return jsonColumnType(db)
+}
+
+// Value - grava a lista como array JSON (nil vira NULL)
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan - lê um array JSON
+func (l *StringList) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into database.StringList", value)
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+func jsonColumnType(db *gorm.DB) string {
+	if IsSQLite(db) {
+		return "text"
+	}
+	return "jsonb"
+}
+
+// TSVector - coluna de busca full-text do PostgreSQL, declarada pela tag type
+// (ex.: "tsvector GENERATED ALWAYS AS (...) STORED"); no SQLite vira uma
+// coluna text vazia e a busca full-text não está disponível
+type TSVector string
+
+// GormDBDataType - a tag type no PostgreSQL, text no SQLite
+func (TSVector) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if IsSQLite(db) {
+		return "text"
+	}
+	return string(field.DataType)
+}
@@ -0,0 +1,200 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc - busca vários valores de uma vez; chaves ausentes no map resultam no valor zero
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Config - limites do agrupamento
+type Config struct {
+	// MaxBatch - máximo de chaves por chamada ao BatchFunc (0 = sem limite)
+	MaxBatch int
+	// Wait - janela de espera para agrupar chaves antes de disparar o lote
+	Wait time.Duration
+}
+
+// DefaultConfig - lotes de até 100 chaves com janela de 2ms
+func DefaultConfig() Config {
+	return Config{
+		MaxBatch: 100,
+		Wait:     2 * time.Millisecond,
+	}
+}
+
+// Loader - agrupa Loads por chave em lotes e mantém cache durante a vida do loader.
+// Deve ser criado por request para que o cache não vaze entre usuários.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	maxBatch int
+	wait     time.Duration
+
+	mu    sync.Mutex
+	cache map[K]*result[V]
+	batch *batch[K, V]
+}
+
+// result - resultado (futuro) de uma chave
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// batch - lote em formação
+type batch[K comparable, V any] struct {
+	ctx     context.Context
+	keys    []K
+	results map[K]*result[V]
+	timer   *time.Timer
+	once    sync.Once
+}
+
+// New - cria um loader com o BatchFunc e a configuração informados
+func New[K comparable, V any](fetch BatchFunc[K, V], config Config) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		maxBatch: config.MaxBatch,
+		wait:     config.Wait,
+		cache:    make(map[K]*result[V]),
+	}
+}
+
+// Load - carrega uma chave, aguardando o lote em que ela foi incluída
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	return l.LoadThunk(ctx, key)()
+}
+
+// LoadThunk - registra a chave no lote atual e retorna uma função que aguarda o valor.
+// Resolvers GraphQL podem retornar o thunk para que todos os irmãos entrem no mesmo lote.
+func (l *Loader[K, V]) LoadThunk(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	if res, ok := l.cache[key]; ok {
+		l.mu.Unlock()
+		return func() (V, error) {
+			<-res.done
+			return res.value, res.err
+		}
+	}
+
+	res := &result[V]{done: make(chan struct{})}
+	l.cache[key] = res
+
+	if l.batch == nil {
+		l.batch = l.newBatch(ctx)
+	}
+	current := l.batch
+	current.keys = append(current.keys, key)
+	current.results[key] = res
+
+	if l.maxBatch > 0 && len(current.keys) >= l.maxBatch {
+		l.batch = nil
+		l.mu.Unlock()
+		go l.dispatch(current)
+	} else {
+		l.mu.Unlock()
+	}
+
+	return func() (V, error) {
+		select {
+		case <-res.done:
+		default:
+			// Quem precisa do valor não espera a janela: dispara o lote agora
+			l.dispatchPending(current)
+			<-res.done
+		}
+		return res.value, res.err
+	}
+}
+
+// LoadMany - carrega várias chaves mantendo a ordem; retorna o primeiro erro encontrado
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, error) {
+	thunks := make([]func() (V, error), len(keys))
+	for i, key := range keys {
+		thunks[i] = l.LoadThunk(ctx, key)
+	}
+
+	values := make([]V, len(keys))
+	for i, thunk := range thunks {
+		value, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// Prime - coloca um valor já conhecido no cache (não sobrescreve)
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.cache[key]; ok {
+		return
+	}
+	res := &result[V]{done: make(chan struct{}), value: value}
+	close(res.done)
+	l.cache[key] = res
+}
+
+// Clear - remove uma chave do cache (ex.: após uma mutation)
+func (l *Loader[K, V]) Clear(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.cache, key)
+}
+
+func (l *Loader[K, V]) newBatch(ctx context.Context) *batch[K, V] {
+	b := &batch[K, V]{
+		ctx:     ctx,
+		results: make(map[K]*result[V]),
+	}
+	b.timer = time.AfterFunc(l.wait, func() {
+		l.dispatchPending(b)
+	})
+	return b
+}
+
+// dispatchPending - fecha o lote (se ainda estiver aberto) e o executa
+func (l *Loader[K, V]) dispatchPending(b *batch[K, V]) {
+	l.mu.Lock()
+	if l.batch == b {
+		l.batch = nil
+	}
+	l.mu.Unlock()
+
+	l.dispatch(b)
+}
+
+// dispatch - executa o BatchFunc uma única vez por lote e resolve todos os resultados
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	b.once.Do(func() {
+		b.timer.Stop()
+
+		values, err := l.fetch(b.ctx, b.keys)
+		for _, key := range b.keys {
+			res := b.results[key]
+			if err != nil {
+				res.err = err
+			} else {
+				res.value = values[key]
+			}
+			close(res.done)
+		}
+
+		// Erros não ficam em cache para que uma nova tentativa possa buscar de novo
+		if err != nil {
+			l.mu.Lock()
+			for _, key := range b.keys {
+				if l.cache[key] == b.results[key] {
+					delete(l.cache, key)
+				}
+			}
+			l.mu.Unlock()
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package dataloader_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/dataloader"
+)
+
+// recorder - BatchFunc que registra os lotes recebidos
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]int
+	err     error
+}
+
+func (r *recorder) fetch(_ context.Context, keys []int) (map[int]string, error) {
+	r.mu.Lock()
+	r.batches = append(r.batches, append([]int(nil), keys...))
+	err := r.err
+	r.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[int]string, len(keys))
+	for _, key := range keys {
+		if key >= 0 {
+			values[key] = string(rune('a' + key))
+		}
+	}
+	return values, nil
+}
+
+func (r *recorder) calls() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.batches)
+}
+
+func TestLoader_BatchesThunks(t *testing.T) {
+	rec := &recorder{}
+	loader := dataloader.New(rec.fetch, dataloader.Config{Wait: time.Hour})
+	ctx := context.Background()
+
+	thunks := make([]func() (string, error), 5)
+	for i := range thunks {
+		thunks[i] = loader.LoadThunk(ctx, i)
+	}
+	for i, thunk := range thunks {
+		value, err := thunk()
+		require.NoError(t, err)
+		assert.Equal(t, string(rune('a'+i)), value)
+	}
+
+	assert.Equal(t, 1, rec.calls())
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, rec.batches[0])
+}
+
+func TestLoader_MaxBatch(t *testing.T) {
+	rec := &recorder{}
+	loader := dataloader.New(rec.fetch, dataloader.Config{MaxBatch: 2, Wait: time.Hour})
+
+	values, err := loader.LoadMany(context.Background(), []int{0, 1, 2, 3, 4})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, values)
+	assert.Equal(t, 3, rec.calls())
+}
+
+func TestLoader_CachesPerLoader(t *testing.T) {
+	rec := &recorder{}
+	loader := dataloader.New(rec.fetch, dataloader.DefaultConfig())
+	ctx := context.Background()
+
+	_, err := loader.Load(ctx, 1)
+	require.NoError(t, err)
+	_, err = loader.Load(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rec.calls())
+
+	loader.Clear(1)
+	_, err = loader.Load(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, rec.calls())
+
+	loader.Prime(7, "primed")
+	value, err := loader.Load(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "primed", value)
+	assert.Equal(t, 2, rec.calls())
+}
+
+func TestLoader_MissingKeyReturnsZeroValue(t *testing.T) {
+	rec := &recorder{}
+	loader := dataloader.New(rec.fetch, dataloader.DefaultConfig())
+
+	value, err := loader.Load(context.Background(), -1)
+	require.NoError(t, err)
+	assert.Equal(t, "", value)
+}
+
+func TestLoader_ErrorsAreNotCached(t *testing.T) {
+	rec := &recorder{err: errors.New("database unavailable")}
+	loader := dataloader.New(rec.fetch, dataloader.DefaultConfig())
+	ctx := context.Background()
+
+	_, err := loader.Load(ctx, 1)
+	assert.Error(t, err)
+
+	rec.mu.Lock()
+	rec.err = nil
+	rec.mu.Unlock()
+
+	value, err := loader.Load(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "b", value)
+	assert.Equal(t, 2, rec.calls())
+}
+
+func TestLoader_WaitWindowGroupsConcurrentLoads(t *testing.T) {
+	var calls int32
+	loader := dataloader.New(func(_ context.Context, keys []int) (map[int]int, error) {
+		atomic.AddInt32(&calls, 1)
+		values := make(map[int]int, len(keys))
+		for _, key := range keys {
+			values[key] = key * 10
+		}
+		return values, nil
+	}, dataloader.Config{Wait: 20 * time.Millisecond})
+
+	// Registra todas as chaves antes de a janela expirar
+	ctx := context.Background()
+	thunks := make([]func() (int, error), 10)
+	for i := range thunks {
+		thunks[i] = loader.LoadThunk(ctx, i)
+	}
+
+	// Aguarda a janela disparar o lote sozinha
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	for i, thunk := range thunks {
+		value, err := thunk()
+		require.NoError(t, err)
+		assert.Equal(t, i*10, value)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,35 @@
+// Package dataloader implementa agrupamento (batching) e cache por request
+// para eliminar o problema N+1 em resolvers GraphQL.
+//
+// Este pacote fornece:
+//   - Loader genérico por chave (Loader[K, V]) com BatchFunc
+//   - Agrupamento de chaves dentro de uma janela configurável (Config.Wait)
+//   - Limite de chaves por lote (Config.MaxBatch)
+//   - Cache por instância: a mesma chave é buscada uma única vez
+//   - LoadThunk para integração com os thunks do executor do graphql-go
+//
+// # Ciclo de Vida
+//
+// Um Loader deve ser criado por request (ex.: em um middleware que coloca
+// os loaders no context). Assim o cache nunca vaza dados entre usuários e
+// é descartado ao fim da request. Erros não ficam em cache.
+//
+// # Exemplo de Uso
+//
+//	loader := dataloader.New(func(ctx context.Context, ids []uint) (map[uint]int, error) {
+//		return repo.GetMultipleUsersXP(ctx, ids)
+//	}, dataloader.DefaultConfig())
+//
+//	// Em um resolver GraphQL: retornar o thunk permite que todos os campos
+//	// irmãos sejam registrados antes do lote ser disparado
+//	Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+//		thunk := loader.LoadThunk(p.Context, userID)
+//		return func() (interface{}, error) {
+//			return thunk()
+//		}, nil
+//	}
+//
+// # Thread Safety
+//
+// Todas as operações do Loader são seguras para uso concorrente.
+package dataloader
@@ -92,6 +92,12 @@
 //   - DATABASE_ERROR: Erro de banco de dados
 //   - EXTERNAL_API_ERROR: Erro em API externa
 //
+// # Códigos no GraphQL
+//
+// Code(err) resolve o código exposto ao cliente (AppError.Code, sentinel na
+// cadeia ou INTERNAL_ERROR) e Validation(fields...) carrega falhas por campo;
+// pkg/graphqlerrors os publica em extensions.code e extensions.fields.
+//
 // Este pacote garante tratamento consistente e rastreável
 // de erros em toda a aplicação LabEnd.
 package errors
@@ -1,7 +1,10 @@
 package errors
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"net/http"
 
 	"github.com/pkg/errors"
 )
@@ -15,10 +18,26 @@ var (
 	ErrInternal      = errors.New("internal error")
 )
 
+// Códigos expostos aos clientes (ex.: extensions.code no GraphQL)
+const (
+	CodeNotFound      = "NOT_FOUND"
+	CodeAlreadyExists = "ALREADY_EXISTS"
+	CodeInvalidInput  = "INVALID_INPUT"
+	CodeUnauthorized  = "UNAUTHORIZED"
+	CodeInternal      = "INTERNAL_ERROR"
+)
+
 type AppError struct {
 	Code    string
 	Message string
 	Err     error
+	Fields  []FieldError
+}
+
+// FieldError - falha de validação de um campo da entrada
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 func (e AppError) Error() string {
@@ -34,7 +53,7 @@ func (e AppError) Unwrap() error {
 
 func NotFound(entity string, id interface{}) error {
 	return AppError{
-		Code:    "NOT_FOUND",
+		Code:    CodeNotFound,
 		Message: fmt.Sprintf("%s with id %v not found", entity, id),
 		Err:     ErrNotFound,
 	}
@@ -42,7 +61,7 @@ func NotFound(entity string, id interface{}) error {
 
 func AlreadyExists(entity string, field string, value interface{}) error {
 	return AppError{
-		Code:    "ALREADY_EXISTS",
+		Code:    CodeAlreadyExists,
 		Message: fmt.Sprintf("%s with %s %v already exists", entity, field, value),
 		Err:     ErrAlreadyExists,
 	}
@@ -50,20 +69,100 @@ func AlreadyExists(entity string, field string, value interface{}) error {
 
 func InvalidInput(msg string) error {
 	return AppError{
-		Code:    "INVALID_INPUT",
+		Code:    CodeInvalidInput,
 		Message: msg,
 		Err:     ErrInvalidInput,
 	}
 }
 
+// Validation - entrada inválida com a lista de campos que falharam
+func Validation(fields ...FieldError) error {
+	return AppError{
+		Code:    CodeInvalidInput,
+		Message: "validation failed",
+		Err:     ErrInvalidInput,
+		Fields:  fields,
+	}
+}
+
+func Unauthorized(msg string) error {
+	return AppError{
+		Code:    CodeUnauthorized,
+		Message: msg,
+		Err:     ErrUnauthorized,
+	}
+}
+
 func Internal(err error) error {
 	return AppError{
-		Code:    "INTERNAL_ERROR",
+		Code:    CodeInternal,
 		Message: "internal server error",
 		Err:     errors.Wrap(err, "internal error"),
 	}
 }
 
+// Code - código do erro: o da AppError mais externa, o do sentinel na cadeia
+// ou CodeInternal para erros desconhecidos
+func Code(err error) string {
+	var appErr AppError
+	if errors.As(err, &appErr) && appErr.Code != "" {
+		return appErr.Code
+	}
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, ErrAlreadyExists):
+		return CodeAlreadyExists
+	case errors.Is(err, ErrInvalidInput):
+		return CodeInvalidInput
+	case errors.Is(err, ErrUnauthorized):
+		return CodeUnauthorized
+	}
+	return CodeInternal
+}
+
+// HTTPStatus - status HTTP correspondente ao código do erro
+func HTTPStatus(err error) int {
+	switch Code(err) {
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeAlreadyExists:
+		return http.StatusConflict
+	case CodeInvalidInput:
+		return http.StatusBadRequest
+	case CodeUnauthorized:
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+// NewCorrelationID - identificador que liga a resposta de um erro interno ao log
+func NewCorrelationID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// PublicMessage - mensagem segura para o cliente (sem a causa encadeada)
+func PublicMessage(err error) string {
+	var appErr AppError
+	if errors.As(err, &appErr) && appErr.Message != "" {
+		return appErr.Message
+	}
+	return err.Error()
+}
+
+// Fields - erros por campo de uma falha de validação
+func Fields(err error) []FieldError {
+	var appErr AppError
+	if errors.As(err, &appErr) {
+		return appErr.Fields
+	}
+	return nil
+}
+
 // Wrap - para adicionar contexto
 func Wrap(err error, msg string) error {
 	return errors.Wrap(err, msg)
@@ -73,3 +172,8 @@ func Wrap(err error, msg string) error {
 func Is(err, target error) bool {
 	return errors.Is(err, target)
 }
+
+// As - helper para extrair um tipo de erro da cadeia
+func As(err error, target interface{}) bool {
+	return errors.As(err, target)
+}
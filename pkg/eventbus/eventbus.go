@@ -56,6 +56,31 @@ func (eb *EventBus) Subscribe(eventType string, handler EventHandler) {
 		zap.String("handler", getHandlerName(handler)))
 }
 
+// Unsubscribe - remove um handler previamente inscrito (comparado por identidade)
+func (eb *EventBus) Unsubscribe(eventType string, handler EventHandler) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	handlers := eb.handlers[eventType]
+	for i, h := range handlers {
+		if h == handler {
+			// Nova slice para não alterar cópias em uso por Publish
+			remaining := make([]EventHandler, 0, len(handlers)-1)
+			remaining = append(remaining, handlers[:i]...)
+			remaining = append(remaining, handlers[i+1:]...)
+			if len(remaining) == 0 {
+				delete(eb.handlers, eventType)
+			} else {
+				eb.handlers[eventType] = remaining
+			}
+			eb.logger.Debug("event handler unsubscribed",
+				zap.String("event_type", eventType),
+				zap.String("handler", getHandlerName(handler)))
+			return
+		}
+	}
+}
+
 // Publish - publica evento para todos os handlers interessados
 func (eb *EventBus) Publish(event Event) {
 	eb.mu.RLock()
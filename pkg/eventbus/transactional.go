@@ -7,6 +7,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/rafaelcoelhox/labbend/pkg/database"
 	"github.com/rafaelcoelhox/labbend/pkg/errors"
 	"github.com/rafaelcoelhox/labbend/pkg/logger"
 
@@ -15,15 +16,15 @@ import (
 
 // OutboxEvent - evento armazenado no outbox
 type OutboxEvent struct {
-	ID          uint            `json:"id" gorm:"primarykey"`
-	EventType   string          `json:"event_type" gorm:"not null;index"`
-	EventSource string          `json:"event_source" gorm:"not null"`
-	EventData   json.RawMessage `json:"event_data" gorm:"type:jsonb"`
-	Status      string          `json:"status" gorm:"not null;default:'pending';index"`
-	CreatedAt   time.Time       `json:"created_at" gorm:"index"`
-	ProcessedAt *time.Time      `json:"processed_at"`
-	RetryCount  int             `json:"retry_count" gorm:"default:0"`
-	ErrorMsg    string          `json:"error_msg"`
+	ID          uint          `json:"id" gorm:"primarykey"`
+	EventType   string        `json:"event_type" gorm:"not null;index"`
+	EventSource string        `json:"event_source" gorm:"not null"`
+	EventData   database.JSON `json:"event_data"`
+	Status      string        `json:"status" gorm:"not null;default:'pending';index"`
+	CreatedAt   time.Time     `json:"created_at" gorm:"index"`
+	ProcessedAt *time.Time    `json:"processed_at"`
+	RetryCount  int           `json:"retry_count" gorm:"default:0"`
+	ErrorMsg    string        `json:"error_msg"`
 }
 
 const (
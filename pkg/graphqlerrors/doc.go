@@ -0,0 +1,45 @@
+// Package graphqlerrors centraliza a apresentação dos erros dos resolvers
+// GraphQL ao cliente.
+//
+// Este pacote fornece:
+//   - Presenter: mapeia AppError e sentinels de pkg/errors para extensions.code
+//   - Erros de validação por campo em extensions.fields (errors.Validation)
+//   - Erros internos ocultos em produção, com extensions.correlationId no log
+//   - Extensão do graphql-go que reescreve os erros ao fim da execução
+//
+// # Códigos
+//
+//	AppError.Code (mais externa)   -> código da AppError
+//	errors.ErrNotFound na cadeia   -> NOT_FOUND
+//	errors.ErrAlreadyExists        -> ALREADY_EXISTS
+//	errors.ErrInvalidInput         -> INVALID_INPUT
+//	errors.ErrUnauthorized         -> UNAUTHORIZED
+//	qualquer outro erro            -> INTERNAL_ERROR
+//
+// Erros que já trazem extensions.code (complexity, persisted) e erros de
+// sintaxe/validação do documento são mantidos como estão.
+//
+// # Exemplo de Uso
+//
+//	presenter := graphqlerrors.NewPresenter(graphqlerrors.Config{
+//		Production: config.IsProduction(),
+//		Logger:     log,
+//	})
+//	schema.AddExtensions(presenter)
+//
+//	// Subscriptions não passam pelas extensions do schema
+//	graphqlws.NewHandler(graphqlws.Config{
+//		Schema:        &schema,
+//		PresentErrors: presenter.PresentAll,
+//	})
+//
+// Nos resolvers, basta retornar os erros de pkg/errors:
+//
+//	if err != nil {
+//		return nil, errors.InvalidInput(fmt.Sprintf("ID inválido: %v", err))
+//	}
+//
+// # Thread Safety
+//
+// Presenter não guarda estado por operação e pode ser compartilhado.
+package graphqlerrors
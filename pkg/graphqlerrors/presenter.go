@@ -0,0 +1,142 @@
+package graphqlerrors
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"go.uber.org/zap"
+
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+// ExtensionName - nome da extensão registrada no schema
+const ExtensionName = "errorPresenter"
+
+// InternalMessage - mensagem exibida no lugar de erros internos em produção
+const InternalMessage = "internal server error"
+
+// Config - configuração do Presenter
+type Config struct {
+	// Production oculta a mensagem de erros internos (fica só o correlationId)
+	Production bool
+	Logger     logger.Logger
+}
+
+// Presenter - converte erros dos resolvers em erros GraphQL com extensions.code
+type Presenter struct {
+	config Config
+}
+
+var _ graphql.Extension = (*Presenter)(nil)
+
+// NewPresenter - cria o presenter
+func NewPresenter(config Config) *Presenter {
+	return &Presenter{config: config}
+}
+
+// Present - aplica o mapeamento a um erro formatado pelo graphql-go.
+// Erros que já têm extensions.code (complexity, persisted) e erros sem causa
+// de resolver (sintaxe, validação) são mantidos como estão.
+func (p *Presenter) Present(ctx context.Context, formatted gqlerrors.FormattedError) gqlerrors.FormattedError {
+	if _, ok := formatted.Extensions["code"]; ok {
+		return formatted
+	}
+	cause := originalError(formatted)
+	if cause == nil {
+		return formatted
+	}
+
+	extensions := map[string]interface{}{}
+	if extended, ok := cause.(gqlerrors.ExtendedError); ok {
+		for k, v := range extended.Extensions() {
+			extensions[k] = v
+		}
+	}
+
+	code := errors.Code(cause)
+	extensions["code"] = code
+	message := errors.PublicMessage(cause)
+
+	if code == errors.CodeInternal {
+		correlationID := errors.NewCorrelationID()
+		extensions["correlationId"] = correlationID
+		if p.config.Logger != nil {
+			p.config.Logger.Error("graphql internal error",
+				zap.String("correlation_id", correlationID),
+				zap.Any("path", formatted.Path),
+				zap.Error(cause))
+		}
+		if p.config.Production {
+			message = InternalMessage
+		} else {
+			message = cause.Error()
+		}
+	}
+
+	if fields := errors.Fields(cause); len(fields) > 0 {
+		extensions["fields"] = fields
+	}
+
+	return gqlerrors.FormattedError{
+		Message:    message,
+		Locations:  formatted.Locations,
+		Path:       formatted.Path,
+		Extensions: extensions,
+	}
+}
+
+// PresentAll - aplica Present a cada erro da lista
+func (p *Presenter) PresentAll(ctx context.Context, errs []gqlerrors.FormattedError) []gqlerrors.FormattedError {
+	if len(errs) == 0 {
+		return errs
+	}
+	presented := make([]gqlerrors.FormattedError, len(errs))
+	for i, err := range errs {
+		presented[i] = p.Present(ctx, err)
+	}
+	return presented
+}
+
+// originalError - erro retornado pelo resolver, sem o envelope do graphql-go
+func originalError(formatted gqlerrors.FormattedError) error {
+	switch err := formatted.OriginalError().(type) {
+	case *gqlerrors.Error:
+		return err.OriginalError
+	case gqlerrors.Error:
+		return err.OriginalError
+	default:
+		return err
+	}
+}
+
+// Extension - os erros da execução são reescritos ao fim de cada operação
+
+func (p *Presenter) Init(ctx context.Context, _ *graphql.Params) context.Context { return ctx }
+
+func (p *Presenter) Name() string { return ExtensionName }
+
+func (p *Presenter) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(error) {}
+}
+
+func (p *Presenter) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func([]gqlerrors.FormattedError) {}
+}
+
+func (p *Presenter) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	return ctx, func(result *graphql.Result) {
+		if result != nil {
+			result.Errors = p.PresentAll(ctx, result.Errors)
+		}
+	}
+}
+
+func (p *Presenter) ResolveFieldDidStart(ctx context.Context, _ *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	return ctx, func(interface{}, error) {}
+}
+
+func (p *Presenter) HasResult() bool { return false }
+
+func (p *Presenter) GetResult(context.Context) interface{} { return nil }
@@ -0,0 +1,113 @@
+package graphqlerrors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+)
+
+func newTestSchema(t *testing.T, presenter *Presenter, resolve graphql.FieldResolveFn) graphql.Schema {
+	t.Helper()
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"item": &graphql.Field{Type: graphql.String, Resolve: resolve},
+			},
+		}),
+	})
+	require.NoError(t, err)
+	schema.AddExtensions(presenter)
+	return schema
+}
+
+func execute(t *testing.T, production bool, resolverErr error) *graphql.Result {
+	t.Helper()
+	presenter := NewPresenter(Config{Production: production})
+	schema := newTestSchema(t, presenter, func(graphql.ResolveParams) (interface{}, error) {
+		return nil, resolverErr
+	})
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: "{ item }", Context: context.Background()})
+	require.Len(t, result.Errors, 1)
+	return result
+}
+
+func TestPresenter_MapsCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    string
+		message string
+	}{
+		{"not found", errors.NotFound("user", 7), errors.CodeNotFound, "user with id 7 not found"},
+		{"already exists", errors.AlreadyExists("user", "email", "a@b.c"), errors.CodeAlreadyExists, "user with email a@b.c already exists"},
+		{"invalid input", errors.InvalidInput("ID inválido"), errors.CodeInvalidInput, "ID inválido"},
+		{"unauthorized", errors.Unauthorized("moderator role required"), errors.CodeUnauthorized, "moderator role required"},
+		{"wrapped sentinel", fmt.Errorf("lookup: %w", errors.ErrNotFound), errors.CodeNotFound, "lookup: resource not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := execute(t, true, tt.err)
+			assert.Equal(t, tt.message, result.Errors[0].Message)
+			assert.Equal(t, tt.code, result.Errors[0].Extensions["code"])
+			assert.Equal(t, []interface{}{"item"}, result.Errors[0].Path)
+			assert.NotContains(t, result.Errors[0].Extensions, "correlationId")
+		})
+	}
+}
+
+func TestPresenter_HidesInternalErrorsInProduction(t *testing.T) {
+	cause := errors.Internal(gorm.ErrInvalidDB)
+
+	result := execute(t, true, cause)
+	assert.Equal(t, InternalMessage, result.Errors[0].Message)
+	assert.Equal(t, errors.CodeInternal, result.Errors[0].Extensions["code"])
+	assert.NotEmpty(t, result.Errors[0].Extensions["correlationId"])
+
+	// Erros desconhecidos também são tratados como internos
+	result = execute(t, true, fmt.Errorf("pq: connection refused"))
+	assert.Equal(t, InternalMessage, result.Errors[0].Message)
+	assert.Equal(t, errors.CodeInternal, result.Errors[0].Extensions["code"])
+
+	result = execute(t, false, fmt.Errorf("pq: connection refused"))
+	assert.Equal(t, "pq: connection refused", result.Errors[0].Message)
+	assert.NotEmpty(t, result.Errors[0].Extensions["correlationId"])
+}
+
+func TestPresenter_ValidationFields(t *testing.T) {
+	result := execute(t, true, errors.Validation(
+		errors.FieldError{Field: "email", Message: "must be a valid email"},
+		errors.FieldError{Field: "name", Message: "is required"},
+	))
+
+	assert.Equal(t, "validation failed", result.Errors[0].Message)
+	assert.Equal(t, errors.CodeInvalidInput, result.Errors[0].Extensions["code"])
+	assert.Equal(t, []errors.FieldError{
+		{Field: "email", Message: "must be a valid email"},
+		{Field: "name", Message: "is required"},
+	}, result.Errors[0].Extensions["fields"])
+}
+
+func TestPresenter_KeepsErrorsWithoutResolverCause(t *testing.T) {
+	presenter := NewPresenter(Config{Production: true})
+	schema := newTestSchema(t, presenter, func(graphql.ResolveParams) (interface{}, error) {
+		return "ok", nil
+	})
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: "{ missing }"})
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, "missing")
+	assert.Nil(t, result.Errors[0].Extensions)
+
+	// Idempotente: erros já apresentados não mudam
+	presented := execute(t, true, errors.NotFound("user", 1)).Errors
+	assert.Equal(t, presented, presenter.PresentAll(context.Background(), presented))
+}
@@ -0,0 +1,52 @@
+// Package graphqlws implementa o protocolo graphql-transport-ws para servir
+// GraphQL subscriptions (e também queries/mutations) sobre WebSocket.
+//
+// Este pacote fornece:
+//   - Handler HTTP que faz o upgrade para WebSocket e negocia o subprotocolo
+//   - Handshake connection_init/connection_ack com autenticação por conexão
+//   - Execução de operações subscribe/next/error/complete com IDs por operação
+//   - Ping/pong e timeout de inicialização da conexão
+//   - EventStream para alimentar subscriptions a partir do EventBus
+//   - Limpeza automática das inscrições no EventBus ao desconectar
+//
+// # Protocolo
+//
+// O fluxo segue a especificação graphql-transport-ws:
+//  1. Cliente envia connection_init (payload opcional, ex.: authToken)
+//  2. Servidor valida via Config.Authenticate e responde connection_ack
+//  3. Cliente envia subscribe com id e {query, variables, operationName}
+//  4. Servidor envia next para cada resultado e complete ao terminar
+//  5. Cliente pode enviar complete para cancelar uma operação
+//
+// Violações do protocolo fecham a conexão com os close codes 4400, 4401,
+// 4408, 4409 e 4429.
+//
+// # Exemplo de Uso
+//
+//	handler := graphqlws.NewHandler(graphqlws.Config{
+//		Schema: &schema,
+//		Logger: logger,
+//		Authenticate: func(ctx context.Context, payload map[string]interface{}) (context.Context, error) {
+//			return ctx, nil
+//		},
+//	})
+//	router.GET("/graphql/ws", gin.WrapH(handler))
+//
+// Campos de subscription retornam um canal criado por EventStream:
+//
+//	Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+//		return graphqlws.EventStream(p.Context, bus, []string{"ChallengeVoteAdded"},
+//			func(event eventbus.Event) (interface{}, bool) {
+//				return event.Data, true
+//			}), nil
+//	},
+//	Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+//		return p.Source, nil
+//	},
+//
+// # Thread Safety
+//
+// Cada conexão tem um loop de leitura e uma goroutine por operação; as
+// escritas no WebSocket são serializadas por mutex. Ao desconectar, todas
+// as operações são canceladas e o EventStream remove seus handlers do bus.
+package graphqlws
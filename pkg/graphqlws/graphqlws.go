@@ -0,0 +1,457 @@
+package graphqlws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"go.uber.org/zap"
+
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+// Subprotocol - nome do subprotocolo WebSocket negociado com o cliente
+const Subprotocol = "graphql-transport-ws"
+
+// Tipos de mensagem do protocolo graphql-transport-ws
+const (
+	MessageConnectionInit = "connection_init"
+	MessageConnectionAck  = "connection_ack"
+	MessagePing           = "ping"
+	MessagePong           = "pong"
+	MessageSubscribe      = "subscribe"
+	MessageNext           = "next"
+	MessageError          = "error"
+	MessageComplete       = "complete"
+)
+
+// Close codes definidos pelo protocolo
+const (
+	CloseBadRequest          = 4400
+	CloseUnauthorized        = 4401
+	CloseInitTimeout         = 4408
+	CloseSubscriberExists    = 4409
+	CloseTooManyInitRequests = 4429
+	CloseInternalError       = 4500
+)
+
+const defaultConnectionInitTimeout = 10 * time.Second
+
+// Message - envelope de todas as mensagens trocadas
+type Message struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// SubscribePayload - payload da mensagem subscribe
+type SubscribePayload struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+type connectionPayloadKey struct{}
+
+// ConnectionPayload - payload do connection_init disponível no contexto dos resolvers
+func ConnectionPayload(ctx context.Context) map[string]interface{} {
+	payload, _ := ctx.Value(connectionPayloadKey{}).(map[string]interface{})
+	return payload
+}
+
+// AuthenticateFunc - valida o payload de connection_init e retorna o contexto da conexão
+type AuthenticateFunc func(ctx context.Context, payload map[string]interface{}) (context.Context, error)
+
+// Config - configuração do handler WebSocket
+type Config struct {
+	Schema                *graphql.Schema
+	Logger                logger.Logger
+	Authenticate          AuthenticateFunc
+	ConnectionInitTimeout time.Duration
+	CheckOrigin           func(r *http.Request) bool
+	// OperationContext - prepara o contexto de cada operação (ex.: dataloaders novos)
+	OperationContext func(ctx context.Context) context.Context
+	// CheckOperation - valida a operação antes da execução (ex.: limites de complexidade);
+	// o erro é enviado ao cliente como mensagem error
+	CheckOperation func(ctx context.Context, payload SubscribePayload) (context.Context, error)
+	// PresentErrors - reescreve os erros de cada resultado (ex.: graphqlerrors.Presenter);
+	// necessário nos eventos de subscription, que não passam pelas extensions do schema
+	PresentErrors func(ctx context.Context, errs []gqlerrors.FormattedError) []gqlerrors.FormattedError
+}
+
+// Handler - http.Handler que implementa graphql-transport-ws sobre WebSocket
+type Handler struct {
+	config   Config
+	upgrader websocket.Upgrader
+}
+
+// NewHandler - cria o handler; Authenticate nil aceita qualquer conexão
+func NewHandler(config Config) *Handler {
+	if config.ConnectionInitTimeout <= 0 {
+		config.ConnectionInitTimeout = defaultConnectionInitTimeout
+	}
+	if config.Authenticate == nil {
+		config.Authenticate = func(ctx context.Context, _ map[string]interface{}) (context.Context, error) {
+			return ctx, nil
+		}
+	}
+
+	return &Handler{
+		config: config,
+		upgrader: websocket.Upgrader{
+			Subprotocols: []string{Subprotocol},
+			CheckOrigin:  config.CheckOrigin,
+		},
+	}
+}
+
+// ServeHTTP - faz o upgrade para WebSocket e atende a conexão até o disconnect
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.config.Logger.Warn("websocket upgrade failed", zap.Error(err))
+		return
+	}
+
+	if ws.Subprotocol() != Subprotocol {
+		h.config.Logger.Warn("websocket client did not negotiate graphql-transport-ws")
+		ws.WriteMessage(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseProtocolError, "unsupported subprotocol"))
+		ws.Close()
+		return
+	}
+
+	conn := newConnection(ws, h, r.Context())
+	conn.serve()
+}
+
+// connection - estado de uma conexão WebSocket
+type connection struct {
+	ws      *websocket.Conn
+	handler *Handler
+	logger  logger.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	writeMu sync.Mutex
+
+	mu           sync.Mutex
+	initReceived bool
+	acknowledged bool
+	operations   map[string]context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+func newConnection(ws *websocket.Conn, handler *Handler, parent context.Context) *connection {
+	// O contexto da conexão não herda o cancelamento da request HTTP
+	ctx, cancel := context.WithCancel(context.WithoutCancel(parent))
+	return &connection{
+		ws:         ws,
+		handler:    handler,
+		logger:     handler.config.Logger,
+		ctx:        ctx,
+		cancel:     cancel,
+		operations: make(map[string]context.CancelFunc),
+	}
+}
+
+// serve - loop de leitura; ao sair cancela todas as operações e fecha a conexão
+func (c *connection) serve() {
+	defer c.cleanup()
+
+	initTimer := time.AfterFunc(c.handler.config.ConnectionInitTimeout, func() {
+		c.mu.Lock()
+		acknowledged := c.acknowledged
+		c.mu.Unlock()
+		if !acknowledged {
+			c.close(CloseInitTimeout, "Connection initialisation timeout")
+		}
+	})
+	defer initTimer.Stop()
+
+	for {
+		var msg Message
+		if err := c.ws.ReadJSON(&msg); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				c.logger.Debug("websocket read finished", zap.Error(err))
+			}
+			return
+		}
+
+		if !c.handleMessage(msg) {
+			return
+		}
+	}
+}
+
+// handleMessage - trata uma mensagem; retorna false quando a conexão deve ser encerrada
+func (c *connection) handleMessage(msg Message) bool {
+	switch msg.Type {
+	case MessageConnectionInit:
+		return c.handleInit(msg)
+
+	case MessagePing:
+		c.write(Message{Type: MessagePong})
+		return true
+
+	case MessagePong:
+		return true
+
+	case MessageSubscribe:
+		if !c.isAcknowledged() {
+			c.close(CloseUnauthorized, "Unauthorized")
+			return false
+		}
+		return c.handleSubscribe(msg)
+
+	case MessageComplete:
+		c.stopOperation(msg.ID)
+		return true
+	}
+
+	c.close(CloseBadRequest, fmt.Sprintf("Invalid message type %q", msg.Type))
+	return false
+}
+
+func (c *connection) handleInit(msg Message) bool {
+	c.mu.Lock()
+	if c.initReceived {
+		c.mu.Unlock()
+		c.close(CloseTooManyInitRequests, "Too many initialisation requests")
+		return false
+	}
+	c.initReceived = true
+	c.mu.Unlock()
+
+	var payload map[string]interface{}
+	if len(msg.Payload) > 0 {
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			c.close(CloseBadRequest, "Invalid connection_init payload")
+			return false
+		}
+	}
+
+	ctx := context.WithValue(c.ctx, connectionPayloadKey{}, payload)
+	ctx, err := c.handler.config.Authenticate(ctx, payload)
+	if err != nil {
+		c.logger.Warn("websocket connection rejected", zap.Error(err))
+		c.close(CloseUnauthorized, "Forbidden")
+		return false
+	}
+
+	c.mu.Lock()
+	c.ctx = ctx
+	c.acknowledged = true
+	c.mu.Unlock()
+
+	c.write(Message{Type: MessageConnectionAck})
+	return true
+}
+
+func (c *connection) handleSubscribe(msg Message) bool {
+	if msg.ID == "" {
+		c.close(CloseBadRequest, "Subscribe message requires an id")
+		return false
+	}
+
+	var payload SubscribePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil || payload.Query == "" {
+		c.close(CloseBadRequest, "Invalid subscribe payload")
+		return false
+	}
+
+	c.mu.Lock()
+	if _, exists := c.operations[msg.ID]; exists {
+		c.mu.Unlock()
+		c.close(CloseSubscriberExists, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+		return false
+	}
+	opCtx, cancel := context.WithCancel(c.ctx)
+	if c.handler.config.OperationContext != nil {
+		opCtx = c.handler.config.OperationContext(opCtx)
+	}
+	c.operations[msg.ID] = cancel
+	c.wg.Add(1)
+	c.mu.Unlock()
+
+	go c.runOperation(opCtx, msg.ID, payload)
+	return true
+}
+
+// runOperation - executa query/mutation uma vez ou encaminha cada resultado da subscription
+func (c *connection) runOperation(ctx context.Context, id string, payload SubscribePayload) {
+	defer c.wg.Done()
+
+	if c.handler.config.CheckOperation != nil {
+		checked, err := c.handler.config.CheckOperation(ctx, payload)
+		if err != nil {
+			c.write(Message{ID: id, Type: MessageError, Payload: mustMarshal([]gqlerrors.FormattedError{formatError(err)})})
+			c.finishOperation(id, false)
+			return
+		}
+		ctx = checked
+	}
+
+	params := graphql.Params{
+		Schema:         *c.handler.config.Schema,
+		RequestString:  payload.Query,
+		VariableValues: payload.Variables,
+		OperationName:  payload.OperationName,
+		Context:        ctx,
+	}
+
+	if !isSubscription(payload) {
+		c.sendResult(ctx, id, graphql.Do(params))
+		c.finishOperation(id, true)
+		return
+	}
+
+	results := graphql.Subscribe(params)
+	first := true
+	for result := range results {
+		// Erros antes do primeiro evento (validação, argumentos) usam a mensagem error
+		if first && result.Data == nil && result.HasErrors() {
+			c.write(Message{ID: id, Type: MessageError, Payload: mustMarshal(c.presentErrors(ctx, result.Errors))})
+			c.finishOperation(id, false)
+			drain(results)
+			return
+		}
+		first = false
+		c.sendResult(ctx, id, result)
+	}
+
+	c.finishOperation(id, ctx.Err() == nil)
+}
+
+// formatError - erro de CheckOperation, preservando extensions (gqlerrors.ExtendedError)
+func formatError(err error) gqlerrors.FormattedError {
+	formatted := gqlerrors.NewFormattedError(err.Error())
+	var extended gqlerrors.ExtendedError
+	if errors.As(err, &extended) {
+		formatted.Extensions = extended.Extensions()
+	}
+	return formatted
+}
+
+func (c *connection) sendResult(ctx context.Context, id string, result *graphql.Result) {
+	result.Errors = c.presentErrors(ctx, result.Errors)
+	c.write(Message{ID: id, Type: MessageNext, Payload: mustMarshal(result)})
+}
+
+func (c *connection) presentErrors(ctx context.Context, errs []gqlerrors.FormattedError) []gqlerrors.FormattedError {
+	if c.handler.config.PresentErrors == nil || len(errs) == 0 {
+		return errs
+	}
+	return c.handler.config.PresentErrors(ctx, errs)
+}
+
+// finishOperation - remove a operação e, se o servidor a encerrou, envia complete
+func (c *connection) finishOperation(id string, notify bool) {
+	c.mu.Lock()
+	cancel, exists := c.operations[id]
+	delete(c.operations, id)
+	c.mu.Unlock()
+
+	if !exists {
+		// Cliente já enviou complete
+		return
+	}
+	cancel()
+	if notify {
+		c.write(Message{ID: id, Type: MessageComplete})
+	}
+}
+
+// stopOperation - complete enviado pelo cliente
+func (c *connection) stopOperation(id string) {
+	c.mu.Lock()
+	cancel, exists := c.operations[id]
+	delete(c.operations, id)
+	c.mu.Unlock()
+
+	if exists {
+		cancel()
+	}
+}
+
+func (c *connection) isAcknowledged() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.acknowledged
+}
+
+func (c *connection) write(msg Message) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.ws.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	if err := c.ws.WriteJSON(msg); err != nil {
+		c.logger.Debug("websocket write failed", zap.String("type", msg.Type), zap.Error(err))
+	}
+}
+
+func (c *connection) close(code int, reason string) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.ws.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+	c.ws.Close()
+}
+
+// cleanup - cancela todas as operações da conexão (remove inscrições no event bus)
+func (c *connection) cleanup() {
+	c.mu.Lock()
+	for id, cancel := range c.operations {
+		cancel()
+		delete(c.operations, id)
+	}
+	c.mu.Unlock()
+
+	c.cancel()
+	c.wg.Wait()
+	c.ws.Close()
+}
+
+// isSubscription - identifica se a operação selecionada é uma subscription
+func isSubscription(payload SubscribePayload) bool {
+	document, err := parser.Parse(parser.ParseParams{Source: payload.Query})
+	if err != nil {
+		return false
+	}
+
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if payload.OperationName != "" && (operation.Name == nil || operation.Name.Value != payload.OperationName) {
+			continue
+		}
+		return operation.Operation == ast.OperationTypeSubscription
+	}
+	return false
+}
+
+func drain(results chan *graphql.Result) {
+	for range results {
+	}
+}
+
+func mustMarshal(v interface{}) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal([]gqlerrors.FormattedError{gqlerrors.NewFormattedError(err.Error())})
+	}
+	return data
+}
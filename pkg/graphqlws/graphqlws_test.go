@@ -0,0 +1,322 @@
+package graphqlws_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	"github.com/rafaelcoelhox/labbend/pkg/graphqlws"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+// fakeBus - event bus síncrono que expõe o número de handlers inscritos
+type fakeBus struct {
+	mu       sync.Mutex
+	handlers map[string][]eventbus.EventHandler
+}
+
+func newFakeBus() *fakeBus {
+	return &fakeBus{handlers: make(map[string][]eventbus.EventHandler)}
+}
+
+func (b *fakeBus) Subscribe(eventType string, handler eventbus.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+func (b *fakeBus) Unsubscribe(eventType string, handler eventbus.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	handlers := b.handlers[eventType]
+	for i, h := range handlers {
+		if h == handler {
+			b.handlers[eventType] = append(handlers[:i:i], handlers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (b *fakeBus) Publish(event eventbus.Event) {
+	b.mu.Lock()
+	handlers := append([]eventbus.EventHandler(nil), b.handlers[event.Type]...)
+	b.mu.Unlock()
+	for _, h := range handlers {
+		h.HandleEvent(context.Background(), event)
+	}
+}
+
+func (b *fakeBus) count(eventType string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.handlers[eventType])
+}
+
+func newTestServer(t *testing.T, bus *fakeBus) *httptest.Server {
+	t.Helper()
+	return newTestServerWithConfig(t, bus, nil)
+}
+
+// newTestServerWithConfig - configure permite ajustar o Config antes de criar o handler
+func newTestServerWithConfig(t *testing.T, bus *fakeBus, configure func(*graphqlws.Config)) *httptest.Server {
+	t.Helper()
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"ping": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "pong", nil
+					},
+				},
+				"broken": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("db down")
+					},
+				},
+			},
+		}),
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"greeting": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					},
+					Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+						name := p.Args["name"].(string)
+						return graphqlws.EventStream(p.Context, bus, []string{"Greeted"}, func(event eventbus.Event) (interface{}, bool) {
+							if event.Data["name"] != name {
+								return nil, false
+							}
+							return "olá " + name, true
+						}), nil
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source, nil
+					},
+				},
+			},
+		}),
+	})
+	require.NoError(t, err)
+
+	log, err := logger.New()
+	require.NoError(t, err)
+
+	config := graphqlws.Config{
+		Schema: &schema,
+		Logger: log,
+		Authenticate: func(ctx context.Context, payload map[string]interface{}) (context.Context, error) {
+			if payload["authToken"] != "secret" {
+				return nil, errors.New("invalid token")
+			}
+			return ctx, nil
+		},
+		ConnectionInitTimeout: time.Second,
+	}
+	if configure != nil {
+		configure(&config)
+	}
+
+	return httptest.NewServer(graphqlws.NewHandler(config))
+}
+
+func dial(t *testing.T, server *httptest.Server) *websocket.Conn {
+	t.Helper()
+
+	dialer := websocket.Dialer{Subprotocols: []string{graphqlws.Subprotocol}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+func send(t *testing.T, conn *websocket.Conn, msgType, id string, payload interface{}) {
+	t.Helper()
+
+	msg := map[string]interface{}{"type": msgType}
+	if id != "" {
+		msg["id"] = id
+	}
+	if payload != nil {
+		msg["payload"] = payload
+	}
+	require.NoError(t, conn.WriteJSON(msg))
+}
+
+func read(t *testing.T, conn *websocket.Conn) graphqlws.Message {
+	t.Helper()
+
+	var msg graphqlws.Message
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func TestHandler_SubscriptionLifecycle(t *testing.T) {
+	bus := newFakeBus()
+	server := newTestServer(t, bus)
+	defer server.Close()
+
+	conn := dial(t, server)
+	defer conn.Close()
+
+	send(t, conn, graphqlws.MessageConnectionInit, "", map[string]interface{}{"authToken": "secret"})
+	assert.Equal(t, graphqlws.MessageConnectionAck, read(t, conn).Type)
+
+	send(t, conn, graphqlws.MessagePing, "", nil)
+	assert.Equal(t, graphqlws.MessagePong, read(t, conn).Type)
+
+	// Query simples é executada uma vez e finalizada com complete
+	send(t, conn, graphqlws.MessageSubscribe, "q1", map[string]interface{}{"query": "{ ping }"})
+	next := read(t, conn)
+	assert.Equal(t, graphqlws.MessageNext, next.Type)
+	assert.JSONEq(t, `{"data":{"ping":"pong"}}`, string(next.Payload))
+	assert.Equal(t, graphqlws.MessageComplete, read(t, conn).Type)
+
+	// Subscription recebe apenas eventos que passam pelo filtro
+	send(t, conn, graphqlws.MessageSubscribe, "s1", map[string]interface{}{
+		"query":     `subscription($name: String!) { greeting(name: $name) }`,
+		"variables": map[string]interface{}{"name": "ana"},
+	})
+	require.Eventually(t, func() bool { return bus.count("Greeted") == 1 }, time.Second, 10*time.Millisecond)
+
+	bus.Publish(eventbus.Event{Type: "Greeted", Data: map[string]interface{}{"name": "bia"}})
+	bus.Publish(eventbus.Event{Type: "Greeted", Data: map[string]interface{}{"name": "ana"}})
+
+	next = read(t, conn)
+	assert.Equal(t, "s1", next.ID)
+	var result struct {
+		Data map[string]string `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(next.Payload, &result))
+	assert.Equal(t, "olá ana", result.Data["greeting"])
+
+	// complete do cliente remove a inscrição no event bus
+	send(t, conn, graphqlws.MessageComplete, "s1", nil)
+	assert.Eventually(t, func() bool { return bus.count("Greeted") == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestHandler_DisconnectCleansUpSubscriptions(t *testing.T) {
+	bus := newFakeBus()
+	server := newTestServer(t, bus)
+	defer server.Close()
+
+	conn := dial(t, server)
+	send(t, conn, graphqlws.MessageConnectionInit, "", map[string]interface{}{"authToken": "secret"})
+	read(t, conn)
+
+	send(t, conn, graphqlws.MessageSubscribe, "s1", map[string]interface{}{
+		"query": `subscription { greeting(name: "ana") }`,
+	})
+	require.Eventually(t, func() bool { return bus.count("Greeted") == 1 }, time.Second, 10*time.Millisecond)
+
+	conn.Close()
+	assert.Eventually(t, func() bool { return bus.count("Greeted") == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestHandler_RejectsUnauthorizedConnection(t *testing.T) {
+	server := newTestServer(t, newFakeBus())
+	defer server.Close()
+
+	conn := dial(t, server)
+	defer conn.Close()
+
+	send(t, conn, graphqlws.MessageConnectionInit, "", map[string]interface{}{"authToken": "wrong"})
+
+	var msg graphqlws.Message
+	err := conn.ReadJSON(&msg)
+	assert.True(t, websocket.IsCloseError(err, graphqlws.CloseUnauthorized))
+}
+
+func TestHandler_SubscribeBeforeInit(t *testing.T) {
+	server := newTestServer(t, newFakeBus())
+	defer server.Close()
+
+	conn := dial(t, server)
+	defer conn.Close()
+
+	send(t, conn, graphqlws.MessageSubscribe, "s1", map[string]interface{}{"query": "{ ping }"})
+
+	var msg graphqlws.Message
+	err := conn.ReadJSON(&msg)
+	assert.True(t, websocket.IsCloseError(err, graphqlws.CloseUnauthorized))
+}
+
+// rejectedError - erro com extensions, como os de limite de complexidade
+type rejectedError struct{}
+
+func (rejectedError) Error() string { return "query too complex" }
+
+func (rejectedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": "QUERY_TOO_COMPLEX"}
+}
+
+func TestHandler_CheckOperationRejects(t *testing.T) {
+	server := newTestServerWithConfig(t, newFakeBus(), func(config *graphqlws.Config) {
+		config.CheckOperation = func(ctx context.Context, payload graphqlws.SubscribePayload) (context.Context, error) {
+			if strings.Contains(payload.Query, "greeting") {
+				return nil, rejectedError{}
+			}
+			return ctx, nil
+		}
+	})
+	defer server.Close()
+
+	conn := dial(t, server)
+	defer conn.Close()
+
+	send(t, conn, graphqlws.MessageConnectionInit, "", map[string]interface{}{"authToken": "secret"})
+	read(t, conn)
+
+	send(t, conn, graphqlws.MessageSubscribe, "s1", map[string]interface{}{
+		"query": `subscription { greeting(name: "ana") }`,
+	})
+	msg := read(t, conn)
+	assert.Equal(t, graphqlws.MessageError, msg.Type)
+	assert.JSONEq(t, `[{"message":"query too complex","locations":[],"extensions":{"code":"QUERY_TOO_COMPLEX"}}]`, string(msg.Payload))
+
+	// Operações aceitas continuam funcionando na mesma conexão
+	send(t, conn, graphqlws.MessageSubscribe, "q1", map[string]interface{}{"query": "{ ping }"})
+	assert.Equal(t, graphqlws.MessageNext, read(t, conn).Type)
+}
+
+func TestHandler_PresentErrors(t *testing.T) {
+	server := newTestServerWithConfig(t, newFakeBus(), func(config *graphqlws.Config) {
+		config.PresentErrors = func(ctx context.Context, errs []gqlerrors.FormattedError) []gqlerrors.FormattedError {
+			for i := range errs {
+				errs[i].Message = "internal server error"
+				errs[i].Extensions = map[string]interface{}{"code": "INTERNAL_ERROR"}
+			}
+			return errs
+		}
+	})
+	defer server.Close()
+
+	conn := dial(t, server)
+	defer conn.Close()
+
+	send(t, conn, graphqlws.MessageConnectionInit, "", map[string]interface{}{"authToken": "secret"})
+	read(t, conn)
+
+	send(t, conn, graphqlws.MessageSubscribe, "q1", map[string]interface{}{"query": "{ broken }"})
+	msg := read(t, conn)
+	assert.Equal(t, graphqlws.MessageNext, msg.Type)
+	assert.JSONEq(t, `{"data":{"broken":null},"errors":[{"message":"internal server error","locations":[{"line":1,"column":3}],"path":["broken"],"extensions":{"code":"INTERNAL_ERROR"}}]}`, string(msg.Payload))
+}
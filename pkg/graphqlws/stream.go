@@ -0,0 +1,93 @@
+package graphqlws
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+)
+
+// EventSubscriber - parte do EventBus usada pelas subscriptions
+type EventSubscriber interface {
+	Subscribe(eventType string, handler eventbus.EventHandler)
+	Unsubscribe(eventType string, handler eventbus.EventHandler)
+}
+
+// EventMapper - converte um evento no payload da subscription; false descarta o evento
+type EventMapper func(event eventbus.Event) (interface{}, bool)
+
+// streamHandler - handler inscrito no event bus durante a vida de uma subscription
+type streamHandler struct {
+	ctx    context.Context
+	mapper EventMapper
+	out    chan interface{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func (h *streamHandler) HandleEvent(_ context.Context, event eventbus.Event) error {
+	payload, ok := h.mapper(event)
+	if !ok {
+		return nil
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.closed {
+		return nil
+	}
+
+	select {
+	case h.out <- payload:
+	case <-h.ctx.Done():
+	}
+	return nil
+}
+
+// EventStream - inscreve-se nos tipos de evento e entrega os payloads no canal
+// retornado até o contexto ser cancelado, quando remove a inscrição e fecha o canal.
+// O canal é do tipo esperado por graphql.Field.Subscribe.
+func EventStream(ctx context.Context, bus EventSubscriber, eventTypes []string, mapper EventMapper) chan interface{} {
+	handler := &streamHandler{
+		ctx:    ctx,
+		mapper: mapper,
+		out:    make(chan interface{}, 16),
+	}
+
+	for _, eventType := range eventTypes {
+		bus.Subscribe(eventType, handler)
+	}
+
+	go func() {
+		<-ctx.Done()
+		for _, eventType := range eventTypes {
+			bus.Unsubscribe(eventType, handler)
+		}
+
+		// Aguarda handlers em andamento antes de fechar o canal
+		handler.mu.Lock()
+		handler.closed = true
+		close(handler.out)
+		handler.mu.Unlock()
+	}()
+
+	return handler.out
+}
+
+// EventUint - lê um ID numérico dos dados do evento, aceitando os tipos usados pelos módulos
+func EventUint(data map[string]interface{}, key string) uint {
+	switch v := data[key].(type) {
+	case uint:
+		return v
+	case uint64:
+		return uint(v)
+	case int:
+		return uint(v)
+	case int64:
+		return uint(v)
+	case float64:
+		return uint(v)
+	}
+	return 0
+}
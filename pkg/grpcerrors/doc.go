@@ -0,0 +1,34 @@
+// Package grpcerrors converte os erros de pkg/errors em status gRPC e de
+// volta, para que chamadas service-to-service preservem o código do erro.
+//
+// Este pacote fornece:
+//   - ToStatus: AppError/sentinels -> status com o código gRPC correspondente
+//   - FromStatus: status -> AppError, com o mesmo código e mensagem pública
+//   - Campos de validação (errors.Validation) no detalhe BadRequest
+//   - UnaryServerInterceptor: converte os erros dos handlers; internos
+//     recebem correlationId no log e, em produção, mensagem genérica
+//   - UnaryClientInterceptor: aplica FromStatus às chamadas do client
+//
+// # Códigos
+//
+//	NOT_FOUND       <-> codes.NotFound
+//	ALREADY_EXISTS  <-> codes.AlreadyExists
+//	INVALID_INPUT   <-> codes.InvalidArgument
+//	UNAUTHORIZED    <-> codes.Unauthenticated (PermissionDenied também volta como UNAUTHORIZED)
+//	INTERNAL_ERROR  <-> codes.Internal e demais códigos (Unavailable, DeadlineExceeded...)
+//
+// # Exemplo de Uso
+//
+//	server := grpc.NewServer(grpc.UnaryInterceptor(
+//		grpcerrors.UnaryServerInterceptor(grpcerrors.Config{
+//			Production: config.IsProduction(),
+//			Logger:     log,
+//		}),
+//	))
+//
+//	// No client
+//	_, err := client.GiveUserXP(ctx, req)
+//	if errors.Is(grpcerrors.FromStatus(err), errors.ErrNotFound) {
+//		...
+//	}
+package grpcerrors
@@ -0,0 +1,141 @@
+package grpcerrors
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+// InternalMessage - mensagem exibida no lugar de erros internos em produção
+const InternalMessage = "internal server error"
+
+// Config - configuração do interceptor do servidor
+type Config struct {
+	// Production oculta a mensagem de erros internos (fica só o correlationId)
+	Production bool
+	Logger     logger.Logger
+}
+
+// ToStatus - converte um erro de pkg/errors em status gRPC; validações levam
+// os campos em um detalhe BadRequest. Erros que já são status passam intactos
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := errors.Code(err)
+	st := status.New(grpcCode(code), errors.PublicMessage(err))
+	if code == errors.CodeInternal {
+		st = status.New(codes.Internal, err.Error())
+	}
+
+	if fields := errors.Fields(err); len(fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(fields))
+		for i, field := range fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: field.Field, Description: field.Message}
+		}
+		if detailed, detailErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); detailErr == nil {
+			st = detailed
+		}
+	}
+	return st.Err()
+}
+
+// FromStatus - converte um status gRPC de volta em AppError, para que o código
+// do cliente trate erros remotos como os locais (errors.Is(err, errors.ErrNotFound)).
+// Falhas de transporte (Unavailable, DeadlineExceeded...) viram erros internos
+func FromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return errors.Internal(err)
+	}
+
+	var appErr errors.AppError
+	switch st.Code() {
+	case codes.NotFound:
+		appErr = errors.AppError{Code: errors.CodeNotFound, Err: errors.ErrNotFound}
+	case codes.AlreadyExists:
+		appErr = errors.AppError{Code: errors.CodeAlreadyExists, Err: errors.ErrAlreadyExists}
+	case codes.InvalidArgument:
+		appErr = errors.AppError{Code: errors.CodeInvalidInput, Err: errors.ErrInvalidInput}
+	case codes.Unauthenticated, codes.PermissionDenied:
+		appErr = errors.AppError{Code: errors.CodeUnauthorized, Err: errors.ErrUnauthorized}
+	default:
+		return errors.Internal(err)
+	}
+	appErr.Message = st.Message()
+
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				appErr.Fields = append(appErr.Fields, errors.FieldError{
+					Field:   violation.GetField(),
+					Message: violation.GetDescription(),
+				})
+			}
+		}
+	}
+	return appErr
+}
+
+func grpcCode(code string) codes.Code {
+	switch code {
+	case errors.CodeNotFound:
+		return codes.NotFound
+	case errors.CodeAlreadyExists:
+		return codes.AlreadyExists
+	case errors.CodeInvalidInput:
+		return codes.InvalidArgument
+	case errors.CodeUnauthorized:
+		return codes.Unauthenticated
+	}
+	return codes.Internal
+}
+
+// UnaryServerInterceptor - aplica ToStatus aos erros dos handlers; erros
+// internos recebem um correlationId registrado no log e, em produção,
+// mensagem genérica
+func UnaryServerInterceptor(config Config) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if _, ok := status.FromError(err); ok || errors.Code(err) != errors.CodeInternal {
+			return resp, ToStatus(err)
+		}
+
+		correlationID := errors.NewCorrelationID()
+		if config.Logger != nil {
+			config.Logger.Error("grpc internal error",
+				zap.String("correlation_id", correlationID),
+				zap.String("method", info.FullMethod),
+				zap.Error(err))
+		}
+		message := err.Error()
+		if config.Production {
+			message = InternalMessage
+		}
+		return resp, status.Errorf(codes.Internal, "%s (correlationId: %s)", message, correlationID)
+	}
+}
+
+// UnaryClientInterceptor - aplica FromStatus aos erros das chamadas
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return FromStatus(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
@@ -0,0 +1,47 @@
+package grpcerrors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+)
+
+func TestToStatus_Codes(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{errors.NotFound("user", 7), codes.NotFound},
+		{errors.AlreadyExists("user", "email", "a@b.c"), codes.AlreadyExists},
+		{errors.InvalidInput("amount must be positive"), codes.InvalidArgument},
+		{errors.Unauthorized("token required"), codes.Unauthenticated},
+		{fmt.Errorf("lookup: %w", errors.ErrNotFound), codes.NotFound},
+		{fmt.Errorf("boom"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.code, status.Code(ToStatus(tt.err)), tt.err.Error())
+	}
+	assert.NoError(t, ToStatus(nil))
+}
+
+func TestFromStatus_RoundTrip(t *testing.T) {
+	original := errors.Validation(
+		errors.FieldError{Field: "amount", Message: "must be at least 1"},
+		errors.FieldError{Field: "source_type", Message: "is required"},
+	)
+
+	err := FromStatus(ToStatus(original))
+	assert.True(t, errors.Is(err, errors.ErrInvalidInput))
+	assert.Equal(t, "validation failed", errors.PublicMessage(err))
+	assert.Equal(t, errors.Fields(original), errors.Fields(err))
+
+	unavailable := FromStatus(status.Error(codes.Unavailable, "connection refused"))
+	assert.Equal(t, errors.CodeInternal, errors.Code(unavailable))
+	assert.Equal(t, codes.Unavailable, status.Code(unavailable))
+}
@@ -161,3 +161,40 @@ func (c *EventBusChecker) Check(ctx context.Context) *Check {
 
 	return check
 }
+
+// Pinger - dependência verificável por ping (ex.: cache.Redis)
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// CacheChecker - sem o cache as leituras caem no banco, então falha é degraded
+type CacheChecker struct {
+	pinger Pinger
+}
+
+func NewCacheChecker(pinger Pinger) *CacheChecker {
+	return &CacheChecker{pinger: pinger}
+}
+
+func (c *CacheChecker) Check(ctx context.Context) *Check {
+	start := time.Now()
+	check := &Check{
+		Name:      "cache",
+		Timestamp: start,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	if err := c.pinger.Ping(ctx); err != nil {
+		check.Status = StatusDegraded
+		check.Message = "cache ping failed: " + err.Error()
+		check.Duration = time.Since(start)
+		return check
+	}
+
+	check.Status = StatusHealthy
+	check.Message = "cache is healthy"
+	check.Duration = time.Since(start)
+	return check
+}
@@ -0,0 +1,36 @@
+// Package module define o contrato dos módulos de negócio e o registry
+// usado para montar a aplicação a partir deles.
+//
+// Este pacote fornece:
+//   - Interface Module: tipos, queries, mutations e subscriptions GraphQL,
+//     modelos, handlers de eventos, rotas HTTP e contexto por request
+//   - Base com implementações vazias para os métodos opcionais
+//   - Registry com Register/Build e resolução de dependências (DependsOn)
+//   - Deps com as dependências compartilhadas entregues às factories
+//
+// # Registro
+//
+// Cada módulo se registra no próprio init.go; a aplicação só precisa
+// importar o pacote:
+//
+//	func init() {
+//		module.Register(module.Definition{
+//			Name:      "challenges",
+//			DependsOn: []string{"users"},
+//			New:       NewModule,
+//		})
+//	}
+//
+// # Construção
+//
+// Build chama as factories na ordem das dependências. Um módulo acessa o
+// service de uma dependência com deps.Service(name). Dependências ausentes,
+// ciclos e nomes divergentes resultam em erro.
+//
+//	modules, err := module.Build(&module.Deps{DB: db, Logger: log, EventBus: bus})
+//
+// # Thread Safety
+//
+// Register e Build são seguros para uso concorrente, mas normalmente só
+// são chamados na inicialização.
+package module
@@ -0,0 +1,121 @@
+package module
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+
+	"github.com/rafaelcoelhox/labbend/pkg/cache"
+	"github.com/rafaelcoelhox/labbend/pkg/complexity"
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+	"github.com/rafaelcoelhox/labbend/pkg/dataloader"
+	"github.com/rafaelcoelhox/labbend/pkg/eventbus"
+	"github.com/rafaelcoelhox/labbend/pkg/graphqlws"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+	"github.com/rafaelcoelhox/labbend/pkg/rest"
+	"github.com/rafaelcoelhox/labbend/pkg/saga"
+)
+
+// Module - tudo que um módulo expõe para a aplicação; embuta Base e
+// sobrescreva apenas o que o módulo usa
+type Module interface {
+	// Name - nome único do módulo (o mesmo da Definition)
+	Name() string
+	// Service - service público, consultado por módulos dependentes via Deps.Service
+	Service() interface{}
+
+	// Types - tipos GraphQL extras que não são alcançáveis pelos campos (ex.: implementações de interfaces)
+	Types() []graphql.Type
+	Queries() graphql.Fields
+	Mutations() graphql.Fields
+	Subscriptions(bus graphqlws.EventSubscriber) graphql.Fields
+	// Costs - custos dos campos do módulo para a análise de complexidade ("Tipo.campo")
+	Costs() complexity.Costs
+
+	// Models - modelos GORM do módulo; o schema vem das migrações (Definition.Migrations)
+	Models() []interface{}
+	// EventSubscriptions - handlers inscritos no event bus na inicialização
+	EventSubscriptions() []EventSubscription
+	// Jobs - tarefas periódicas executadas enquanto a aplicação estiver no ar
+	Jobs() []Job
+	// Routes - rotas HTTP avulsas do módulo (ex.: webhooks), fora da especificação OpenAPI
+	Routes(router gin.IRouter)
+	// RESTRoutes - endpoints REST em /api/v1, documentados no OpenAPI
+	RESTRoutes() []rest.Route
+	// WithRequestContext - prepara o contexto de cada request GraphQL (ex.: dataloaders)
+	WithRequestContext(ctx context.Context) context.Context
+}
+
+// EventSubscription - handler de um tipo de evento
+type EventSubscription struct {
+	EventType string
+	Handler   eventbus.EventHandler
+}
+
+// Job - tarefa periódica de um módulo (ex.: reconciliação); Interval <= 0
+// desativa o job. Erros são registrados e a próxima execução segue no intervalo
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Deps - dependências compartilhadas entregues às factories dos módulos
+type Deps struct {
+	DB           *gorm.DB
+	Logger       logger.Logger
+	EventBus     *eventbus.EventBus
+	TxManager    *database.TxManager
+	SagaManager  *saga.SagaManager
+	LoaderConfig dataloader.Config
+	// CacheStore - cache compartilhado (memória ou Redis); nil desativa o cache
+	CacheStore   cache.Cache
+	CacheMetrics *cache.Metrics
+	// Config - configuração da aplicação; cada módulo declara a interface que espera dela
+	Config interface{}
+	// Remote - services de módulos acessados remotamente (ex.: clientes gRPC);
+	// têm precedência sobre os services construídos localmente
+	Remote map[string]interface{}
+
+	services map[string]interface{}
+}
+
+// Service - service de um módulo já construído (declare-o em DependsOn) ou
+// o service remoto registrado com o mesmo nome
+func (d *Deps) Service(name string) interface{} {
+	if remote, ok := d.Remote[name]; ok {
+		return remote
+	}
+	return d.services[name]
+}
+
+// Cache - visão do cache compartilhado para o módulo, com as chaves prefixadas
+// por name e métricas rotuladas cache=name; nil quando o cache está desativado
+func (d *Deps) Cache(name string) cache.Cache {
+	if d.CacheStore == nil {
+		return nil
+	}
+	c := cache.Prefix(d.CacheStore, name+":")
+	if d.CacheMetrics != nil {
+		c = cache.Instrument(c, d.CacheMetrics, name)
+	}
+	return c
+}
+
+// Base - implementação vazia dos métodos opcionais de Module
+type Base struct{}
+
+func (Base) Types() []graphql.Type                                      { return nil }
+func (Base) Queries() graphql.Fields                                    { return nil }
+func (Base) Mutations() graphql.Fields                                  { return nil }
+func (Base) Subscriptions(bus graphqlws.EventSubscriber) graphql.Fields { return nil }
+func (Base) Costs() complexity.Costs                                    { return nil }
+func (Base) Models() []interface{}                                      { return nil }
+func (Base) EventSubscriptions() []EventSubscription                    { return nil }
+func (Base) Jobs() []Job                                                { return nil }
+func (Base) Routes(router gin.IRouter)                                  {}
+func (Base) RESTRoutes() []rest.Route                                   { return nil }
+func (Base) WithRequestContext(ctx context.Context) context.Context     { return ctx }
@@ -0,0 +1,151 @@
+package module
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"sync"
+
+	"github.com/rafaelcoelhox/labbend/pkg/database"
+)
+
+// Factory - constrói o módulo a partir das dependências compartilhadas
+type Factory func(deps *Deps) (Module, error)
+
+// Definition - registro de um módulo, feito no init.go do pacote
+type Definition struct {
+	Name string
+	// DependsOn - módulos que precisam ser construídos antes (ex.: services consumidos)
+	DependsOn []string
+	New       Factory
+	// Migrations - migrações SQL do módulo ("<versão>_<nome>.up.sql"/".down.sql"),
+	// normalmente um embed.FS de migrations/*.sql
+	Migrations fs.FS
+	// Models - modelos GORM do módulo; Register os inclui em database.RegisterModel
+	// (AutoMigrate no SQLite e databasetest)
+	Models []interface{}
+}
+
+// Registry - conjunto de definições de módulos
+type Registry struct {
+	definitions []Definition
+	mutex       sync.RWMutex
+}
+
+// NewRegistry - cria um registry vazio (a aplicação usa o registry global)
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+var defaultRegistry = NewRegistry()
+
+// Register - registra um módulo no registry global e seus modelos no registro
+// de modelos do database; chamado no init.go do módulo
+func Register(def Definition) {
+	defaultRegistry.Register(def)
+	for _, model := range def.Models {
+		database.RegisterModel(model)
+	}
+}
+
+// Build - constrói os módulos do registry global
+func Build(deps *Deps) ([]Module, error) {
+	return defaultRegistry.Build(deps)
+}
+
+// Migrations - migrações de todos os módulos do registry global
+func Migrations() ([]database.Migration, error) {
+	return defaultRegistry.Migrations()
+}
+
+// Register - registra uma definição; nomes duplicados são erro de programação
+func (r *Registry) Register(def Definition) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.definitions {
+		if existing.Name == def.Name {
+			panic(fmt.Sprintf("module %q registered twice", def.Name))
+		}
+	}
+	r.definitions = append(r.definitions, def)
+}
+
+// Migrations - migrações de todos os módulos registrados, em ordem de versão;
+// não exige construir os módulos (usado por cmd/migrate)
+func (r *Registry) Migrations() ([]database.Migration, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var migrations []database.Migration
+	for _, def := range r.definitions {
+		loaded, err := database.LoadMigrations(def.Name, def.Migrations)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, loaded...)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Build - constrói os módulos respeitando DependsOn; a ordem retornada é a ordem de construção
+func (r *Registry) Build(deps *Deps) ([]Module, error) {
+	r.mutex.RLock()
+	definitions := make(map[string]Definition, len(r.definitions))
+	names := make([]string, 0, len(r.definitions))
+	for _, def := range r.definitions {
+		definitions[def.Name] = def
+		names = append(names, def.Name)
+	}
+	r.mutex.RUnlock()
+
+	deps.services = make(map[string]interface{}, len(names))
+
+	var modules []Module
+	state := make(map[string]int) // 1 = construindo, 2 = pronto
+
+	var build func(name string, path []string) error
+	build = func(name string, path []string) error {
+		switch state[name] {
+		case 2:
+			return nil
+		case 1:
+			return fmt.Errorf("module dependency cycle: %v", append(path, name))
+		}
+
+		def, ok := definitions[name]
+		if !ok {
+			return fmt.Errorf("module %q depends on unregistered module %q", path[len(path)-1], name)
+		}
+
+		state[name] = 1
+		for _, dep := range def.DependsOn {
+			if err := build(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+
+		m, err := def.New(deps)
+		if err != nil {
+			return fmt.Errorf("failed to build module %q: %w", name, err)
+		}
+		if m.Name() != name {
+			return fmt.Errorf("module registered as %q reports name %q", name, m.Name())
+		}
+
+		deps.services[name] = m.Service()
+		modules = append(modules, m)
+		state[name] = 2
+		return nil
+	}
+
+	for _, name := range names {
+		if err := build(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return modules, nil
+}
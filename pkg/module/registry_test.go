@@ -0,0 +1,142 @@
+package module_test
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/module"
+)
+
+// namedModule - módulo que expõe o nome como service
+type namedModule struct {
+	module.Base
+	name string
+}
+
+func (m *namedModule) Name() string         { return m.name }
+func (m *namedModule) Service() interface{} { return m.name + "-service" }
+
+func definition(name string, dependsOn ...string) module.Definition {
+	return module.Definition{
+		Name:      name,
+		DependsOn: dependsOn,
+		New: func(deps *module.Deps) (module.Module, error) {
+			// Dependências já estão construídas quando a factory roda
+			for _, dep := range dependsOn {
+				if deps.Service(dep) == nil {
+					return nil, errors.New("dependency not built: " + dep)
+				}
+			}
+			return &namedModule{name: name}, nil
+		},
+	}
+}
+
+func names(modules []module.Module) []string {
+	result := make([]string, len(modules))
+	for i, m := range modules {
+		result[i] = m.Name()
+	}
+	return result
+}
+
+func TestRegistry_BuildsInDependencyOrder(t *testing.T) {
+	registry := module.NewRegistry()
+	registry.Register(definition("moderation", "challenges"))
+	registry.Register(definition("challenges", "users"))
+	registry.Register(definition("users"))
+
+	modules, err := registry.Build(&module.Deps{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users", "challenges", "moderation"}, names(modules))
+}
+
+func TestRegistry_BuildErrors(t *testing.T) {
+	t.Run("dependência ausente", func(t *testing.T) {
+		registry := module.NewRegistry()
+		registry.Register(definition("challenges", "users"))
+
+		_, err := registry.Build(&module.Deps{})
+		assert.EqualError(t, err, `module "challenges" depends on unregistered module "users"`)
+	})
+
+	t.Run("ciclo", func(t *testing.T) {
+		registry := module.NewRegistry()
+		registry.Register(definition("a", "b"))
+		registry.Register(definition("b", "a"))
+
+		_, err := registry.Build(&module.Deps{})
+		assert.EqualError(t, err, "module dependency cycle: [a b a]")
+	})
+
+	t.Run("nome divergente", func(t *testing.T) {
+		registry := module.NewRegistry()
+		registry.Register(module.Definition{
+			Name: "users",
+			New: func(deps *module.Deps) (module.Module, error) {
+				return &namedModule{name: "accounts"}, nil
+			},
+		})
+
+		_, err := registry.Build(&module.Deps{})
+		assert.EqualError(t, err, `module registered as "users" reports name "accounts"`)
+	})
+}
+
+func TestRegistry_RegisterTwicePanics(t *testing.T) {
+	registry := module.NewRegistry()
+	registry.Register(definition("users"))
+	assert.Panics(t, func() { registry.Register(definition("users")) })
+}
+
+func TestDeps_RemoteServiceTakesPrecedence(t *testing.T) {
+	registry := module.NewRegistry()
+	registry.Register(definition("users"))
+
+	var seen interface{}
+	registry.Register(module.Definition{
+		Name:      "challenges",
+		DependsOn: []string{"users"},
+		New: func(deps *module.Deps) (module.Module, error) {
+			seen = deps.Service("users")
+			return &namedModule{name: "challenges"}, nil
+		},
+	})
+
+	_, err := registry.Build(&module.Deps{Remote: map[string]interface{}{"users": "users-grpc-client"}})
+	require.NoError(t, err)
+	assert.Equal(t, "users-grpc-client", seen)
+}
+
+func TestRegistry_Migrations(t *testing.T) {
+	registry := module.NewRegistry()
+	registry.Register(module.Definition{
+		Name: "users",
+		New:  definition("users").New,
+		Migrations: fstest.MapFS{
+			"migrations/20250101000000_create_users.up.sql": {Data: []byte("CREATE TABLE users ();")},
+		},
+	})
+	registry.Register(module.Definition{
+		Name: "challenges",
+		New:  definition("challenges").New,
+		Migrations: fstest.MapFS{
+			"migrations/20241231000000_create_tags.up.sql":       {Data: []byte("CREATE TABLE tags ();")},
+			"migrations/20250102000000_create_challenges.up.sql": {Data: []byte("CREATE TABLE challenges ();")},
+		},
+	})
+	registry.Register(definition("moderation"))
+
+	migrations, err := registry.Migrations()
+	require.NoError(t, err)
+
+	var order []string
+	for _, migration := range migrations {
+		order = append(order, migration.Source+"/"+migration.Name)
+	}
+	assert.Equal(t, []string{"challenges/create_tags", "users/create_users", "challenges/create_challenges"}, order)
+}
@@ -0,0 +1,77 @@
+package pagination
+
+// PageInfo - metadados Relay da página retornada
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
+// Edge - registro e o cursor que aponta para ele
+type Edge[T any] struct {
+	Node   T      `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
+// Connection - página de resultados no formato Relay
+type Connection[T any] struct {
+	Edges    []Edge[T] `json:"edges"`
+	PageInfo PageInfo  `json:"pageInfo"`
+}
+
+// NewConnection - monta a Connection a partir das linhas buscadas com Keyset.Apply
+// (até Limit+1 linhas, na ordem invertida quando page.Backward)
+func NewConnection[T any](rows []T, page Page, cursor func(T) Cursor) *Connection[T] {
+	hasMore := len(rows) > page.Limit
+	if hasMore {
+		rows = rows[:page.Limit]
+	}
+
+	if page.Backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	conn := &Connection[T]{Edges: make([]Edge[T], len(rows))}
+	for i, row := range rows {
+		conn.Edges[i] = Edge[T]{Node: row, Cursor: cursor(row).Encode()}
+	}
+
+	if page.Backward {
+		conn.PageInfo.HasPreviousPage = hasMore
+		conn.PageInfo.HasNextPage = page.Before != nil
+	} else {
+		conn.PageInfo.HasNextPage = hasMore
+		conn.PageInfo.HasPreviousPage = page.After != nil
+	}
+
+	if len(conn.Edges) > 0 {
+		start, end := conn.Edges[0].Cursor, conn.Edges[len(conn.Edges)-1].Cursor
+		conn.PageInfo.StartCursor = &start
+		conn.PageInfo.EndCursor = &end
+	}
+	return conn
+}
+
+// Nodes - registros da página, na ordem das edges
+func (c *Connection[T]) Nodes() []T {
+	nodes := make([]T, len(c.Edges))
+	for i, edge := range c.Edges {
+		nodes[i] = edge.Node
+	}
+	return nodes
+}
+
+// Map - converte os nodes mantendo cursores e PageInfo (ex.: entidade -> formato GraphQL)
+func Map[T, U any](c *Connection[T], fn func(T) U) *Connection[U] {
+	mapped := &Connection[U]{
+		Edges:    make([]Edge[U], len(c.Edges)),
+		PageInfo: c.PageInfo,
+	}
+	for i, edge := range c.Edges {
+		mapped.Edges[i] = Edge[U]{Node: fn(edge.Node), Cursor: edge.Cursor}
+	}
+	return mapped
+}
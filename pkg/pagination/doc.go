@@ -0,0 +1,42 @@
+// Package pagination implementa paginação por cursor no estilo Relay, baseada em
+// keyset (seek) em vez de limit/offset.
+//
+// Este pacote fornece:
+//   - Cursores opacos (base64) com o valor da coluna ordenada + ID de desempate
+//   - Args/Page para validar first/after/last/before com limite máximo
+//   - Keyset para aplicar filtro, ordenação estável e limite em queries GORM
+//   - Connection/Edge/PageInfo genéricos para os resultados
+//   - Tipos GraphQL <Nome>Connection/<Nome>Edge gerados por entidade
+//
+// # Por que keyset
+//
+// Com limit/offset, inserções concorrentes deslocam as páginas (itens repetidos
+// ou pulados) e páginas profundas exigem que o banco percorra todas as linhas
+// anteriores. Com keyset a próxima página é buscada por
+// (coluna, id) < (valor_do_cursor, id_do_cursor), usando o índice da coluna.
+//
+// # Exemplo de Uso
+//
+//	var userKeyset = pagination.Keyset{
+//		Name:       "users.created_at",
+//		Column:     "users.created_at",
+//		IDColumn:   "users.id",
+//		Kind:       pagination.KindTime,
+//		Descending: true,
+//	}
+//
+//	// Repository: busca até page.Limit+1 linhas
+//	query, err := userKeyset.Apply(db.Model(&User{}), page)
+//
+//	// Service: monta a Connection
+//	page, err := args.Page()
+//	conn := pagination.NewConnection(users, page, func(u *User) pagination.Cursor {
+//		return userKeyset.Cursor(u.CreatedAt, u.ID)
+//	})
+//
+//	// GraphQL
+//	"users": &graphql.Field{
+//		Type: pagination.NewConnectionType("User", UserType),
+//		Args: pagination.ConnectionArgs(nil),
+//	}
+package pagination
@@ -0,0 +1,75 @@
+package pagination
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+// PageInfoType - tipo PageInfo compartilhado por todas as connections do schema
+var PageInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "PageInfo",
+	Fields: graphql.Fields{
+		"hasNextPage": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Boolean),
+		},
+		"hasPreviousPage": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Boolean),
+		},
+		"startCursor": &graphql.Field{
+			Type: graphql.String,
+		},
+		"endCursor": &graphql.Field{
+			Type: graphql.String,
+		},
+	},
+})
+
+// NewConnectionType - gera os tipos <Name>Edge e <Name>Connection para o node informado
+func NewConnectionType(name string, node graphql.Output) *graphql.Object {
+	edge := graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Edge",
+		Fields: graphql.Fields{
+			"node": &graphql.Field{
+				Type: node,
+			},
+			"cursor": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+	})
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Connection",
+		Fields: graphql.Fields{
+			"edges": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edge))),
+			},
+			"pageInfo": &graphql.Field{
+				Type: graphql.NewNonNull(PageInfoType),
+			},
+		},
+	})
+}
+
+// ConnectionArgs - argumentos first/after/last/before somados aos argumentos do campo
+func ConnectionArgs(extra graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: "Quantidade de itens após o cursor after (máximo 100)",
+		},
+		"after": &graphql.ArgumentConfig{
+			Type: graphql.String,
+		},
+		"last": &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: "Quantidade de itens antes do cursor before (máximo 100)",
+		},
+		"before": &graphql.ArgumentConfig{
+			Type: graphql.String,
+		},
+	}
+	for name, arg := range extra {
+		args[name] = arg
+	}
+	return args
+}
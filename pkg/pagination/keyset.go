@@ -0,0 +1,112 @@
+package pagination
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+)
+
+// Kind - tipo do valor da coluna de ordenação, usado para converter o cursor de volta
+type Kind int
+
+const (
+	KindTime Kind = iota
+	KindInt
+	KindFloat
+	KindString
+)
+
+// Keyset - ordenação estável usada para paginar por cursor (coluna + ID como desempate)
+type Keyset struct {
+	// Name - identifica a ordenação; cursores de outra ordenação são recusados
+	Name string
+	// Column - coluna ou expressão SQL ordenada (pode conter placeholders)
+	Column string
+	// Vars - argumentos dos placeholders de Column
+	Vars []interface{}
+	// IDColumn - coluna de desempate, única por registro
+	IDColumn string
+	Kind     Kind
+	// Descending - direção natural da listagem
+	Descending bool
+}
+
+// Cursor - cria o cursor de um registro a partir do valor ordenado e do ID
+func (k Keyset) Cursor(value interface{}, id uint) Cursor {
+	return Cursor{Key: k.Name, Value: formatValue(value), ID: id}
+}
+
+// Apply - aplica filtro de cursor, ordenação e limite (Limit+1 para detectar a próxima página)
+func (k Keyset) Apply(query *gorm.DB, page Page) (*gorm.DB, error) {
+	row := fmt.Sprintf("(%s, %s)", k.Column, k.IDColumn)
+
+	// "Depois" do cursor, na direção natural da listagem
+	afterOp, beforeOp := ">", "<"
+	if k.Descending {
+		afterOp, beforeOp = "<", ">"
+	}
+
+	if page.After != nil {
+		condition, err := k.condition(row, afterOp, page.After)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition.SQL, condition.Vars...)
+	}
+	if page.Before != nil {
+		condition, err := k.condition(row, beforeOp, page.Before)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition.SQL, condition.Vars...)
+	}
+
+	// Paginando para trás a ordem é invertida; NewConnection restaura a ordem natural
+	descending := k.Descending != page.Backward
+	direction := "ASC"
+	if descending {
+		direction = "DESC"
+	}
+
+	return query.
+		Clauses(clause.OrderBy{
+			Expression: clause.Expr{
+				SQL:                k.Column + " " + direction + ", " + k.IDColumn + " " + direction,
+				Vars:               k.Vars,
+				WithoutParentheses: true,
+			},
+		}).
+		Limit(page.Limit + 1), nil
+}
+
+func (k Keyset) condition(row, op string, cursor *Cursor) (clause.Expr, error) {
+	if cursor.Key != k.Name {
+		return clause.Expr{}, errors.InvalidInput("cursor does not match the requested ordering")
+	}
+
+	value, err := k.parse(cursor.Value)
+	if err != nil {
+		return clause.Expr{}, errors.InvalidInput("invalid cursor")
+	}
+
+	vars := append(append([]interface{}{}, k.Vars...), value, cursor.ID)
+	return clause.Expr{SQL: row + " " + op + " (?, ?)", Vars: vars}, nil
+}
+
+func (k Keyset) parse(value string) (interface{}, error) {
+	switch k.Kind {
+	case KindTime:
+		return time.Parse(time.RFC3339Nano, value)
+	case KindInt:
+		return strconv.ParseInt(value, 10, 64)
+	case KindFloat:
+		return strconv.ParseFloat(value, 64)
+	default:
+		return value, nil
+	}
+}
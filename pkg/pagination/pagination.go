@@ -0,0 +1,137 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+)
+
+const (
+	// DefaultLimit - tamanho de página quando first/last não são informados
+	DefaultLimit = 10
+	// MaxLimit - maior página aceita; valores acima são reduzidos
+	MaxLimit = 100
+)
+
+// Cursor - posição de um registro na ordenação: valor da coluna ordenada + ID para desempate
+type Cursor struct {
+	Key   string `json:"k"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// Encode - serializa o cursor em uma string opaca para o cliente
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor - reverte Encode; cursores adulterados resultam em InvalidInput
+func DecodeCursor(encoded string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.InvalidInput("invalid cursor")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return nil, errors.InvalidInput("invalid cursor")
+	}
+	return &cursor, nil
+}
+
+// formatValue - representação textual estável do valor de ordenação
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Args - argumentos Relay (first/after para frente, last/before para trás)
+type Args struct {
+	First  *int
+	After  string
+	Last   *int
+	Before string
+}
+
+// ArgsFromMap - extrai first/after/last/before dos argumentos de um campo GraphQL
+func ArgsFromMap(args map[string]interface{}) Args {
+	var a Args
+	if first, ok := args["first"].(int); ok {
+		a.First = &first
+	}
+	if last, ok := args["last"].(int); ok {
+		a.Last = &last
+	}
+	if after, ok := args["after"].(string); ok {
+		a.After = after
+	}
+	if before, ok := args["before"].(string); ok {
+		a.Before = before
+	}
+	return a
+}
+
+// Page - Args validado, com limite aplicado e cursores decodificados
+type Page struct {
+	Limit    int
+	After    *Cursor
+	Before   *Cursor
+	Backward bool
+}
+
+// Page - valida os argumentos e aplica DefaultLimit/MaxLimit
+func (a Args) Page() (Page, error) {
+	if a.First != nil && a.Last != nil {
+		return Page{}, errors.InvalidInput("first and last cannot be used together")
+	}
+
+	page := Page{Limit: DefaultLimit}
+	switch {
+	case a.First != nil:
+		if *a.First < 0 {
+			return Page{}, errors.InvalidInput("first cannot be negative")
+		}
+		page.Limit = *a.First
+	case a.Last != nil:
+		if *a.Last < 0 {
+			return Page{}, errors.InvalidInput("last cannot be negative")
+		}
+		page.Limit = *a.Last
+		page.Backward = true
+	case a.Before != "" && a.After == "":
+		// Apenas before: pagina para trás a partir do cursor
+		page.Backward = true
+	}
+	if page.Limit > MaxLimit {
+		page.Limit = MaxLimit
+	}
+
+	if a.After != "" {
+		cursor, err := DecodeCursor(a.After)
+		if err != nil {
+			return Page{}, err
+		}
+		page.After = cursor
+	}
+	if a.Before != "" {
+		cursor, err := DecodeCursor(a.Before)
+		if err != nil {
+			return Page{}, err
+		}
+		page.Before = cursor
+	}
+	return page, nil
+}
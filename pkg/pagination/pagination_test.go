@@ -0,0 +1,151 @@
+package pagination_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
+)
+
+type item struct {
+	ID        uint
+	CreatedAt time.Time
+}
+
+var itemKeyset = pagination.Keyset{
+	Name:       "items.created_at",
+	Column:     "items.created_at",
+	IDColumn:   "items.id",
+	Kind:       pagination.KindTime,
+	Descending: true,
+}
+
+func itemCursor(i *item) pagination.Cursor {
+	return itemKeyset.Cursor(i.CreatedAt, i.ID)
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestCursor_RoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 12, 30, 0, 123456000, time.UTC)
+	encoded := itemKeyset.Cursor(createdAt, 42).Encode()
+
+	cursor, err := pagination.DecodeCursor(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "items.created_at", cursor.Key)
+	assert.Equal(t, "2024-03-01T12:30:00.123456Z", cursor.Value)
+	assert.Equal(t, uint(42), cursor.ID)
+
+	_, err = pagination.DecodeCursor("não-é-um-cursor")
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+}
+
+func TestArgs_Page(t *testing.T) {
+	page, err := pagination.Args{}.Page()
+	require.NoError(t, err)
+	assert.Equal(t, pagination.DefaultLimit, page.Limit)
+	assert.False(t, page.Backward)
+
+	page, err = pagination.Args{First: intPtr(500)}.Page()
+	require.NoError(t, err)
+	assert.Equal(t, pagination.MaxLimit, page.Limit)
+
+	page, err = pagination.Args{Last: intPtr(5)}.Page()
+	require.NoError(t, err)
+	assert.Equal(t, 5, page.Limit)
+	assert.True(t, page.Backward)
+
+	_, err = pagination.Args{First: intPtr(1), Last: intPtr(1)}.Page()
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+
+	_, err = pagination.Args{First: intPtr(-1)}.Page()
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+
+	_, err = pagination.Args{Before: "%%%"}.Page()
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+}
+
+func TestNewConnection(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := func(ids ...uint) []*item {
+		items := make([]*item, len(ids))
+		for i, id := range ids {
+			items[i] = &item{ID: id, CreatedAt: base.Add(time.Duration(id) * time.Minute)}
+		}
+		return items
+	}
+
+	t.Run("para frente com próxima página", func(t *testing.T) {
+		page, _ := pagination.Args{First: intPtr(2)}.Page()
+		conn := pagination.NewConnection(rows(5, 4, 3), page, itemCursor)
+
+		require.Len(t, conn.Edges, 2)
+		assert.Equal(t, uint(5), conn.Edges[0].Node.ID)
+		assert.True(t, conn.PageInfo.HasNextPage)
+		assert.False(t, conn.PageInfo.HasPreviousPage)
+		assert.Equal(t, conn.Edges[1].Cursor, *conn.PageInfo.EndCursor)
+	})
+
+	t.Run("para trás restaura a ordem natural", func(t *testing.T) {
+		before := itemKeyset.Cursor(base, 1).Encode()
+		page, _ := pagination.Args{Last: intPtr(2), Before: before}.Page()
+		// Keyset.Apply inverte a ordem ao paginar para trás
+		conn := pagination.NewConnection(rows(2, 3, 4), page, itemCursor)
+
+		require.Len(t, conn.Edges, 2)
+		assert.Equal(t, []uint{3, 2}, []uint{conn.Edges[0].Node.ID, conn.Edges[1].Node.ID})
+		assert.True(t, conn.PageInfo.HasPreviousPage)
+		assert.True(t, conn.PageInfo.HasNextPage)
+	})
+
+	t.Run("página vazia", func(t *testing.T) {
+		page, _ := pagination.Args{}.Page()
+		conn := pagination.NewConnection([]*item{}, page, itemCursor)
+
+		assert.Empty(t, conn.Edges)
+		assert.Nil(t, conn.PageInfo.StartCursor)
+		assert.False(t, conn.PageInfo.HasNextPage)
+	})
+}
+
+func TestKeyset_Apply(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	after := itemKeyset.Cursor(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 7).Encode()
+	page, err := pagination.Args{First: intPtr(20), After: after}.Page()
+	require.NoError(t, err)
+
+	query, err := itemKeyset.Apply(db.Table("items"), page)
+	require.NoError(t, err)
+	stmt := query.Find(&[]item{}).Statement
+
+	assert.Equal(t,
+		`SELECT * FROM "items" WHERE (items.created_at, items.id) < ($1, $2) ORDER BY items.created_at DESC, items.id DESC LIMIT 21`,
+		stmt.SQL.String())
+	assert.Equal(t, uint(7), stmt.Vars[1])
+
+	// Paginando para trás a comparação e a ordem são invertidas
+	page, err = pagination.Args{Last: intPtr(5), Before: after}.Page()
+	require.NoError(t, err)
+
+	query, err = itemKeyset.Apply(db.Table("items"), page)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`SELECT * FROM "items" WHERE (items.created_at, items.id) > ($1, $2) ORDER BY items.created_at ASC, items.id ASC LIMIT 6`,
+		query.Find(&[]item{}).Statement.SQL.String())
+
+	// Cursor de outra ordenação é recusado
+	other := pagination.Keyset{Name: "items.title", Column: "items.title", IDColumn: "items.id", Kind: pagination.KindString}
+	_, err = other.Apply(db.Table("items"), page)
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+}
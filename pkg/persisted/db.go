@@ -0,0 +1,58 @@
+package persisted
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Query - query persistida na tabela persisted_queries
+type Query struct {
+	Hash      string    `gorm:"primaryKey;size:64" json:"hash"`
+	Query     string    `gorm:"type:text;not null" json:"query"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TableName - nome da tabela
+func (Query) TableName() string {
+	return "persisted_queries"
+}
+
+// DBStore - store na tabela persisted_queries (compartilhado entre instâncias e usado pela CLI)
+type DBStore struct {
+	db *gorm.DB
+}
+
+// NewDBStore - cria o store; a tabela é migrada com o modelo Query
+func NewDBStore(db *gorm.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// Get - busca a query pelo hash
+func (s *DBStore) Get(ctx context.Context, hash string) (string, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var query Query
+	err := s.db.WithContext(ctx).Where("hash = ?", hash).First(&query).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return query.Query, true, nil
+}
+
+// Put - registra a query; hashes já existentes são mantidos
+func (s *DBStore) Put(ctx context.Context, hash, query string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&Query{Hash: hash, Query: query}).Error
+}
@@ -0,0 +1,37 @@
+// Package persisted implementa persisted queries para o endpoint GraphQL:
+// Automatic Persisted Queries (APQ) e allowlist de queries pré-registradas.
+//
+// Este pacote fornece:
+//   - Manager com os modos ModeAPQ e ModeAllowlist
+//   - Manager.Handler: middleware HTTP que troca o hash pelo texto da query
+//   - Store plugável: MemoryStore (LRU), DBStore (tabela persisted_queries)
+//     e CachedStore (LRU na frente de outro store)
+//   - ReadDir/Register para carregar arquivos .graphql (usados pela CLI
+//     cmd/persist-queries)
+//
+// # Protocolo APQ
+//
+// O cliente envia apenas o hash em extensions.persistedQuery:
+//
+//	{"extensions": {"persistedQuery": {"version": 1, "sha256Hash": "<sha256 do texto>"}}}
+//
+// Se o hash for desconhecido a resposta traz PersistedQueryNotFound e o
+// cliente reenvia hash + texto, que é conferido e registrado. Daí em diante
+// basta o hash.
+//
+// # Allowlist
+//
+// Em ModeAllowlist nada é registrado pela API: apenas hashes gravados pela
+// CLI podem ser executados (por hash ou pelo texto idêntico). Qualquer outra
+// operação recebe PERSISTED_QUERY_NOT_IN_LIST.
+//
+// # Exemplo de Uso
+//
+//	store := persisted.NewCachedStore(persisted.NewDBStore(db), 1000)
+//	manager := persisted.NewManager(store, persisted.ModeAllowlist)
+//	http.Handle("/graphql", manager.Handler(graphqlHandler))
+//
+// # Thread Safety
+//
+// Manager e todos os stores são seguros para uso concorrente.
+package persisted
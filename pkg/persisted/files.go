@@ -0,0 +1,63 @@
+package persisted
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Entry - query lida de um arquivo .graphql
+type Entry struct {
+	File  string `json:"file"`
+	Hash  string `json:"hash"`
+	Query string `json:"-"`
+}
+
+// ReadDir - lê recursivamente os arquivos .graphql de dir (um documento por arquivo).
+// O hash é calculado sobre o conteúdo sem espaços nas pontas: os clientes
+// devem enviar exatamente esse texto.
+func ReadDir(dir string) ([]Entry, error) {
+	var entries []Entry
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".graphql" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		query := strings.TrimSpace(string(content))
+		if query == "" {
+			return fmt.Errorf("%s: empty query", path)
+		}
+
+		rel, _ := filepath.Rel(dir, path)
+		entries = append(entries, Entry{File: filepath.ToSlash(rel), Hash: Hash(query), Query: query})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].File < entries[j].File })
+	return entries, nil
+}
+
+// Register - grava as entradas no store
+func Register(ctx context.Context, store Store, entries []Entry) error {
+	for _, entry := range entries {
+		if err := store.Put(ctx, entry.Hash, entry.Query); err != nil {
+			return fmt.Errorf("failed to register %s: %w", entry.File, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package persisted
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+)
+
+// request - campos da request GraphQL relevantes para persisted queries
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	Extensions    struct {
+		PersistedQuery *Extension `json:"persistedQuery,omitempty"`
+	} `json:"extensions"`
+}
+
+// Handler - middleware que resolve extensions.persistedQuery antes de next.
+// Aceita GET (parâmetros query/extensions) e POST application/json; a request
+// repassada a next sempre carrega o texto completo da query.
+func (m *Manager) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, body, ok := readRequest(r)
+		if !ok {
+			// Outros formatos (application/graphql, form) não suportam APQ
+			if m.mode == ModeAllowlist {
+				writeError(w, ErrNotInList)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		// GET sem query (ex.: página do GraphiQL) segue direto
+		if req.Query == "" && req.Extensions.PersistedQuery == nil {
+			restoreBody(r, body)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		query, err := m.Resolve(r.Context(), req.Query, req.Extensions.PersistedQuery)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if query == req.Query {
+			restoreBody(r, body)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		req.Query = query
+		next.ServeHTTP(w, rewrite(r, req))
+	})
+}
+
+// readRequest - lê a request GraphQL; ok=false para formatos não suportados
+func readRequest(r *http.Request) (request, []byte, bool) {
+	var req request
+
+	if r.Method == http.MethodGet {
+		values := r.URL.Query()
+		req.Query = values.Get("query")
+		req.OperationName = values.Get("operationName")
+		if raw := values.Get("variables"); raw != "" {
+			json.Unmarshal([]byte(raw), &req.Variables)
+		}
+		if raw := values.Get("extensions"); raw != "" {
+			json.Unmarshal([]byte(raw), &req.Extensions)
+		}
+		return req, nil, true
+	}
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Method != http.MethodPost || contentType != "application/json" || r.Body == nil {
+		return req, nil, false
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return req, nil, false
+	}
+	restoreBody(r, body)
+	if err := json.Unmarshal(body, &req); err != nil {
+		return req, body, false
+	}
+	return req, body, true
+}
+
+func restoreBody(r *http.Request, body []byte) {
+	if body != nil {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+}
+
+// rewrite - nova request com o texto da query no lugar do hash
+func rewrite(r *http.Request, req request) *http.Request {
+	rewritten := r.Clone(r.Context())
+
+	if r.Method == http.MethodGet {
+		values := r.URL.Query()
+		values.Set("query", req.Query)
+		rewritten.URL.RawQuery = values.Encode()
+		return rewritten
+	}
+
+	body, _ := json.Marshal(req)
+	rewritten.Body = io.NopCloser(bytes.NewReader(body))
+	rewritten.ContentLength = int64(len(body))
+	rewritten.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return rewritten
+}
+
+// writeError - resposta no formato GraphQL; erros do protocolo levam extensions.code
+func writeError(w http.ResponseWriter, err error) {
+	formatted := gqlerrors.FormattedError{
+		Message:   err.Error(),
+		Locations: []location.SourceLocation{},
+	}
+
+	var protocolErr *Error
+	if errors.As(err, &protocolErr) {
+		formatted.Extensions = protocolErr.Extensions()
+	} else {
+		// Falha do store: não expõe detalhes internos
+		formatted.Message = "failed to load persisted query"
+		formatted.Extensions = map[string]interface{}{"code": "INTERNAL_ERROR"}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []gqlerrors.FormattedError{formatted},
+	})
+}
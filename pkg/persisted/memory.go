@@ -0,0 +1,110 @@
+package persisted
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// DefaultCacheSize - capacidade padrão do MemoryStore
+const DefaultCacheSize = 1000
+
+// MemoryStore - store LRU em memória; as queries menos usadas são descartadas
+// ao atingir a capacidade (no modo APQ o cliente simplesmente reenvia o texto)
+type MemoryStore struct {
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type memoryEntry struct {
+	hash  string
+	query string
+}
+
+// NewMemoryStore - capacity <= 0 usa DefaultCacheSize
+func NewMemoryStore(capacity int) *MemoryStore {
+	if capacity <= 0 {
+		capacity = DefaultCacheSize
+	}
+	return &MemoryStore{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get - busca e marca a entrada como usada recentemente
+func (s *MemoryStore) Get(_ context.Context, hash string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[hash]
+	if !ok {
+		return "", false, nil
+	}
+	s.order.MoveToFront(element)
+	return element.Value.(*memoryEntry).query, true, nil
+}
+
+// Put - registra a entrada, descartando a menos usada se necessário
+func (s *MemoryStore) Put(_ context.Context, hash, query string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[hash]; ok {
+		s.order.MoveToFront(element)
+		return nil
+	}
+
+	s.entries[hash] = s.order.PushFront(&memoryEntry{hash: hash, query: query})
+	if s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryEntry).hash)
+	}
+	return nil
+}
+
+// Len - número de queries em memória
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+// CachedStore - MemoryStore na frente de outro store (ex.: DBStore), evitando
+// uma consulta ao banco por request para as queries mais usadas
+type CachedStore struct {
+	cache   *MemoryStore
+	backing Store
+}
+
+// NewCachedStore - capacity <= 0 usa DefaultCacheSize
+func NewCachedStore(backing Store, capacity int) *CachedStore {
+	return &CachedStore{cache: NewMemoryStore(capacity), backing: backing}
+}
+
+// Get - busca no cache e, se ausente, no store de origem
+func (s *CachedStore) Get(ctx context.Context, hash string) (string, bool, error) {
+	if query, ok, _ := s.cache.Get(ctx, hash); ok {
+		return query, true, nil
+	}
+
+	query, ok, err := s.backing.Get(ctx, hash)
+	if err != nil || !ok {
+		return "", ok, err
+	}
+	s.cache.Put(ctx, hash, query)
+	return query, true, nil
+}
+
+// Put - grava no store de origem e no cache
+func (s *CachedStore) Put(ctx context.Context, hash, query string) error {
+	if err := s.backing.Put(ctx, hash, query); err != nil {
+		return err
+	}
+	return s.cache.Put(ctx, hash, query)
+}
@@ -0,0 +1,8 @@
+package persisted
+
+import "embed"
+
+// Migrations - migração da tabela persisted_queries (registrada pela aplicação)
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,7 @@
+-- Allowlist/cache de persisted queries (APQ_STORE=postgres)
+CREATE TABLE IF NOT EXISTS "persisted_queries" (
+    "hash" varchar(64),
+    "query" text NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("hash")
+);
@@ -0,0 +1,139 @@
+package persisted
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Version - versão do protocolo APQ suportada (extensions.persistedQuery.version)
+const Version = 1
+
+// Mode - modo de operação do Manager
+type Mode string
+
+const (
+	// ModeAPQ - queries desconhecidas são registradas quando o cliente envia hash + texto
+	ModeAPQ Mode = "apq"
+	// ModeAllowlist - apenas hashes pré-registrados (CLI) podem ser executados
+	ModeAllowlist Mode = "allowlist"
+)
+
+// Store - armazenamento hash -> texto da query
+type Store interface {
+	// Get - texto da query; ok=false quando o hash não está registrado
+	Get(ctx context.Context, hash string) (query string, ok bool, err error)
+	// Put - registra a query (registrar de novo o mesmo hash não é erro)
+	Put(ctx context.Context, hash, query string) error
+}
+
+// Hash - sha256 hexadecimal do texto exato da query
+func Hash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// Error - erro do protocolo com código em extensions.code
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Extensions - implementa gqlerrors.ExtendedError
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code}
+}
+
+// Mensagens seguem as usadas pelos clientes APQ (ex.: Apollo), que reenviam o texto ao receber PersistedQueryNotFound
+var (
+	ErrNotFound     = &Error{Code: "PERSISTED_QUERY_NOT_FOUND", Message: "PersistedQueryNotFound"}
+	ErrNotInList    = &Error{Code: "PERSISTED_QUERY_NOT_IN_LIST", Message: "PersistedQueryNotInList"}
+	ErrNotSupported = &Error{Code: "PERSISTED_QUERY_NOT_SUPPORTED", Message: "PersistedQueryNotSupported"}
+	ErrHashMismatch = &Error{Code: "PERSISTED_QUERY_HASH_MISMATCH", Message: "provided sha does not match query"}
+)
+
+// Extension - conteúdo de extensions.persistedQuery
+type Extension struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+// Manager - resolve hashes em queries e aplica o modo configurado
+type Manager struct {
+	store Store
+	mode  Mode
+}
+
+// NewManager - cria o manager; modo vazio usa ModeAPQ
+func NewManager(store Store, mode Mode) *Manager {
+	if mode == "" {
+		mode = ModeAPQ
+	}
+	return &Manager{store: store, mode: mode}
+}
+
+// Mode - modo configurado
+func (m *Manager) Mode() Mode {
+	return m.mode
+}
+
+// Resolve - devolve o texto a executar para a combinação query/extension recebida.
+// ext nil significa uma request comum (permitida apenas fora do modo allowlist).
+func (m *Manager) Resolve(ctx context.Context, query string, ext *Extension) (string, error) {
+	if ext == nil {
+		if m.mode == ModeAllowlist {
+			return query, m.Allowed(ctx, query)
+		}
+		return query, nil
+	}
+
+	if ext.Version != Version {
+		return "", ErrNotSupported
+	}
+	hash := strings.ToLower(ext.Sha256Hash)
+
+	// Só o hash: busca o texto registrado
+	if query == "" {
+		stored, ok, err := m.store.Get(ctx, hash)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			if m.mode == ModeAllowlist {
+				return "", ErrNotInList
+			}
+			return "", ErrNotFound
+		}
+		return stored, nil
+	}
+
+	// Hash + texto: confere o hash e registra (APQ) ou exige registro prévio (allowlist)
+	if Hash(query) != hash {
+		return "", ErrHashMismatch
+	}
+	if m.mode == ModeAllowlist {
+		return query, m.Allowed(ctx, query)
+	}
+	if err := m.store.Put(ctx, hash, query); err != nil {
+		return "", err
+	}
+	return query, nil
+}
+
+// Allowed - no modo allowlist, exige que o hash do texto esteja registrado
+func (m *Manager) Allowed(ctx context.Context, query string) error {
+	if m.mode != ModeAllowlist {
+		return nil
+	}
+	_, ok, err := m.store.Get(ctx, Hash(query))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotInList
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package persisted_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/persisted"
+)
+
+const pingQuery = "{ ping }"
+
+func newServer(t *testing.T, manager *persisted.Manager) *httptest.Server {
+	t.Helper()
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"ping": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "pong", nil
+					},
+				},
+			},
+		}),
+	})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(manager.Handler(handler.New(&handler.Config{Schema: &schema})))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// post - envia a request e retorna a resposta decodificada
+func post(t *testing.T, server *httptest.Server, body map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	content, _ := json.Marshal(body)
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(string(content)))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	return result
+}
+
+func persistedExt(hash string) map[string]interface{} {
+	return map[string]interface{}{
+		"persistedQuery": map[string]interface{}{"version": 1, "sha256Hash": hash},
+	}
+}
+
+func errorCode(result map[string]interface{}) string {
+	errs, _ := result["errors"].([]interface{})
+	if len(errs) == 0 {
+		return ""
+	}
+	extensions, _ := errs[0].(map[string]interface{})["extensions"].(map[string]interface{})
+	code, _ := extensions["code"].(string)
+	return code
+}
+
+func TestHandler_APQ(t *testing.T) {
+	server := newServer(t, persisted.NewManager(persisted.NewMemoryStore(10), persisted.ModeAPQ))
+	hash := persisted.Hash(pingQuery)
+	pong := map[string]interface{}{"ping": "pong"}
+
+	// 1. Só o hash: desconhecido
+	result := post(t, server, map[string]interface{}{"extensions": persistedExt(hash)})
+	assert.Equal(t, "PERSISTED_QUERY_NOT_FOUND", errorCode(result))
+
+	// 2. Hash + texto: executa e registra
+	result = post(t, server, map[string]interface{}{"query": pingQuery, "extensions": persistedExt(hash)})
+	assert.Equal(t, pong, result["data"])
+
+	// 3. Só o hash: executa a query registrada (POST e GET)
+	result = post(t, server, map[string]interface{}{"extensions": persistedExt(hash)})
+	assert.Equal(t, pong, result["data"])
+
+	ext, _ := json.Marshal(persistedExt(hash))
+	resp, err := http.Get(server.URL + "?extensions=" + url.QueryEscape(string(ext)))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Equal(t, pong, result["data"])
+
+	// Hash que não confere com o texto
+	result = post(t, server, map[string]interface{}{"query": "{ __typename }", "extensions": persistedExt(hash)})
+	assert.Equal(t, "PERSISTED_QUERY_HASH_MISMATCH", errorCode(result))
+
+	// Requests comuns continuam funcionando
+	result = post(t, server, map[string]interface{}{"query": pingQuery})
+	assert.Equal(t, pong, result["data"])
+}
+
+func TestHandler_Allowlist(t *testing.T) {
+	store := persisted.NewMemoryStore(10)
+	require.NoError(t, store.Put(context.Background(), persisted.Hash(pingQuery), pingQuery))
+	server := newServer(t, persisted.NewManager(store, persisted.ModeAllowlist))
+
+	// Registradas: por hash ou pelo texto
+	result := post(t, server, map[string]interface{}{"extensions": persistedExt(persisted.Hash(pingQuery))})
+	assert.Equal(t, map[string]interface{}{"ping": "pong"}, result["data"])
+	result = post(t, server, map[string]interface{}{"query": pingQuery})
+	assert.Equal(t, map[string]interface{}{"ping": "pong"}, result["data"])
+
+	// Não registradas: nem por hash + texto, nem pelo texto
+	other := "{ __typename }"
+	result = post(t, server, map[string]interface{}{"query": other, "extensions": persistedExt(persisted.Hash(other))})
+	assert.Equal(t, "PERSISTED_QUERY_NOT_IN_LIST", errorCode(result))
+	result = post(t, server, map[string]interface{}{"query": other})
+	assert.Equal(t, "PERSISTED_QUERY_NOT_IN_LIST", errorCode(result))
+	assert.Nil(t, result["data"])
+
+	// Allowlist não registra nada
+	_, ok, _ := store.Get(context.Background(), persisted.Hash(other))
+	assert.False(t, ok)
+}
+
+func TestMemoryStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	store := persisted.NewMemoryStore(2)
+
+	store.Put(ctx, "a", "A")
+	store.Put(ctx, "b", "B")
+	store.Get(ctx, "a") // "b" passa a ser o menos usado
+	store.Put(ctx, "c", "C")
+
+	_, ok, _ := store.Get(ctx, "b")
+	assert.False(t, ok)
+	query, ok, _ := store.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, "A", query)
+	assert.Equal(t, 2, store.Len())
+}
+
+func TestReadDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "users"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "users", "list.graphql"), []byte("\nquery Users { users { edges { node { id } } } }\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ping.graphql"), []byte(pingQuery), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignorado"), 0644))
+
+	entries, err := persisted.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "ping.graphql", entries[0].File)
+	assert.Equal(t, "users/list.graphql", entries[1].File)
+	// O hash considera o texto sem espaços nas pontas
+	assert.Equal(t, persisted.Hash("query Users { users { edges { node { id } } } }"), entries[1].Hash)
+
+	store := persisted.NewMemoryStore(10)
+	require.NoError(t, persisted.Register(context.Background(), store, entries))
+	assert.Equal(t, 2, store.Len())
+}
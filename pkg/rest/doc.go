@@ -0,0 +1,49 @@
+// Package rest expõe os services dos módulos como API REST/JSON sobre o Gin
+// e gera a especificação OpenAPI 3 a partir das próprias definições de rota.
+//
+// Este pacote fornece:
+//   - Route: método, path, parâmetros, tipos de corpo/resposta e handler
+//   - API: registra as rotas em um grupo do Gin (ex.: /api/v1) e guarda as definições
+//   - ErrorResponse: formato único de erro, com status de errors.HTTPStatus
+//   - OpenAPI/ServeSpec: documento gerado por reflexão dos tipos Go
+//   - Helpers: PathID, Bind, Page (paginação por cursor) e PageParams
+//
+// # Erros
+//
+// O handler retorna (resultado, erro). Erros de pkg/errors viram
+//
+//	{"error": {"code": "NOT_FOUND", "message": "...", "fields": [...]}}
+//
+// com o status correspondente ao código. Erros internos recebem
+// correlationId (registrado no log) e, em produção, mensagem genérica.
+//
+// # Especificação
+//
+// Paths do Gin (":id") viram parâmetros OpenAPI ("{id}"); structs nomeadas
+// viram components (genéricos como Connection[*User] viram "UserConnection").
+// As tags json definem os nomes e as tags validate viram restrições
+// (required, enum, minLength, format email/uri...).
+//
+// # Exemplo de Uso
+//
+//	api := rest.NewAPI(router.Group("/api/v1"), rest.Config{Title: "LabEnd API", Version: "1.0.0"})
+//	api.Handle(rest.Route{
+//		Method:      http.MethodGet,
+//		Path:        "/users/:id",
+//		OperationID: "getUser",
+//		Params:      []rest.Param{rest.IDParam("id", "ID do usuário")},
+//		Response:    users.User{},
+//		Handler: func(c *gin.Context) (interface{}, error) {
+//			id, err := rest.PathID(c, "id")
+//			if err != nil {
+//				return nil, err
+//			}
+//			return service.GetUser(c.Request.Context(), id)
+//		},
+//	})
+//	api.ServeSpec("/openapi.json")
+//
+// # Thread Safety
+//
+// Registre as rotas na inicialização; depois disso a API só é lida.
+package rest
@@ -0,0 +1,355 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenAPIVersion - versão da especificação gerada
+const OpenAPIVersion = "3.0.3"
+
+// Document - documento OpenAPI 3 (apenas o subconjunto usado pela API)
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Servers    []Server                         `json:"servers,omitempty"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema - JSON Schema no dialeto do OpenAPI 3.0
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+}
+
+// OpenAPI - gera o documento a partir das rotas registradas
+func (a *API) OpenAPI() *Document {
+	gen := &schemaGenerator{schemas: map[string]*Schema{}}
+	doc := &Document{
+		OpenAPI:    OpenAPIVersion,
+		Info:       Info{Title: a.config.Title, Version: a.config.Version},
+		Servers:    []Server{{URL: a.group.BasePath()}},
+		Paths:      map[string]map[string]*Operation{},
+		Components: Components{Schemas: gen.schemas},
+	}
+	errorSchema := gen.schemaFor(reflect.TypeOf(ErrorResponse{}))
+
+	for _, route := range a.routes {
+		path := openAPIPath(route.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]*Operation{}
+		}
+
+		op := &Operation{
+			OperationID: route.OperationID,
+			Summary:     route.Summary,
+			Tags:        route.Tags,
+			Parameters:  parameters(route),
+			Responses: map[string]*Response{
+				"default": {
+					Description: "Erro",
+					Content:     jsonContent(errorSchema),
+				},
+			},
+		}
+		if route.Body != nil {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  jsonContent(gen.schemaFor(reflect.TypeOf(route.Body))),
+			}
+		}
+
+		success := &Response{Description: http.StatusText(route.status())}
+		if route.Response != nil {
+			success.Content = jsonContent(gen.schemaFor(reflect.TypeOf(route.Response)))
+		}
+		op.Responses[strconv.Itoa(route.status())] = success
+
+		doc.Paths[path][strings.ToLower(route.Method)] = op
+	}
+	return doc
+}
+
+var ginParamPattern = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// openAPIPath - "/users/:id" -> "/users/{id}"
+func openAPIPath(path string) string {
+	return ginParamPattern.ReplaceAllString(path, "{$1}")
+}
+
+// parameters - parâmetros declarados mais os de path que não foram declarados
+func parameters(route Route) []Parameter {
+	declared := map[string]bool{}
+	var params []Parameter
+	for _, p := range route.Params {
+		declared[p.Name] = true
+		params = append(params, toParameter(p))
+	}
+	for _, match := range ginParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		if !declared[match[1]] {
+			params = append(params, toParameter(Param{Name: match[1], In: "path", Required: true}))
+		}
+	}
+	return params
+}
+
+func toParameter(p Param) Parameter {
+	typ := p.Type
+	if typ == "" {
+		typ = "string"
+	}
+	return Parameter{
+		Name:        p.Name,
+		In:          p.In,
+		Description: p.Description,
+		Required:    p.Required || p.In == "path",
+		Schema:      &Schema{Type: typ},
+	}
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// schemaGenerator - converte tipos Go em schemas; structs nomeadas viram
+// components referenciados por $ref (o que também resolve tipos recursivos)
+type schemaGenerator struct {
+	schemas map[string]*Schema
+}
+
+func (g *schemaGenerator) schemaFor(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time", Nullable: nullable}
+	}
+	if t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType) {
+		// Serialização própria: o formato não é dedutível pela estrutura
+		return &Schema{Nullable: nullable}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean", Nullable: nullable}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32", Nullable: nullable}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Nullable: nullable}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Nullable: nullable}
+	case reflect.String:
+		return &Schema{Type: "string", Nullable: nullable}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte", Nullable: nullable}
+		}
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem()), Nullable: nullable}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem()), Nullable: nullable}
+	case reflect.Struct:
+		name := componentName(t)
+		if name == "" {
+			return g.structSchema(t)
+		}
+		if _, exists := g.schemas[name]; !exists {
+			g.schemas[name] = &Schema{} // reservado antes de descer nos campos
+			*g.schemas[name] = *g.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	return &Schema{}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.addFields(schema, t)
+	return schema
+}
+
+// addFields - campos exportados pela tag json; structs embutidas sem nome são achatadas
+func (g *schemaGenerator) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.SplitN(tag, ",", 2)[0]
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.addFields(schema, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schemaFor(field.Type)
+		if applyConstraints(property, field.Type, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// applyConstraints - traduz as tags validate em restrições do schema;
+// retorna true quando o campo é obrigatório
+func applyConstraints(schema *Schema, t reflect.Type, tag string) bool {
+	required := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			// Regras seguintes valem para os itens
+			return required
+		case "required":
+			required = true
+		case "email":
+			schema.Format = "email"
+		case "url":
+			schema.Format = "uri"
+		case "numeric":
+			schema.Pattern = "^[0-9]+$"
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		case "min", "max":
+			setBound(schema, t.Kind(), name == "min", param)
+		}
+	}
+	return required
+}
+
+func setBound(schema *Schema, kind reflect.Kind, min bool, param string) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	n := int(value)
+	switch kind {
+	case reflect.String:
+		if min {
+			schema.MinLength = &n
+		} else {
+			schema.MaxLength = &n
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if min {
+			schema.MinItems = &n
+		} else {
+			schema.MaxItems = &n
+		}
+	default:
+		if min {
+			schema.Minimum = &value
+		} else {
+			schema.Maximum = &value
+		}
+	}
+}
+
+// componentName - nome do component; genéricos viram argumentos + base
+// ("Connection[*pkg.User]" -> "UserConnection"); structs anônimas ficam inline
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	open := strings.Index(name, "[")
+	if open < 0 {
+		return name
+	}
+
+	base := name[:open]
+	var prefix strings.Builder
+	for _, arg := range strings.Split(name[open+1:len(name)-1], ",") {
+		arg = strings.TrimLeft(strings.TrimSpace(arg), "*[]")
+		if dot := strings.LastIndex(arg, "."); dot >= 0 {
+			arg = arg[dot+1:]
+		}
+		prefix.WriteString(arg)
+	}
+	return prefix.String() + base
+}
@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
+)
+
+// PageParams - parâmetros de paginação por cursor (mesmos do GraphQL)
+var PageParams = []Param{
+	{Name: "first", In: "query", Type: "integer", Description: "Itens após o cursor after"},
+	{Name: "after", In: "query", Description: "Cursor de início (exclusivo)"},
+	{Name: "last", In: "query", Type: "integer", Description: "Itens antes do cursor before"},
+	{Name: "before", In: "query", Description: "Cursor de fim (exclusivo)"},
+}
+
+// IDParam - parâmetro de path numérico obrigatório
+func IDParam(name, description string) Param {
+	return Param{Name: name, In: "path", Type: "integer", Required: true, Description: description}
+}
+
+// PathID - lê um parâmetro de path numérico
+func PathID(c *gin.Context, name string) (uint, error) {
+	value, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		return 0, errors.InvalidInput(fmt.Sprintf("ID inválido: %s", c.Param(name)))
+	}
+	return uint(value), nil
+}
+
+// Bind - decodifica o corpo JSON; a validação das tags fica com o service
+func Bind(c *gin.Context, target interface{}) error {
+	if err := json.NewDecoder(c.Request.Body).Decode(target); err != nil {
+		return errors.InvalidInput(fmt.Sprintf("corpo JSON inválido: %v", err))
+	}
+	return nil
+}
+
+// Page - lê first/after/last/before da query string
+func Page(c *gin.Context) (pagination.Args, error) {
+	args := pagination.Args{
+		After:  c.Query("after"),
+		Before: c.Query("before"),
+	}
+	for name, target := range map[string]**int{"first": &args.First, "last": &args.Last} {
+		raw, ok := c.GetQuery(name)
+		if !ok {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return pagination.Args{}, errors.Validation(errors.FieldError{Field: name, Message: "must be an integer"})
+		}
+		*target = &value
+	}
+	return args, nil
+}
@@ -0,0 +1,146 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/logger"
+)
+
+// InternalMessage - mensagem exibida no lugar de erros internos em produção
+const InternalMessage = "internal server error"
+
+// HandlerFunc - handler de uma rota; o resultado é serializado em JSON e o
+// erro vira ErrorResponse com o status de errors.HTTPStatus
+type HandlerFunc func(c *gin.Context) (interface{}, error)
+
+// Param - parâmetro de path ou query documentado na especificação
+type Param struct {
+	Name        string
+	In          string // "path" ou "query"
+	Type        string // tipo OpenAPI: "string" (padrão), "integer", "boolean"
+	Required    bool
+	Description string
+}
+
+// Route - definição de um endpoint: registra o handler no Gin e descreve a
+// operação no documento OpenAPI
+type Route struct {
+	Method      string
+	Path        string // formato Gin, relativo ao grupo (ex.: "/users/:id")
+	OperationID string
+	Summary     string
+	Tags        []string
+	Params      []Param
+	// Body - valor de exemplo do tipo do corpo (ex.: CreateUserInput{}); nil sem corpo
+	Body interface{}
+	// Response - valor de exemplo do tipo da resposta; nil para respostas vazias
+	Response interface{}
+	// Status - status de sucesso (padrão 200)
+	Status  int
+	Handler HandlerFunc
+}
+
+func (r Route) status() int {
+	if r.Status == 0 {
+		return http.StatusOK
+	}
+	return r.Status
+}
+
+// ErrorBody - erro no formato comum da API REST
+type ErrorBody struct {
+	Code          string              `json:"code"`
+	Message       string              `json:"message"`
+	Fields        []errors.FieldError `json:"fields,omitempty"`
+	CorrelationID string              `json:"correlationId,omitempty"`
+}
+
+// ErrorResponse - corpo de toda resposta de erro
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// Config - configuração da API
+type Config struct {
+	Title   string
+	Version string
+	// Production oculta a mensagem de erros internos (fica só o correlationId)
+	Production bool
+	Logger     logger.Logger
+}
+
+// API - conjunto de rotas REST sob um grupo do Gin (ex.: /api/v1)
+type API struct {
+	group  *gin.RouterGroup
+	config Config
+	routes []Route
+}
+
+// NewAPI - cria a API sobre o grupo informado
+func NewAPI(group *gin.RouterGroup, config Config) *API {
+	return &API{group: group, config: config}
+}
+
+// Handle - registra as rotas no Gin e na especificação
+func (a *API) Handle(routes ...Route) {
+	for _, route := range routes {
+		route := route
+		a.group.Handle(route.Method, route.Path, func(c *gin.Context) {
+			result, err := route.Handler(c)
+			if err != nil {
+				a.WriteError(c, err)
+				return
+			}
+			if result == nil || route.status() == http.StatusNoContent {
+				c.Status(route.status())
+				return
+			}
+			c.JSON(route.status(), result)
+		})
+		a.routes = append(a.routes, route)
+	}
+}
+
+// Routes - rotas registradas, na ordem de registro
+func (a *API) Routes() []Route {
+	return a.routes
+}
+
+// ServeSpec - publica o documento OpenAPI em JSON no caminho informado
+func (a *API) ServeSpec(path string) {
+	a.group.GET(path, func(c *gin.Context) {
+		c.JSON(http.StatusOK, a.OpenAPI())
+	})
+}
+
+// WriteError - responde com ErrorResponse; erros internos recebem um
+// correlationId registrado no log e, em produção, mensagem genérica
+func (a *API) WriteError(c *gin.Context, err error) {
+	body := ErrorBody{
+		Code:    errors.Code(err),
+		Message: errors.PublicMessage(err),
+		Fields:  errors.Fields(err),
+	}
+
+	if body.Code == errors.CodeInternal {
+		body.CorrelationID = errors.NewCorrelationID()
+		if a.config.Logger != nil {
+			a.config.Logger.Error("rest internal error",
+				zap.String("correlation_id", body.CorrelationID),
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.FullPath()),
+				zap.Error(err))
+		}
+		if a.config.Production {
+			body.Message = InternalMessage
+		} else {
+			body.Message = err.Error()
+		}
+	}
+
+	c.AbortWithStatusJSON(errors.HTTPStatus(err), ErrorResponse{Error: body})
+}
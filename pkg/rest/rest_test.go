@@ -0,0 +1,208 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/pagination"
+	"github.com/rafaelcoelhox/labbend/pkg/rest"
+)
+
+type item struct {
+	ID       uint    `json:"id"`
+	Name     string  `json:"name"`
+	Parent   *item   `json:"parent,omitempty"`
+	Internal string  `json:"-"`
+	Score    float64 `json:"score"`
+}
+
+type createItemInput struct {
+	Name  string   `json:"name" validate:"required,min=2"`
+	Kind  string   `json:"kind" validate:"omitempty,oneof=a b"`
+	Email string   `json:"email" validate:"required,email"`
+	Tags  []string `json:"tags" validate:"max=3,dive,min=1"`
+}
+
+func newTestAPI(production bool) (*gin.Engine, *rest.API) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := rest.NewAPI(router.Group("/api/v1"), rest.Config{Title: "Test", Version: "1.0.0", Production: production})
+
+	api.Handle(
+		rest.Route{
+			Method:      http.MethodGet,
+			Path:        "/items/:id",
+			OperationID: "getItem",
+			Params:      []rest.Param{rest.IDParam("id", "ID do item")},
+			Response:    item{},
+			Handler: func(c *gin.Context) (interface{}, error) {
+				id, err := rest.PathID(c, "id")
+				if err != nil {
+					return nil, err
+				}
+				switch id {
+				case 1:
+					return item{ID: 1, Name: "um"}, nil
+				case 2:
+					return nil, errors.Internal(errors.Wrap(errors.ErrInternal, "pq: connection refused"))
+				}
+				return nil, errors.NotFound("item", id)
+			},
+		},
+		rest.Route{
+			Method:      http.MethodPost,
+			Path:        "/items",
+			OperationID: "createItem",
+			Body:        createItemInput{},
+			Response:    item{},
+			Status:      http.StatusCreated,
+			Handler: func(c *gin.Context) (interface{}, error) {
+				var input createItemInput
+				if err := rest.Bind(c, &input); err != nil {
+					return nil, err
+				}
+				return nil, errors.Validation(errors.FieldError{Field: "email", Message: "must be a valid email"})
+			},
+		},
+		rest.Route{
+			Method:   http.MethodGet,
+			Path:     "/items",
+			Params:   rest.PageParams,
+			Response: pagination.Connection[*item]{},
+			Handler: func(c *gin.Context) (interface{}, error) {
+				args, err := rest.Page(c)
+				if err != nil {
+					return nil, err
+				}
+				return gin.H{"first": *args.First}, nil
+			},
+		},
+		rest.Route{
+			Method: http.MethodDelete,
+			Path:   "/items/:id",
+			Status: http.StatusNoContent,
+			Handler: func(c *gin.Context) (interface{}, error) {
+				return nil, nil
+			},
+		},
+	)
+	api.ServeSpec("/openapi.json")
+	return router, api
+}
+
+func do(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPI_Responses(t *testing.T) {
+	router, _ := newTestAPI(true)
+
+	w := do(router, http.MethodGet, "/api/v1/items/1", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":1,"name":"um","score":0}`, w.Body.String())
+
+	w = do(router, http.MethodGet, "/api/v1/items/9", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error":{"code":"NOT_FOUND","message":"item with id 9 not found"}}`, w.Body.String())
+
+	w = do(router, http.MethodGet, "/api/v1/items/abc", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"INVALID_INPUT"`)
+
+	w = do(router, http.MethodPost, "/api/v1/items", `{"name": "x"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":{"code":"INVALID_INPUT","message":"validation failed","fields":[{"field":"email","message":"must be a valid email"}]}}`, w.Body.String())
+
+	w = do(router, http.MethodPost, "/api/v1/items", `{"name":`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(router, http.MethodGet, "/api/v1/items?first=x", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"first"`)
+
+	w = do(router, http.MethodGet, "/api/v1/items?first=5", "")
+	assert.JSONEq(t, `{"first":5}`, w.Body.String())
+
+	w = do(router, http.MethodDelete, "/api/v1/items/1", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestAPI_InternalErrors(t *testing.T) {
+	var resp rest.ErrorResponse
+
+	router, _ := newTestAPI(true)
+	w := do(router, http.MethodGet, "/api/v1/items/2", "")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, errors.CodeInternal, resp.Error.Code)
+	assert.Equal(t, rest.InternalMessage, resp.Error.Message)
+	assert.NotEmpty(t, resp.Error.CorrelationID)
+
+	router, _ = newTestAPI(false)
+	w = do(router, http.MethodGet, "/api/v1/items/2", "")
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Contains(t, resp.Error.Message, "pq: connection refused")
+}
+
+func TestAPI_OpenAPI(t *testing.T) {
+	router, api := newTestAPI(true)
+
+	doc := api.OpenAPI()
+	assert.Equal(t, rest.OpenAPIVersion, doc.OpenAPI)
+	assert.Equal(t, "/api/v1", doc.Servers[0].URL)
+
+	get := doc.Paths["/items/{id}"]["get"]
+	require.NotNil(t, get)
+	assert.Equal(t, "getItem", get.OperationID)
+	assert.Equal(t, "path", get.Parameters[0].In)
+	assert.True(t, get.Parameters[0].Required)
+	assert.Equal(t, "integer", get.Parameters[0].Schema.Type)
+	assert.Equal(t, "#/components/schemas/item", get.Responses["200"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/ErrorResponse", get.Responses["default"].Content["application/json"].Schema.Ref)
+
+	// Parâmetros de path não declarados são inferidos
+	del := doc.Paths["/items/{id}"]["delete"]
+	require.Len(t, del.Parameters, 1)
+	assert.Equal(t, "id", del.Parameters[0].Name)
+	assert.Nil(t, del.Responses["204"].Content)
+
+	// Tags validate viram restrições
+	input := doc.Components.Schemas["createItemInput"]
+	require.NotNil(t, input)
+	assert.Equal(t, []string{"name", "email"}, input.Required)
+	assert.Equal(t, 2, *input.Properties["name"].MinLength)
+	assert.Equal(t, []string{"a", "b"}, input.Properties["kind"].Enum)
+	assert.Equal(t, "email", input.Properties["email"].Format)
+	assert.Equal(t, 3, *input.Properties["tags"].MaxItems)
+
+	// Tipos recursivos e json:"-"
+	itemSchema := doc.Components.Schemas["item"]
+	assert.Equal(t, "#/components/schemas/item", itemSchema.Properties["parent"].Ref)
+	assert.NotContains(t, itemSchema.Properties, "Internal")
+	assert.Equal(t, "number", itemSchema.Properties["score"].Type)
+
+	// Genéricos recebem nomes legíveis
+	assert.Contains(t, doc.Components.Schemas, "itemConnection")
+	assert.Contains(t, doc.Components.Schemas, "itemEdge")
+
+	// Documento servido pela própria API
+	w := do(router, http.MethodGet, "/api/v1/openapi.json", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var served map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &served))
+	assert.Equal(t, rest.OpenAPIVersion, served["openapi"])
+	assert.Contains(t, served["paths"], "/items/{id}")
+}
@@ -3,6 +3,8 @@ package saga
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/rafaelcoelhox/labbend/pkg/logger"
 
@@ -216,7 +218,9 @@ func (sb *StepBuilder) Add() *SagaBuilder {
 // SagaManager - gerenciador de sagas em execução
 type SagaManager struct {
 	logger       logger.Logger
+	mu           sync.RWMutex
 	runningSagas map[string]*Saga
+	sequence     uint64
 }
 
 // NewSagaManager - cria novo gerenciador
@@ -229,16 +233,20 @@ func NewSagaManager(logger logger.Logger) *SagaManager {
 
 // ExecuteSaga - executa saga com tracking
 func (sm *SagaManager) ExecuteSaga(ctx context.Context, saga *Saga) error {
-	sagaID := fmt.Sprintf("%s-%d", saga.name, len(sm.runningSagas))
+	sagaID := fmt.Sprintf("%s-%d", saga.name, atomic.AddUint64(&sm.sequence, 1))
 
 	sm.logger.Info("registering saga for execution",
 		zap.String("saga_id", sagaID),
 		zap.String("saga_name", saga.name))
 
+	sm.mu.Lock()
 	sm.runningSagas[sagaID] = saga
+	sm.mu.Unlock()
 
 	defer func() {
+		sm.mu.Lock()
 		delete(sm.runningSagas, sagaID)
+		sm.mu.Unlock()
 		sm.logger.Info("saga execution finished",
 			zap.String("saga_id", sagaID),
 			zap.String("saga_name", saga.name))
@@ -247,7 +255,14 @@ func (sm *SagaManager) ExecuteSaga(ctx context.Context, saga *Saga) error {
 	return saga.Execute(ctx)
 }
 
-// GetRunningSagas - retorna sagas em execução
+// GetRunningSagas - retorna cópia das sagas em execução
 func (sm *SagaManager) GetRunningSagas() map[string]*Saga {
-	return sm.runningSagas
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	running := make(map[string]*Saga, len(sm.runningSagas))
+	for id, saga := range sm.runningSagas {
+		running[id] = saga
+	}
+	return running
 }
@@ -0,0 +1,35 @@
+// Package validation avalia as tags `validate` das structs de entrada e
+// reporta todas as violações de uma vez como errors.Validation.
+//
+// Este pacote fornece:
+//   - Struct: valida com o validador padrão (go-playground/validator)
+//   - Nomes de campo pela tag json, com caminho para listas ("evidence[0].type")
+//   - Regras da aplicação: nickname, urlscheme=<schemes>, notblank
+//   - RegisterRule: regras customizadas com mensagem própria
+//
+// # Integração com pkg/errors
+//
+// O erro retornado é uma AppError com código INVALID_INPUT (errors.Is com
+// errors.ErrInvalidInput funciona) e Fields com cada violação; no GraphQL
+// elas chegam em extensions.fields via pkg/graphqlerrors.
+//
+// # Exemplo de Uso
+//
+//	type CreateUserInput struct {
+//		Name     string `json:"name" validate:"required,min=2"`
+//		Email    string `json:"email" validate:"required,email"`
+//		Nickname string `json:"nickname" validate:"required,nickname"`
+//	}
+//
+//	func (s *service) CreateUser(ctx context.Context, input CreateUserInput) (*User, error) {
+//		if err := validation.Struct(input); err != nil {
+//			return nil, err
+//		}
+//		// regras de negócio...
+//	}
+//
+// # Thread Safety
+//
+// Struct pode ser chamado concorrentemente; RegisterRule deve ser usado
+// apenas na inicialização.
+package validation
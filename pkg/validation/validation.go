@@ -0,0 +1,172 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+)
+
+// nicknamePattern - letras, dígitos, "_", "." e "-", de 2 a 32 caracteres
+var nicknamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{2,32}$`)
+
+// Rule - regra customizada; param é o texto após "=" na tag (ex.: "https http")
+type Rule func(field reflect.Value, param string) bool
+
+// Validator - avalia as tags `validate` das structs de entrada
+type Validator struct {
+	validate *validator.Validate
+	messages map[string]string
+}
+
+// New - cria o validador com as regras da aplicação (nickname, urlscheme, notblank)
+func New() *Validator {
+	v := &Validator{
+		validate: validator.New(),
+		messages: map[string]string{},
+	}
+
+	// Nomes dos campos seguem a tag json, como o cliente os envia
+	v.validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	v.MustRegisterRule("nickname", "must have 2 to 32 letters, digits, '_', '.' or '-'", func(field reflect.Value, _ string) bool {
+		return field.Kind() == reflect.String && nicknamePattern.MatchString(field.String())
+	})
+	v.MustRegisterRule("urlscheme", "must use one of the schemes: %s", func(field reflect.Value, param string) bool {
+		if field.Kind() != reflect.String {
+			return false
+		}
+		parsed, err := url.Parse(field.String())
+		if err != nil {
+			return false
+		}
+		for _, scheme := range strings.Fields(param) {
+			if strings.EqualFold(parsed.Scheme, scheme) {
+				return true
+			}
+		}
+		return false
+	})
+	v.MustRegisterRule("notblank", "cannot be blank", func(field reflect.Value, _ string) bool {
+		return field.Kind() == reflect.String && strings.TrimSpace(field.String()) != ""
+	})
+
+	return v
+}
+
+// RegisterRule - adiciona uma regra; message pode usar %s para o parâmetro da tag.
+// Não é seguro concorrer com validações: registre durante a inicialização
+func (v *Validator) RegisterRule(tag, message string, rule Rule) error {
+	err := v.validate.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+		return rule(fl.Field(), fl.Param())
+	})
+	if err != nil {
+		return err
+	}
+	v.messages[tag] = message
+	return nil
+}
+
+// MustRegisterRule - RegisterRule que entra em pânico em caso de erro (uso em init)
+func (v *Validator) MustRegisterRule(tag, message string, rule Rule) {
+	if err := v.RegisterRule(tag, message, rule); err != nil {
+		panic(fmt.Sprintf("validation: register rule %q: %v", tag, err))
+	}
+}
+
+// Struct - valida todos os campos e retorna errors.Validation com cada violação,
+// ou nil quando a entrada é válida
+func (v *Validator) Struct(input interface{}) error {
+	err := v.validate.Struct(input)
+	if err == nil {
+		return nil
+	}
+
+	var violations validator.ValidationErrors
+	if !errors.As(err, &violations) {
+		// Entrada que não é struct: erro de programação, não do cliente
+		return errors.Internal(err)
+	}
+
+	fields := make([]errors.FieldError, 0, len(violations))
+	for _, violation := range violations {
+		fields = append(fields, errors.FieldError{
+			Field:   fieldPath(violation.Namespace()),
+			Message: v.message(violation),
+		})
+	}
+	return errors.Validation(fields...)
+}
+
+// fieldPath - remove o nome da struct raiz: "CreateUserInput.email" -> "email"
+func fieldPath(namespace string) string {
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func (v *Validator) message(violation validator.FieldError) string {
+	param := violation.Param()
+	if message, ok := v.messages[violation.Tag()]; ok {
+		if strings.Contains(message, "%s") {
+			return fmt.Sprintf(message, strings.Join(strings.Fields(param), ", "))
+		}
+		return message
+	}
+
+	switch violation.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email"
+	case "url":
+		return "must be a valid URL"
+	case "numeric":
+		return "must be numeric"
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.Join(strings.Fields(param), ", "))
+	case "min":
+		return boundMessage(violation.Kind(), "at least", param)
+	case "max":
+		return boundMessage(violation.Kind(), "at most", param)
+	}
+	return fmt.Sprintf("failed the %q rule", violation.Tag())
+}
+
+// boundMessage - min/max dependem do tipo: tamanho de texto, itens ou valor
+func boundMessage(kind reflect.Kind, bound, param string) string {
+	switch kind {
+	case reflect.String:
+		return fmt.Sprintf("must have %s %s characters", bound, param)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("must have %s %s items", bound, param)
+	}
+	return fmt.Sprintf("must be %s %s", bound, param)
+}
+
+var std = New()
+
+// Struct - valida com o validador padrão da aplicação
+func Struct(input interface{}) error {
+	return std.Struct(input)
+}
+
+// RegisterRule - adiciona uma regra ao validador padrão
+func RegisterRule(tag, message string, rule Rule) error {
+	return std.RegisterRule(tag, message, rule)
+}
@@ -0,0 +1,90 @@
+package validation_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rafaelcoelhox/labbend/pkg/errors"
+	"github.com/rafaelcoelhox/labbend/pkg/validation"
+)
+
+type evidence struct {
+	Type string `json:"type" validate:"required,oneof=url text file"`
+}
+
+type signupInput struct {
+	Name     string     `json:"name" validate:"required,min=2"`
+	Email    string     `json:"email" validate:"required,email"`
+	Nickname string     `json:"nickname" validate:"required,nickname"`
+	Website  string     `json:"website" validate:"omitempty,url,urlscheme=https"`
+	Bio      *string    `json:"bio,omitempty" validate:"omitempty,notblank"`
+	Evidence []evidence `json:"evidence" validate:"max=2,dive"`
+}
+
+func TestStruct_Valid(t *testing.T) {
+	bio := "backend dev"
+	err := validation.Struct(signupInput{
+		Name:     "Ana",
+		Email:    "ana@example.com",
+		Nickname: "ana_dev",
+		Website:  "https://ana.dev",
+		Bio:      &bio,
+		Evidence: []evidence{{Type: "url"}},
+	})
+	assert.NoError(t, err)
+}
+
+func TestStruct_ReportsAllViolations(t *testing.T) {
+	blank := "   "
+	err := validation.Struct(signupInput{
+		Name:     "A",
+		Email:    "not-an-email",
+		Nickname: "ana dev!",
+		Website:  "ftp://ana.dev",
+		Bio:      &blank,
+		Evidence: []evidence{{Type: "url"}, {Type: "video"}},
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrInvalidInput))
+	assert.Equal(t, errors.CodeInvalidInput, errors.Code(err))
+
+	assert.Equal(t, []errors.FieldError{
+		{Field: "name", Message: "must have at least 2 characters"},
+		{Field: "email", Message: "must be a valid email"},
+		{Field: "nickname", Message: "must have 2 to 32 letters, digits, '_', '.' or '-'"},
+		{Field: "website", Message: "must use one of the schemes: https"},
+		{Field: "bio", Message: "cannot be blank"},
+		{Field: "evidence[1].type", Message: "must be one of: url, text, file"},
+	}, errors.Fields(err))
+}
+
+func TestStruct_SliceBounds(t *testing.T) {
+	err := validation.Struct(signupInput{
+		Name:     "Ana",
+		Email:    "ana@example.com",
+		Nickname: "ana",
+		Evidence: []evidence{{Type: "url"}, {Type: "url"}, {Type: "url"}},
+	})
+	assert.Equal(t, []errors.FieldError{
+		{Field: "evidence", Message: "must have at most 2 items"},
+	}, errors.Fields(err))
+}
+
+func TestValidator_RegisterRule(t *testing.T) {
+	v := validation.New()
+	require.NoError(t, v.RegisterRule("even", "must be even", func(field reflect.Value, _ string) bool {
+		return field.Int()%2 == 0
+	}))
+
+	type input struct {
+		Count int `json:"count" validate:"even"`
+	}
+	assert.NoError(t, v.Struct(input{Count: 4}))
+	assert.Equal(t, []errors.FieldError{{Field: "count", Message: "must be even"}}, errors.Fields(v.Struct(input{Count: 3})))
+
+	// Entrada que não é struct é erro interno, não do cliente
+	assert.Equal(t, errors.CodeInternal, errors.Code(v.Struct("x")))
+}